@@ -42,6 +42,26 @@ func NewBuilderFromSources(ctx context.Context, sources *solgo.Sources) (*Builde
 	}, nil
 }
 
+// NewBuilderFromCompactJSON initializes a new ABI builder from the compact AST produced by the
+// solc compiler, so that types come from the compiler instead of being resolved from the sources.
+func NewBuilderFromCompactJSON(ctx context.Context, sources *solgo.Sources, data []byte) (*Builder, error) {
+	parser, err := ir.NewBuilderFromCompactJSON(ctx, sources, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		ctx:        ctx,
+		sources:    sources,
+		parser:     parser,
+		astBuilder: parser.GetAstBuilder(),
+		resolver: &TypeResolver{
+			parser:         parser,
+			processedTypes: make(map[string]bool),
+		},
+	}, nil
+}
+
 // GetSources returns the source files being processed.
 func (b *Builder) GetSources() *solgo.Sources {
 	return b.sources
@@ -1,15 +1,19 @@
 package abi
 
 import (
+	"bytes"
 	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/unpackdev/solgo"
 	"github.com/unpackdev/solgo/ast"
 	"github.com/unpackdev/solgo/ir"
 	"github.com/unpackdev/solgo/tests"
+	"github.com/unpackdev/solgo/tests/solgotest"
 	"github.com/unpackdev/solgo/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -339,3 +343,42 @@ func buildFullPath(relativePath string) string {
 	absPath, _ := filepath.Abs(relativePath)
 	return absPath
 }
+
+func TestBuilderFromCompactJSON(t *testing.T) {
+	sources := solgotest.NewContractSourcesForTest(t, "ast/Lottery")
+
+	// Compiler prints headers in front of the source unit.
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(sources.SourceUnits[0].Path), "Lottery.solc.ast.json"))
+	require.NoError(t, err)
+
+	compactBuilder, err := NewBuilderFromCompactJSON(context.TODO(), sources, content[bytes.IndexByte(content, '{'):])
+	require.NoError(t, err)
+	require.Empty(t, compactBuilder.Parse())
+	require.NoError(t, compactBuilder.Build())
+
+	sourcesBuilder, err := NewBuilderFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+	require.Empty(t, sourcesBuilder.Parse())
+	require.NoError(t, sourcesBuilder.Build())
+
+	compactAbi, err := compactBuilder.ToABI(compactBuilder.GetEntryContract())
+	require.NoError(t, err)
+	sourcesAbi, err := sourcesBuilder.ToABI(sourcesBuilder.GetEntryContract())
+	require.NoError(t, err)
+
+	// ABI built from compiler resolved types has to match the one built from the sources.
+	require.NotEmpty(t, sourcesAbi.Methods)
+	assert.Equal(t, len(sourcesAbi.Methods), len(compactAbi.Methods))
+	for name, method := range sourcesAbi.Methods {
+		if assert.Contains(t, compactAbi.Methods, name) {
+			assert.Equal(t, method.Sig, compactAbi.Methods[name].Sig)
+		}
+	}
+	assert.Equal(t, len(sourcesAbi.Events), len(compactAbi.Events))
+	for name, event := range sourcesAbi.Events {
+		if assert.Contains(t, compactAbi.Events, name) {
+			assert.Equal(t, event.Sig, compactAbi.Events[name].Sig)
+		}
+	}
+	assert.Equal(t, len(sourcesAbi.Errors), len(compactAbi.Errors))
+}
@@ -16,6 +16,7 @@ type AndOperation struct {
 	Src              SrcNode            `json:"src"`
 	Expressions      []Node[NodeType]   `json:"expressions"`
 	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`
+	TypeDescription  *TypeDescription   `json:"typeDescription,omitempty"`
 }
 
 // NewAndOperationExpression creates a new AndOperation instance.
@@ -56,6 +57,9 @@ func (f *AndOperation) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description associated with the AndOperation.
 func (f *AndOperation) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return f.TypeDescriptions[0]
 }
 
@@ -126,6 +130,12 @@ func (f *AndOperation) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &f.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"github.com/goccy/go-json"
+
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
 )
 
@@ -16,6 +18,8 @@ type BaseContract struct {
 	Src SrcNode `json:"src"`
 	// BaseName is the name of the base contract.
 	BaseName *BaseContractName `json:"baseName"`
+	// Arguments are the base constructor arguments passed in the inheritance specifier, such as `is ERC20("Name", "SYM")`.
+	Arguments []Node[NodeType] `json:"arguments,omitempty"`
 }
 
 // GetId returns the unique identifier of the base contract.
@@ -39,6 +43,70 @@ func (b *BaseContract) GetBaseName() *BaseContractName {
 	return b.BaseName
 }
 
+// GetArguments returns the base constructor arguments passed in the inheritance specifier.
+func (b *BaseContract) GetArguments() []Node[NodeType] {
+	return b.Arguments
+}
+
+// UnmarshalJSON unmarshals the JSON data into the BaseContract.
+func (b *BaseContract) UnmarshalJSON(data []byte) error {
+	var tempMap map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tempMap); err != nil {
+		return err
+	}
+
+	if id, ok := tempMap["id"]; ok {
+		if err := json.Unmarshal(id, &b.Id); err != nil {
+			return err
+		}
+	}
+
+	if nodeType, ok := tempMap["nodeType"]; ok {
+		if err := json.Unmarshal(nodeType, &b.NodeType); err != nil {
+			return err
+		}
+	}
+
+	if src, ok := tempMap["src"]; ok {
+		if err := json.Unmarshal(src, &b.Src); err != nil {
+			return err
+		}
+	}
+
+	if baseName, ok := tempMap["baseName"]; ok {
+		if err := json.Unmarshal(baseName, &b.BaseName); err != nil {
+			return err
+		}
+	}
+
+	if arguments, ok := tempMap["arguments"]; ok {
+		var nodes []json.RawMessage
+		if err := json.Unmarshal(arguments, &nodes); err != nil {
+			return err
+		}
+
+		for _, tempNode := range nodes {
+			var tempNodeMap map[string]json.RawMessage
+			if err := json.Unmarshal(tempNode, &tempNodeMap); err != nil {
+				return err
+			}
+
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(tempNode, tempNodeType)
+			if err != nil {
+				return err
+			}
+			b.Arguments = append(b.Arguments, node)
+		}
+	}
+
+	return nil
+}
+
 // ToProto returns the protobuf representation of the base contract.
 func (b *BaseContract) ToProto() *ast_pb.BaseContract {
 	return &ast_pb.BaseContract{
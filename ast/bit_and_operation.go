@@ -17,6 +17,7 @@ type BitAndOperation struct {
 	Src              SrcNode            `json:"src"`
 	Expressions      []Node[NodeType]   `json:"expressions"`
 	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`
+	TypeDescription  *TypeDescription   `json:"typeDescription,omitempty"`
 }
 
 // NewBitAndOperationExpression creates a new BitAndOperation instance.
@@ -52,6 +53,9 @@ func (f *BitAndOperation) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description associated with the BitAndOperation.
 func (f *BitAndOperation) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return f.TypeDescriptions[0]
 }
 
@@ -123,6 +127,12 @@ func (b *BitAndOperation) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &b.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -17,6 +17,7 @@ type BitOrOperation struct {
 	Src              SrcNode            `json:"src"`
 	Expressions      []Node[NodeType]   `json:"expressions"`
 	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`
+	TypeDescription  *TypeDescription   `json:"typeDescription,omitempty"`
 }
 
 // NewBitOrOperationExpression creates a new BitOrOperation instance.
@@ -52,6 +53,9 @@ func (f *BitOrOperation) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description associated with the BitOrOperation.
 func (f *BitOrOperation) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return f.TypeDescriptions[0]
 }
 
@@ -123,6 +127,12 @@ func (b *BitOrOperation) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &b.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
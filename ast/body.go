@@ -281,11 +281,7 @@ func (b *BodyNode) ParseBlock(
 	// This is a basic approach and it's not 100% correct, but it's good enough for now.
 	b.Implemented = len(bodyCtx.GetChildren()) > 0
 
-	for _, statementCtx := range bodyCtx.AllStatement() {
-		for _, child := range statementCtx.GetChildren() {
-			b.parseStatements(unit, contractNode, parentNode, child)
-		}
-	}
+	b.parseBlockChildren(unit, contractNode, parentNode, bodyCtx)
 
 	return b
 }
@@ -311,15 +307,65 @@ func (b *BodyNode) ParseUncheckedBlock(
 		ParentIndex: contractNode.GetId(),
 	}
 
-	for _, statementCtx := range bodyCtx.Block().AllStatement() {
-		for _, child := range statementCtx.GetChildren() {
-			b.parseStatements(unit, contractNode, fnNode, child)
-		}
+	b.parseBlockChildren(unit, contractNode, fnNode, bodyCtx.Block())
+
+	return b
+}
+
+// ParseStatement parses a single statement context, such as the body of a loop or a conditional
+// branch that is not wrapped in a block, and appends the resulting node(s) to the BodyNode.
+func (b *BodyNode) ParseStatement(
+	unit *SourceUnit[Node[ast_pb.SourceUnit]],
+	contractNode Node[NodeType],
+	fnNode Node[NodeType],
+	statementCtx parser.IStatementContext,
+) Node[NodeType] {
+	b.Src = SrcNode{
+		Line:        int64(statementCtx.GetStart().GetLine()),
+		Column:      int64(statementCtx.GetStart().GetColumn()),
+		Start:       int64(statementCtx.GetStart().GetStart()),
+		End:         int64(statementCtx.GetStop().GetStop()),
+		Length:      int64(statementCtx.GetStop().GetStop() - statementCtx.GetStart().GetStart() + 1),
+		ParentIndex: fnNode.GetId(),
+	}
+
+	b.Implemented = len(statementCtx.GetChildren()) > 0
+
+	if statementCtx.Block() != nil {
+		b.parseBlockChildren(unit, contractNode, fnNode, statementCtx.Block())
+		return b
+	}
+
+	for _, child := range statementCtx.GetChildren() {
+		b.parseStatements(unit, contractNode, fnNode, child)
 	}
 
 	return b
 }
 
+// parseBlockChildren walks through the children of a block context in source order. Statements are
+// parsed with parseStatements while unchecked blocks are parsed into nested UNCHECKED_BLOCK body nodes so
+// that the original ordering of the statements is preserved.
+func (b *BodyNode) parseBlockChildren(
+	unit *SourceUnit[Node[ast_pb.SourceUnit]],
+	contractNode Node[NodeType],
+	fnNode Node[NodeType],
+	bodyCtx parser.IBlockContext,
+) {
+	for _, blockChildCtx := range bodyCtx.GetChildren() {
+		switch childCtx := blockChildCtx.(type) {
+		case *parser.StatementContext:
+			for _, child := range childCtx.GetChildren() {
+				b.parseStatements(unit, contractNode, fnNode, child)
+			}
+		case *parser.UncheckedBlockContext:
+			bodyNode := NewBodyNode(b.ASTBuilder, false)
+			bodyNode.ParseUncheckedBlock(unit, contractNode, fnNode, childCtx)
+			b.Statements = append(b.Statements, bodyNode)
+		}
+	}
+}
+
 func (b *BodyNode) ToString() string {
 	return ""
 }
@@ -33,6 +33,9 @@ type ASTBuilder struct {
 	currentVariables            []Node[NodeType]
 	globalDefinitions           []Node[NodeType]
 	currentImports              []Node[NodeType]
+	reparse                     *reparseState // reparse is set while some of the sources are parsed again.
+	symbols                     *SymbolTable  // symbols is the symbol table built once references are resolved.
+	typeChecker                 *TypeChecker  // typeChecker holds types of the expressions once references are resolved.
 }
 
 // NewAstBuilder creates a new ASTBuilder with the provided Solidity parser and source code.
@@ -80,6 +83,24 @@ func (b *ASTBuilder) GetTree() *Tree {
 	return b.tree
 }
 
+// GetSymbolTable returns the SymbolTable of the ASTBuilder, or nil if references were not resolved yet.
+func (b *ASTBuilder) GetSymbolTable() *SymbolTable {
+	return b.symbols
+}
+
+// GetTypeChecker returns the TypeChecker of the ASTBuilder, or nil if references were not resolved yet.
+func (b *ASTBuilder) GetTypeChecker() *TypeChecker {
+	return b.typeChecker
+}
+
+// GetTypeErrors returns the type errors found once references were resolved.
+func (b *ASTBuilder) GetTypeErrors() []*TypeError {
+	if b.typeChecker == nil {
+		return nil
+	}
+	return b.typeChecker.GetErrors()
+}
+
 // GetRoot returns the root node of the AST from the Tree of the ASTBuilder.
 func (b *ASTBuilder) GetRoot() *RootNode {
 	return b.tree.GetRoot()
@@ -100,9 +121,23 @@ func (b *ASTBuilder) InterfaceToJSON(data interface{}) ([]byte, error) {
 }
 
 // ResolveReferences resolves the references in the AST using the Resolver of the ASTBuilder.
+// Scoped resolution and type checking run even if the resolver fails, so the references it is able
+// to resolve are still filled in and typed. The builder is only cleaned up once all of the references
+// were resolved.
 func (b *ASTBuilder) ResolveReferences() []error {
-	if err := b.resolver.Resolve(); err != nil {
-		return err
+	errs := b.resolver.Resolve()
+
+	// Scoped resolution fills in references the resolver was not able to resolve.
+	b.symbols = NewSymbolTable(b.tree)
+	b.symbols.Resolve()
+
+	// Exact types replace the type descriptions approximated while parsing. Type errors are kept
+	// apart from resolution errors, as the tree is usable regardless of them.
+	b.typeChecker = NewTypeChecker(b.tree, b.symbols)
+	b.typeChecker.Check()
+
+	if errs != nil {
+		return errs
 	}
 
 	// Cleanup the builder so garbage collector and memory usage is minimized...
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/tests"
 	"github.com/unpackdev/solgo/utils"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -451,6 +453,34 @@ func buildFullPath(relativePath string) string {
 	return absPath
 }
 
+// newTestSources returns the sources made of the source units, which import the contracts of the
+// repository sources. Tests of ast can not use solgotest, which imports ast through ir.
+func newTestSources(entry string, units ...*solgo.SourceUnit) *solgo.Sources {
+	return &solgo.Sources{
+		SourceUnits:         units,
+		EntrySourceUnitName: entry,
+		LocalSourcesPath:    buildFullPath("../sources/"),
+	}
+}
+
+// newTestSourceUnit returns the source unit of the ast test contract with the given name.
+func newTestSourceUnit(t *testing.T, name string) *solgo.SourceUnit {
+	contract := tests.ReadContractFileForTest(t, "ast/"+name)
+	return &solgo.SourceUnit{Name: name, Path: contract.Path, Content: contract.Content}
+}
+
+// parseTestSources parses the sources, which have to be free of syntax errors, into a new ast builder.
+// References are resolved by the tests, as some of them expect the resolution to fail.
+func parseTestSources(t *testing.T, sources *solgo.Sources) (*solgo.Parser, *ASTBuilder) {
+	parser, err := solgo.NewParserFromSources(context.TODO(), sources)
+	require.NoError(t, err)
+
+	astBuilder := NewAstBuilder(parser.GetParser(), parser.GetSources())
+	require.NoError(t, parser.RegisterListener(solgo.ListenerAst, astBuilder))
+	require.Empty(t, parser.Parse())
+	return parser, astBuilder
+}
+
 // recursiveReferenceDescriptorSetTest is a recursive test that checks if all the reference descriptors
 // functions exist and won't return any panics of some sort... It's just basic test.
 func recursiveReferenceDescriptorSetTest(t *testing.T, node Node[NodeType]) {
@@ -197,14 +197,6 @@ func (t *CatchStatement) Parse(
 		bodyNode := NewBodyNode(t.ASTBuilder, false)
 		bodyNode.ParseBlock(unit, contractNode, t, ctx.Block())
 		t.Body = bodyNode
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(t.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, t, uncheckedCtx)
-				t.Body.Statements = append(t.Body.Statements, bodyNode)
-			}
-		}
 	}
 
 	return t
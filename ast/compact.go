@@ -0,0 +1,525 @@
+package ast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/goccy/go-json"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"go.uber.org/zap"
+)
+
+// compactNode is a single node of the solc compact AST, as found under the "ast" key of every
+// source in the solc --standard-json output.
+type compactNode map[string]any
+
+// compactImporter converts solc compact AST nodes into the AST nodes of this package.
+type compactImporter struct {
+	*ASTBuilder
+	content    string  // content is the source code of the file being imported, if available.
+	lines      []int64 // lines holds the offsets at which lines of the content start.
+	unitName   string  // unitName is the name of the source unit (contract) being imported.
+	contractId int64   // contractId is the id of the contract being imported.
+	fresh      bool    // fresh assigns new ids to imported nodes, used for nodes shared by source units.
+}
+
+// ImportFromCompactJSON imports the AST from the solc compact AST JSON. It accepts either the whole
+// --standard-json compiler output or the AST of a single source unit.
+// Node ids, referenced declarations and type descriptions are taken over from the compiler. Source
+// locations are byte offsets within the file the node belongs to, and lines and columns are only
+// resolved when the builder sources contain the imported files. Import stops between source units
+// once the context is done.
+func (b *ASTBuilder) ImportFromCompactJSON(ctx context.Context, jsonBytes []byte) (*RootNode, error) {
+	var output compactNode
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	if err := decoder.Decode(&output); err != nil {
+		return nil, err
+	}
+
+	units, err := compactSourceUnits(output)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compiler ids are kept, so ids of nodes the compiler does not number have to follow them.
+	maxId := int64(0)
+	for _, unit := range units {
+		if id := compactMaxId(unit); id > maxId {
+			maxId = id
+		}
+	}
+	if current := atomic.LoadInt64(&b.nextID); current <= maxId {
+		atomic.StoreInt64(&b.nextID, maxId+1)
+	}
+
+	if b.tree == nil {
+		b.tree = NewTree(b)
+	}
+
+	root := NewRootNode(b, 0, make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0), make([]*Comment, 0))
+	contractUnits := make(map[int64]int64)
+
+	for _, unit := range units {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		c := &compactImporter{ASTBuilder: b}
+		c.setContent(unit.str("absolutePath"))
+
+		sourceUnits, globals := c.sourceUnit(unit, root)
+		for _, sourceUnit := range sourceUnits {
+			contractUnits[sourceUnit.GetContract().GetId()] = sourceUnit.GetId()
+		}
+
+		root.SourceUnits = append(root.SourceUnits, sourceUnits...)
+		root.Globals = append(root.Globals, globals...)
+	}
+
+	// Base contracts reference source units, while the compiler references contracts.
+	for _, unit := range root.SourceUnits {
+		for _, baseContract := range unit.GetBaseContracts() {
+			if unitId, ok := contractUnits[baseContract.BaseName.ContractReferencedDeclaration]; ok {
+				baseContract.BaseName.ReferencedDeclaration = unitId
+			}
+		}
+	}
+
+	for _, unit := range root.SourceUnits {
+		if b.sources != nil && b.sources.EntrySourceUnitName == unit.GetName() {
+			root.SetEntrySourceUnit(unit.GetId())
+			break
+		}
+
+		if unit.GetId() > root.GetEntrySourceUnit() {
+			root.SetEntrySourceUnit(unit.GetId())
+		}
+	}
+
+	b.sourceUnits = root.SourceUnits
+	b.tree.SetRoot(root)
+
+	return root, nil
+}
+
+// compactSourceUnits returns the source unit ASTs of the compiler output ordered by source id.
+func compactSourceUnits(output compactNode) ([]compactNode, error) {
+	if output.nodeType() == "SourceUnit" {
+		return []compactNode{output}, nil
+	}
+
+	for _, outputError := range output.nodes("errors") {
+		if outputError.str("severity") == "error" {
+			return nil, fmt.Errorf("compilation failed: %s", outputError.str("message"))
+		}
+	}
+
+	sources, ok := output["sources"].(map[string]any)
+	if !ok || len(sources) == 0 {
+		return nil, errors.New("no sources found in compact ast json")
+	}
+
+	type entry struct {
+		id   int64
+		unit compactNode
+	}
+
+	entries := make([]entry, 0, len(sources))
+	for path, source := range sources {
+		sourceNode := asCompactNode(source)
+		unit := sourceNode.node("ast")
+		if unit == nil {
+			if sourceNode.node("legacyAST") != nil {
+				return nil, fmt.Errorf("legacy ast of source %s is not supported", path)
+			}
+			return nil, fmt.Errorf("ast of source %s not found, ast output has to be selected", path)
+		}
+		entries = append(entries, entry{id: sourceNode.int("id"), unit: unit})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+
+	toReturn := make([]compactNode, 0, len(entries))
+	for _, entry := range entries {
+		toReturn = append(toReturn, entry.unit)
+	}
+
+	return toReturn, nil
+}
+
+// compactMaxId returns the highest node id found within the compact AST node.
+func compactMaxId(node any) int64 {
+	toReturn := int64(0)
+
+	switch n := node.(type) {
+	case map[string]any:
+		if id, ok := n["id"].(float64); ok {
+			toReturn = int64(id)
+		}
+		for _, value := range n {
+			if id := compactMaxId(value); id > toReturn {
+				toReturn = id
+			}
+		}
+	case compactNode:
+		return compactMaxId(map[string]any(n))
+	case []any:
+		for _, value := range n {
+			if id := compactMaxId(value); id > toReturn {
+				toReturn = id
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// sourceUnit converts the file level source unit. Every contract of the file gets its own source
+// unit, the same way the parser based builder does it, while the remaining file level
+// definitions are returned as globals.
+func (c *compactImporter) sourceUnit(n compactNode, root *RootNode) ([]*SourceUnit[Node[ast_pb.SourceUnit]], []Node[NodeType]) {
+	directives := make([]compactNode, 0)
+	contracts := make([]compactNode, 0)
+	definitions := make([]compactNode, 0)
+
+	for _, child := range n.nodes("nodes") {
+		switch child.nodeType() {
+		case "PragmaDirective", "ImportDirective":
+			directives = append(directives, child)
+		case "ContractDefinition":
+			contracts = append(contracts, child)
+		default:
+			definitions = append(definitions, child)
+		}
+	}
+
+	units := make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0, len(contracts))
+	for i, contract := range contracts {
+		unit := &SourceUnit[Node[ast_pb.SourceUnit]]{
+			Name:            contract.str("name"),
+			License:         n.str("license"),
+			AbsolutePath:    n.str("absolutePath"),
+			NodeType:        ast_pb.NodeType_SOURCE_UNIT,
+			Nodes:           make([]Node[NodeType], 0),
+			ExportedSymbols: make([]Symbol, 0),
+		}
+
+		// The first contract takes over the id of the file, the following ones get their own
+		// ids together with their own copy of the pragma and import directives.
+		if i == 0 {
+			unit.Id = c.id(n)
+		} else {
+			unit.Id = c.GetNextID()
+		}
+		unit.Src = c.src(contract, "src", root.GetId())
+		unit.ExportedSymbols = append(unit.ExportedSymbols, Symbol{
+			Id:           unit.Id,
+			Name:         unit.Name,
+			AbsolutePath: unit.AbsolutePath,
+		})
+
+		c.fresh = i > 0
+		for _, directive := range directives {
+			if node := c.directive(directive, unit.Id); node != nil {
+				unit.Nodes = append(unit.Nodes, node)
+			}
+		}
+		c.fresh = false
+
+		contractNode, baseContracts := c.contract(contract, unit)
+		unit.Nodes = append(unit.Nodes, contractNode)
+		unit.Contract = contractNode
+		unit.BaseContracts = baseContracts
+
+		units = append(units, unit)
+	}
+
+	parentId := root.GetId()
+	if len(units) > 0 {
+		parentId = units[0].GetId()
+	}
+
+	c.unitName = strings.TrimSuffix(filepath.Base(n.str("absolutePath")), filepath.Ext(n.str("absolutePath")))
+	c.contractId = 0
+
+	globals := make([]Node[NodeType], 0, len(definitions))
+	for _, definition := range definitions {
+		if node := c.member(definition, parentId); node != nil {
+			globals = append(globals, node)
+		}
+	}
+
+	return units, globals
+}
+
+// directive converts pragma and import directives.
+func (c *compactImporter) directive(n compactNode, parentId int64) Node[NodeType] {
+	switch n.nodeType() {
+	case "PragmaDirective":
+		literals := n.strings("literals")
+		text := "pragma" + strings.Join(literals, "") + ";"
+		return &Pragma{
+			Id:       c.id(n),
+			NodeType: ast_pb.NodeType_PRAGMA_DIRECTIVE,
+			Src:      c.src(n, "src", parentId),
+			Literals: getLiterals(text),
+			Text:     text,
+		}
+	case "ImportDirective":
+		id := c.id(n)
+		toReturn := &Import{
+			Id:           id,
+			NodeType:     ast_pb.NodeType_IMPORT_DIRECTIVE,
+			Src:          c.src(n, "src", parentId),
+			AbsolutePath: n.str("absolutePath"),
+			File:         n.str("file"),
+			Scope:        n.int("scope"),
+			UnitAlias:    n.str("unitAlias"),
+			SourceUnit:   n.int("sourceUnit"),
+			Symbols:      make([]*ImportSymbol, 0),
+		}
+
+		if location := c.location(n, "nameLocation", id); location != nil {
+			toReturn.NameLocation = location
+		}
+
+		for _, alias := range n.nodes("symbolAliases") {
+			toReturn.Symbols = append(toReturn.Symbols, &ImportSymbol{
+				Name:  alias.node("foreign").str("name"),
+				Alias: alias.str("local"),
+			})
+			if alias.str("local") != "" {
+				toReturn.UnitAliases = append(toReturn.UnitAliases, alias.str("local"))
+			}
+		}
+
+		return toReturn
+	default:
+		return c.unsupported(n, "compactImporter.directive")
+	}
+}
+
+// setContent looks up the source code of the file with the provided path within the builder
+// sources, which is needed to resolve lines and columns of the source locations.
+func (c *compactImporter) setContent(path string) {
+	c.content = ""
+	c.lines = nil
+
+	if c.sources == nil || path == "" {
+		return
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	for _, unit := range c.sources.SourceUnits {
+		if unit.Path == path || filepath.Base(unit.Path) == filepath.Base(path) || unit.Name == name {
+			c.content = unit.Content
+			break
+		}
+	}
+
+	if c.content == "" {
+		return
+	}
+
+	c.lines = []int64{0}
+	for i := 0; i < len(c.content); i++ {
+		if c.content[i] == '\n' {
+			c.lines = append(c.lines, int64(i+1))
+		}
+	}
+}
+
+// text returns the source code at the provided location, if the source code is available.
+func (c *compactImporter) text(src SrcNode) string {
+	if c.content == "" || src.Start < 0 || src.Length <= 0 || src.Start+src.Length > int64(len(c.content)) {
+		return ""
+	}
+	return c.content[src.Start : src.Start+src.Length]
+}
+
+// textContaining returns the source text at the given location only when it contains the
+// expected value. Sources that do not match the compiled ones would otherwise leak unrelated
+// text into the tree.
+func (c *compactImporter) textContaining(src SrcNode, expected string) string {
+	if text := c.text(src); strings.Contains(text, expected) {
+		return text
+	}
+	return ""
+}
+
+// quotedText returns the source text of the string literal at the given location only when
+// it is a quoted string containing the expected value.
+func (c *compactImporter) quotedText(src SrcNode, expected string) string {
+	text := c.textContaining(src, expected)
+	if len(text) < 2 || !strings.ContainsAny(text[len(text)-1:], "\"'") {
+		return ""
+	}
+	return text
+}
+
+// id returns the compiler assigned id of the node, or a new id for nodes that have none.
+func (c *compactImporter) id(n compactNode) int64 {
+	if _, ok := n["id"].(float64); ok && !c.fresh {
+		return n.int("id")
+	}
+	return c.GetNextID()
+}
+
+// src converts the "start:length:source" location stored under the key into a SrcNode.
+func (c *compactImporter) src(n compactNode, key string, parentId int64) SrcNode {
+	toReturn := SrcNode{ParentIndex: parentId}
+
+	parts := strings.Split(n.str(key), ":")
+	if len(parts) < 2 {
+		return toReturn
+	}
+
+	start, _ := strconv.ParseInt(parts[0], 10, 64)
+	length, _ := strconv.ParseInt(parts[1], 10, 64)
+	if start < 0 {
+		return toReturn
+	}
+
+	toReturn.Start = start
+	toReturn.Length = length
+	toReturn.End = start + length - 1
+
+	if len(c.lines) > 0 {
+		line := sort.Search(len(c.lines), func(i int) bool {
+			return c.lines[i] > start
+		})
+		toReturn.Line = int64(line)
+		toReturn.Column = start - c.lines[line-1]
+	}
+
+	return toReturn
+}
+
+// location returns the optional location stored under the key, such as the name location.
+func (c *compactImporter) location(n compactNode, key string, parentId int64) *SrcNode {
+	if n.str(key) == "" || strings.HasPrefix(n.str(key), "-1:") {
+		return nil
+	}
+	toReturn := c.src(n, key, parentId)
+	return &toReturn
+}
+
+// typeDescription returns the compiler resolved type description of the node.
+func (c *compactImporter) typeDescription(n compactNode) *TypeDescription {
+	descriptions := n.node("typeDescriptions")
+	if descriptions == nil || (descriptions.str("typeIdentifier") == "" && descriptions.str("typeString") == "") {
+		return nil
+	}
+
+	return &TypeDescription{
+		TypeIdentifier: descriptions.str("typeIdentifier"),
+		TypeString:     descriptions.str("typeString"),
+	}
+}
+
+// unsupported logs the compact AST node that cannot be represented and skips it.
+func (c *compactImporter) unsupported(n compactNode, method string) Node[NodeType] {
+	zap.L().Warn(
+		fmt.Sprintf("Unsupported compact ast node @ %s", method),
+		zap.String("node_type", n.nodeType()),
+		zap.String("src", n.str("src")),
+	)
+	return nil
+}
+
+// asCompactNode returns the value as a compact AST node, or nil if it is not an object.
+func asCompactNode(value any) compactNode {
+	if node, ok := value.(map[string]any); ok {
+		return node
+	}
+	return nil
+}
+
+// nodeType returns the compact AST node type.
+func (n compactNode) nodeType() string {
+	return n.str("nodeType")
+}
+
+// str returns the string value stored under the key.
+func (n compactNode) str(key string) string {
+	if value, ok := n[key].(string); ok {
+		return value
+	}
+	return ""
+}
+
+// int returns the integer value stored under the key.
+func (n compactNode) int(key string) int64 {
+	if value, ok := n[key].(float64); ok {
+		return int64(value)
+	}
+	return 0
+}
+
+// bool returns the boolean value stored under the key.
+func (n compactNode) bool(key string) bool {
+	if value, ok := n[key].(bool); ok {
+		return value
+	}
+	return false
+}
+
+// node returns the node stored under the key, or nil if there is none.
+func (n compactNode) node(key string) compactNode {
+	return asCompactNode(n[key])
+}
+
+// nodes returns the nodes stored under the key. Null entries are kept as nil nodes, as they
+// carry meaning, for example for omitted tuple components.
+func (n compactNode) nodes(key string) []compactNode {
+	values, ok := n[key].([]any)
+	if !ok {
+		return nil
+	}
+
+	toReturn := make([]compactNode, 0, len(values))
+	for _, value := range values {
+		toReturn = append(toReturn, asCompactNode(value))
+	}
+	return toReturn
+}
+
+// strings returns the string values stored under the key.
+func (n compactNode) strings(key string) []string {
+	values, ok := n[key].([]any)
+	if !ok {
+		return nil
+	}
+
+	toReturn := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			toReturn = append(toReturn, str)
+		}
+	}
+	return toReturn
+}
+
+// ints returns the integer values stored under the key. Null entries are returned as zero.
+func (n compactNode) ints(key string) []int64 {
+	values, ok := n[key].([]any)
+	if !ok {
+		return make([]int64, 0)
+	}
+
+	toReturn := make([]int64, 0, len(values))
+	for _, value := range values {
+		number, _ := value.(float64)
+		toReturn = append(toReturn, int64(number))
+	}
+	return toReturn
+}
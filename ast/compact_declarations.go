@@ -0,0 +1,713 @@
+package ast
+
+import (
+	"fmt"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// contract converts the contract, interface or library definition of the source unit.
+func (c *compactImporter) contract(n compactNode, unit *SourceUnit[Node[ast_pb.SourceUnit]]) (Node[NodeType], []*BaseContract) {
+	id := c.id(n)
+	c.unitName = unit.GetName()
+	c.contractId = id
+
+	src := c.src(n, "src", unit.GetId())
+	nameLocation := c.src(n, "nameLocation", id)
+
+	baseContracts := make([]*BaseContract, 0)
+	for _, base := range n.nodes("baseContracts") {
+		baseContracts = append(baseContracts, c.baseContract(base, id))
+	}
+
+	nodes := make([]Node[NodeType], 0)
+	for _, child := range n.nodes("nodes") {
+		if node := c.member(child, id); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+
+	linearized := n.ints("linearizedBaseContracts")
+	dependencies := n.ints("contractDependencies")
+	abstract := n.bool("abstract")
+	fullyImplemented := n.bool("fullyImplemented")
+
+	switch n.str("contractKind") {
+	case "interface":
+		return &Interface{
+			ASTBuilder:              c.ASTBuilder,
+			Id:                      id,
+			Name:                    n.str("name"),
+			NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src:                     src,
+			NameLocation:            nameLocation,
+			Abstract:                abstract,
+			Kind:                    ast_pb.NodeType_KIND_INTERFACE,
+			FullyImplemented:        fullyImplemented,
+			Nodes:                   nodes,
+			LinearizedBaseContracts: linearized,
+			BaseContracts:           baseContracts,
+			ContractDependencies:    dependencies,
+		}, baseContracts
+	case "library":
+		return &Library{
+			ASTBuilder:              c.ASTBuilder,
+			Id:                      id,
+			Name:                    n.str("name"),
+			NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src:                     src,
+			NameLocation:            nameLocation,
+			Abstract:                abstract,
+			Kind:                    ast_pb.NodeType_KIND_LIBRARY,
+			FullyImplemented:        fullyImplemented,
+			Nodes:                   nodes,
+			LinearizedBaseContracts: linearized,
+			BaseContracts:           baseContracts,
+			ContractDependencies:    dependencies,
+		}, baseContracts
+	default:
+		return &Contract{
+			ASTBuilder:              c.ASTBuilder,
+			Id:                      id,
+			Name:                    n.str("name"),
+			NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
+			Src:                     src,
+			NameLocation:            nameLocation,
+			Abstract:                abstract,
+			Kind:                    ast_pb.NodeType_KIND_CONTRACT,
+			FullyImplemented:        fullyImplemented,
+			Nodes:                   nodes,
+			LinearizedBaseContracts: linearized,
+			BaseContracts:           baseContracts,
+			ContractDependencies:    dependencies,
+		}, baseContracts
+	}
+}
+
+// baseContract converts the inheritance specifier. The referenced declaration of the base name
+// is resolved to the source unit of the base contract once all source units are imported.
+func (c *compactImporter) baseContract(n compactNode, parentId int64) *BaseContract {
+	id := c.id(n)
+	baseName := n.node("baseName")
+
+	toReturn := &BaseContract{
+		Id:       id,
+		NodeType: ast_pb.NodeType_INHERITANCE_SPECIFIER,
+		Src:      c.src(n, "src", parentId),
+		BaseName: &BaseContractName{
+			Id:                            c.id(baseName),
+			NodeType:                      ast_pb.NodeType_IDENTIFIER_PATH,
+			Src:                           c.src(baseName, "src", id),
+			Name:                          baseName.str("name"),
+			ContractReferencedDeclaration: baseName.int("referencedDeclaration"),
+		},
+	}
+
+	for _, argument := range n.nodes("arguments") {
+		if expression := c.expression(argument, id); expression != nil {
+			toReturn.Arguments = append(toReturn.Arguments, expression)
+		}
+	}
+
+	return toReturn
+}
+
+// member converts the definitions found within contracts and at the file level.
+func (c *compactImporter) member(n compactNode, parentId int64) Node[NodeType] {
+	switch n.nodeType() {
+	case "FunctionDefinition":
+		return c.function(n, parentId)
+	case "ModifierDefinition":
+		return c.modifierDefinition(n, parentId)
+	case "VariableDeclaration":
+		return c.stateVariable(n, parentId)
+	case "StructDefinition":
+		return c.structDefinition(n, parentId)
+	case "EnumDefinition":
+		return c.enumDefinition(n, parentId)
+	case "EventDefinition":
+		return c.eventDefinition(n, parentId)
+	case "ErrorDefinition":
+		return c.errorDefinition(n, parentId)
+	case "UsingForDirective":
+		return c.usingDirective(n, parentId)
+	case "UserDefinedValueTypeDefinition":
+		return c.userDefinedValueType(n, parentId)
+	default:
+		return c.unsupported(n, "compactImporter.member")
+	}
+}
+
+// function converts function definitions, including constructors, fallback and receive functions.
+func (c *compactImporter) function(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	parameters := c.parameterList(n.node("parameters"), id, src, ast_pb.StorageLocation_MEMORY)
+	returnParameters := c.parameterList(n.node("returnParameters"), id, src, ast_pb.StorageLocation_MEMORY)
+	body := c.functionBody(n, id, src)
+	modifiers := c.modifierInvocations(n, id)
+	overrides := c.overrides(n, id)
+
+	switch n.str("kind") {
+	case "constructor":
+		toReturn := &Constructor{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_FUNCTION_DEFINITION,
+			Src:              src,
+			Kind:             ast_pb.NodeType_CONSTRUCTOR,
+			StateMutability:  compactMutability(n.str("stateMutability")),
+			Visibility:       compactVisibility(n.str("visibility")),
+			Implemented:      n.bool("implemented"),
+			Modifiers:        modifiers,
+			Parameters:       parameters,
+			ReturnParameters: returnParameters,
+			Scope:            n.int("scope"),
+			Body:             body,
+		}
+		c.currentFunctions = append(c.currentFunctions, toReturn)
+		return toReturn
+	case "fallback":
+		toReturn := &Fallback{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_FUNCTION_DEFINITION,
+			Kind:             ast_pb.NodeType_FALLBACK,
+			Src:              src,
+			Implemented:      n.bool("implemented"),
+			Visibility:       compactVisibility(n.str("visibility")),
+			StateMutability:  compactMutability(n.str("stateMutability")),
+			Modifiers:        modifiers,
+			Overrides:        overrides,
+			Parameters:       parameters,
+			ReturnParameters: returnParameters,
+			Body:             body,
+			Virtual:          n.bool("virtual"),
+		}
+		c.currentFunctions = append(c.currentFunctions, toReturn)
+		return toReturn
+	case "receive":
+		toReturn := &Receive{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_FUNCTION_DEFINITION,
+			Kind:             ast_pb.NodeType_RECEIVE,
+			Src:              src,
+			Implemented:      n.bool("implemented"),
+			Visibility:       compactVisibility(n.str("visibility")),
+			StateMutability:  compactMutability(n.str("stateMutability")),
+			Modifiers:        modifiers,
+			Overrides:        overrides,
+			Parameters:       parameters,
+			ReturnParameters: returnParameters,
+			Body:             body,
+			Virtual:          n.bool("virtual"),
+			Payable:          n.str("stateMutability") == "payable",
+		}
+		c.currentFunctions = append(c.currentFunctions, toReturn)
+		return toReturn
+	}
+
+	toReturn := &Function{
+		ASTBuilder:       c.ASTBuilder,
+		Id:               id,
+		Name:             n.str("name"),
+		NodeType:         ast_pb.NodeType_FUNCTION_DEFINITION,
+		Kind:             ast_pb.NodeType_KIND_FUNCTION,
+		Src:              src,
+		NameLocation:     c.src(n, "nameLocation", id),
+		Body:             body,
+		Implemented:      n.bool("implemented"),
+		Visibility:       compactVisibility(n.str("visibility")),
+		StateMutability:  compactMutability(n.str("stateMutability")),
+		Virtual:          n.bool("virtual"),
+		Modifiers:        modifiers,
+		Overrides:        overrides,
+		Parameters:       parameters,
+		ReturnParameters: returnParameters,
+		Scope:            n.int("scope"),
+	}
+	toReturn.TypeDescription = toReturn.buildTypeDescription()
+	toReturn.ComputeSignature()
+
+	c.currentFunctions = append(c.currentFunctions, toReturn)
+	return toReturn
+}
+
+// functionBody converts the body of functions and modifiers. Unimplemented functions get an
+// empty body, the same way the parser based builder does it.
+func (c *compactImporter) functionBody(n compactNode, parentId int64, src SrcNode) *BodyNode {
+	if body := n.node("body"); body != nil {
+		return c.block(body, parentId, false)
+	}
+
+	toReturn := NewBodyNode(c.ASTBuilder, false)
+	toReturn.Src = src
+	toReturn.Src.ParentIndex = parentId
+	return toReturn
+}
+
+// modifierDefinition converts the modifier definition.
+func (c *compactImporter) modifierDefinition(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &ModifierDefinition{
+		ASTBuilder:   c.ASTBuilder,
+		Id:           id,
+		Name:         n.str("name"),
+		NodeType:     ast_pb.NodeType_MODIFIER_DEFINITION,
+		Src:          src,
+		NameLocation: c.src(n, "nameLocation", id),
+		Visibility:   compactVisibility(n.str("visibility")),
+		Virtual:      n.bool("virtual"),
+		Parameters:   c.parameterList(n.node("parameters"), id, src, ast_pb.StorageLocation_MEMORY),
+		Body:         c.functionBody(n, id, src),
+	}
+
+	c.currentModifiers = append(c.currentModifiers, toReturn)
+	return toReturn
+}
+
+// modifierInvocations converts the modifiers, as well as the base constructor calls, of functions.
+func (c *compactImporter) modifierInvocations(n compactNode, parentId int64) []*ModifierInvocation {
+	toReturn := make([]*ModifierInvocation, 0)
+
+	for _, modifier := range n.nodes("modifiers") {
+		id := c.id(modifier)
+		modifierName := modifier.node("modifierName")
+
+		invocation := &ModifierInvocation{
+			ASTBuilder:    c.ASTBuilder,
+			Id:            id,
+			Name:          modifierName.str("name"),
+			NodeType:      ast_pb.NodeType_MODIFIER_INVOCATION,
+			Kind:          ast_pb.NodeType_MODIFIER_INVOCATION,
+			Src:           c.src(modifier, "src", parentId),
+			ArgumentTypes: make([]*TypeDescription, 0),
+			Arguments:     make([]Node[NodeType], 0),
+			ModifierName: &ModifierName{
+				Id:       c.id(modifierName),
+				Name:     modifierName.str("name"),
+				NodeType: ast_pb.NodeType_IDENTIFIER_PATH,
+				Src:      c.src(modifierName, "src", id),
+			},
+		}
+
+		for _, argument := range modifier.nodes("arguments") {
+			if expression := c.expression(argument, id); expression != nil {
+				invocation.Arguments = append(invocation.Arguments, expression)
+				invocation.ArgumentTypes = append(invocation.ArgumentTypes, expression.GetTypeDescription())
+			}
+		}
+
+		toReturn = append(toReturn, invocation)
+	}
+
+	return toReturn
+}
+
+// overrides converts the override specifier of functions and state variables.
+func (c *compactImporter) overrides(n compactNode, parentId int64) []*OverrideSpecifier {
+	toReturn := make([]*OverrideSpecifier, 0)
+
+	specifier := n.node("overrides")
+	if specifier == nil {
+		return toReturn
+	}
+
+	id := c.id(specifier)
+	override := &OverrideSpecifier{
+		ASTBuilder: c.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_OVERRIDE_SPECIFIER,
+		Src:        c.src(specifier, "src", parentId),
+		Overrides:  make([]*OverridePath, 0),
+	}
+
+	for _, path := range specifier.nodes("overrides") {
+		override.Overrides = append(override.Overrides, &OverridePath{
+			Id:                    c.id(path),
+			Name:                  path.str("name"),
+			NodeType:              ast_pb.NodeType_OVERRIDE_PATH,
+			Src:                   c.src(path, "src", id),
+			ReferencedDeclaration: path.int("referencedDeclaration"),
+			TypeDescription:       c.typeDescription(path),
+		})
+	}
+
+	return append(toReturn, override)
+}
+
+// parameterList converts the parameter list. Missing lists are returned empty, located at the
+// node they belong to, as the rest of the package expects parameter lists to always be set.
+func (c *compactImporter) parameterList(n compactNode, parentId int64, src SrcNode, location ast_pb.StorageLocation) *ParameterList {
+	toReturn := &ParameterList{
+		ASTBuilder:     c.ASTBuilder,
+		NodeType:       ast_pb.NodeType_PARAMETER_LIST,
+		Parameters:     make([]*Parameter, 0),
+		ParameterTypes: make([]*TypeDescription, 0),
+	}
+
+	if n == nil {
+		toReturn.Id = c.GetNextID()
+		toReturn.Src = src
+		toReturn.Src.ParentIndex = parentId
+		return toReturn
+	}
+
+	toReturn.Id = c.id(n)
+	toReturn.Src = c.src(n, "src", parentId)
+
+	for _, parameter := range n.nodes("parameters") {
+		param := c.parameter(parameter, toReturn.Id, location)
+		toReturn.Parameters = append(toReturn.Parameters, param)
+		toReturn.ParameterTypes = append(toReturn.ParameterTypes, param.TypeDescription)
+	}
+
+	return toReturn
+}
+
+// parameter converts variable declarations used as function, event, error and struct parameters.
+func (c *compactImporter) parameter(n compactNode, parentId int64, location ast_pb.StorageLocation) *Parameter {
+	id := c.id(n)
+
+	toReturn := NewParameter(c.ASTBuilder)
+	toReturn.Id = id
+	toReturn.Src = c.src(n, "src", parentId)
+	toReturn.NameLocation = c.location(n, "nameLocation", id)
+	toReturn.Scope = n.int("scope")
+	toReturn.Name = n.str("name")
+	toReturn.TypeName = c.typeName(n.node("typeName"), id)
+	toReturn.StorageLocation = compactStorageLocation(n.str("storageLocation"), location)
+	toReturn.Constant = n.bool("constant")
+	toReturn.Indexed = n.bool("indexed")
+	toReturn.TypeDescription = c.typeDescription(n)
+
+	if toReturn.TypeName != nil {
+		if toReturn.TypeDescription == nil {
+			toReturn.TypeDescription = toReturn.TypeName.GetTypeDescription()
+		}
+
+		switch toReturn.TypeName.StateMutability {
+		case ast_pb.Mutability_PAYABLE, ast_pb.Mutability_NONPAYABLE:
+			toReturn.StateMutability = toReturn.TypeName.StateMutability
+		}
+	}
+
+	c.currentVariables = append(c.currentVariables, toReturn)
+	return toReturn
+}
+
+// stateVariable converts state variables, and constants declared at the file level.
+func (c *compactImporter) stateVariable(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &StateVariableDeclaration{
+		ASTBuilder:      c.ASTBuilder,
+		Id:              id,
+		Name:            n.str("name"),
+		Constant:        n.bool("constant"),
+		StateVariable:   true,
+		NodeType:        ast_pb.NodeType_VARIABLE_DECLARATION,
+		Src:             c.src(n, "src", parentId),
+		Scope:           n.int("scope"),
+		TypeDescription: c.typeDescription(n),
+		Visibility:      compactVisibility(n.str("visibility")),
+		StorageLocation: ast_pb.StorageLocation_DEFAULT,
+		StateMutability: ast_pb.Mutability_MUTABLE,
+		TypeName:        c.typeName(n.node("typeName"), id),
+	}
+
+	if n.str("mutability") == "immutable" {
+		toReturn.StateMutability = ast_pb.Mutability_IMMUTABLE
+	}
+
+	if value := n.node("value"); value != nil {
+		toReturn.InitialValue = c.expression(value, id)
+	}
+
+	if toReturn.TypeDescription == nil && toReturn.TypeName != nil {
+		toReturn.TypeDescription = toReturn.TypeName.GetTypeDescription()
+	}
+
+	c.currentStateVariables = append(c.currentStateVariables, toReturn)
+	return toReturn
+}
+
+// structDefinition converts the struct definition.
+func (c *compactImporter) structDefinition(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &StructDefinition{
+		ASTBuilder:      c.ASTBuilder,
+		SourceUnitName:  c.unitName,
+		Id:              id,
+		NodeType:        ast_pb.NodeType_STRUCT_DEFINITION,
+		Src:             c.src(n, "src", parentId),
+		Name:            n.str("name"),
+		NameLocation:    c.src(n, "nameLocation", id),
+		CanonicalName:   n.str("canonicalName"),
+		Members:         make([]Node[NodeType], 0),
+		Visibility:      compactVisibility(n.str("visibility")),
+		StorageLocation: ast_pb.StorageLocation_DEFAULT,
+	}
+
+	// The compiler does not describe the types of definitions, so the descriptions follow the
+	// format of the parser based builder.
+	toReturn.TypeDescription = &TypeDescription{
+		TypeIdentifier: fmt.Sprintf("t_struct$_%s_%s_$%d", toReturn.SourceUnitName, toReturn.Name, id),
+		TypeString:     fmt.Sprintf("struct %s.%s", toReturn.SourceUnitName, toReturn.Name),
+	}
+
+	for _, member := range n.nodes("members") {
+		toReturn.Members = append(toReturn.Members, c.parameter(member, id, ast_pb.StorageLocation_DEFAULT))
+	}
+
+	c.currentStructs = append(c.currentStructs, toReturn)
+	return toReturn
+}
+
+// enumDefinition converts the enum definition.
+func (c *compactImporter) enumDefinition(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &EnumDefinition{
+		ASTBuilder:     c.ASTBuilder,
+		SourceUnitName: c.unitName,
+		Id:             id,
+		NodeType:       ast_pb.NodeType_ENUM_DEFINITION,
+		Src:            c.src(n, "src", parentId),
+		NameLocation:   c.src(n, "nameLocation", id),
+		Name:           n.str("name"),
+		CanonicalName:  n.str("canonicalName"),
+		Members:        make([]Node[NodeType], 0),
+	}
+
+	toReturn.TypeDescription = &TypeDescription{
+		TypeIdentifier: fmt.Sprintf("t_enum_$_%s_$%d", toReturn.Name, id),
+		TypeString:     fmt.Sprintf("enum %s", toReturn.CanonicalName),
+	}
+
+	for _, member := range n.nodes("members") {
+		memberId := c.id(member)
+		toReturn.Members = append(toReturn.Members, &Parameter{
+			ASTBuilder:   c.ASTBuilder,
+			Id:           memberId,
+			NodeType:     ast_pb.NodeType_ENUM_VALUE,
+			Src:          c.src(member, "src", id),
+			NameLocation: c.location(member, "nameLocation", id),
+			Name:         member.str("name"),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: fmt.Sprintf("t_enum_$_%s$_%s_$%d", toReturn.Name, member.str("name"), memberId),
+				TypeString:     fmt.Sprintf("enum %s.%s", toReturn.CanonicalName, member.str("name")),
+			},
+		})
+	}
+
+	c.currentEnums = append(c.currentEnums, toReturn)
+	return toReturn
+}
+
+// eventDefinition converts the event definition.
+func (c *compactImporter) eventDefinition(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &EventDefinition{
+		ASTBuilder:     c.ASTBuilder,
+		SourceUnitName: c.unitName,
+		Id:             id,
+		NodeType:       ast_pb.NodeType_EVENT_DEFINITION,
+		Src:            src,
+		Parameters:     c.parameterList(n.node("parameters"), id, src, ast_pb.StorageLocation_MEMORY),
+		Name:           n.str("name"),
+		Anonymous:      n.bool("anonymous"),
+	}
+
+	toReturn.TypeDescription = &TypeDescription{
+		TypeIdentifier: fmt.Sprintf("t_event&_%s_%s_&%d", toReturn.SourceUnitName, toReturn.Name, id),
+		TypeString:     fmt.Sprintf("event %s.%s", toReturn.SourceUnitName, toReturn.Name),
+	}
+
+	c.currentEvents = append(c.currentEvents, toReturn)
+	return toReturn
+}
+
+// errorDefinition converts the custom error definition.
+func (c *compactImporter) errorDefinition(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &ErrorDefinition{
+		ASTBuilder:     c.ASTBuilder,
+		SourceUnitName: c.unitName,
+		Id:             id,
+		NodeType:       ast_pb.NodeType_ERROR_DEFINITION,
+		Src:            src,
+		Name:           n.str("name"),
+		NameLocation:   c.src(n, "nameLocation", id),
+		Parameters:     c.parameterList(n.node("parameters"), id, src, ast_pb.StorageLocation_MEMORY),
+	}
+
+	toReturn.TypeDescription = &TypeDescription{
+		TypeIdentifier: fmt.Sprintf("t_error$_%s_%s_$%d", toReturn.SourceUnitName, toReturn.Name, id),
+		TypeString:     fmt.Sprintf("error %s.%s", toReturn.SourceUnitName, toReturn.Name),
+	}
+
+	c.currentErrors = append(c.currentErrors, toReturn)
+	return toReturn
+}
+
+// usingDirective converts the using for directive. Directives attaching functions instead of
+// a library keep the first function as the library name, next to the list of the functions.
+func (c *compactImporter) usingDirective(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &UsingDirective{
+		ASTBuilder: c.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_USING_FOR_DIRECTIVE,
+		Src:        src,
+	}
+
+	libraryName := n.node("libraryName")
+	if libraryName == nil {
+		for _, function := range n.nodes("functionList") {
+			if libraryName = function.node("function"); libraryName != nil {
+				break
+			}
+		}
+	}
+
+	if libraryName != nil {
+		toReturn.LibraryName = &LibraryName{
+			ASTBuilder:            c.ASTBuilder,
+			Id:                    c.id(libraryName),
+			NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+			Src:                   c.src(libraryName, "src", id),
+			Name:                  libraryName.str("name"),
+			ReferencedDeclaration: libraryName.int("referencedDeclaration"),
+		}
+	}
+
+	// Functions attached directly are listed as function, while operator implementations are
+	// listed as definition along with the operator.
+	for _, function := range n.nodes("functionList") {
+		path := function.node("function")
+		if path == nil {
+			path = function.node("definition")
+		}
+		if path == nil {
+			continue
+		}
+
+		toReturn.Functions = append(toReturn.Functions, &UsingFunction{
+			Id:                    c.id(path),
+			NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+			Src:                   c.src(path, "src", id),
+			Name:                  path.str("name"),
+			Operator:              function.str("operator"),
+			ReferencedDeclaration: path.int("referencedDeclaration"),
+		})
+	}
+	toReturn.Global = n.bool("global")
+
+	if typeName := n.node("typeName"); typeName != nil {
+		toReturn.TypeName = c.typeName(typeName, id)
+		toReturn.TypeDescription = toReturn.TypeName.GetTypeDescription()
+	} else {
+		// Using for any type, written as `using L for *`.
+		toReturn.TypeName = &TypeName{
+			ASTBuilder: c.ASTBuilder,
+			Id:         c.GetNextID(),
+			NodeType:   ast_pb.NodeType_ELEMENTARY_TYPE_NAME,
+			Src:        src,
+			Name:       "*",
+			TypeDescription: &TypeDescription{
+				TypeString:     "string",
+				TypeIdentifier: "t_string_literal",
+			},
+		}
+		toReturn.TypeName.Src.ParentIndex = id
+		toReturn.TypeDescription = toReturn.TypeName.GetTypeDescription()
+	}
+
+	return toReturn
+}
+
+// userDefinedValueType converts the user defined value type definition.
+func (c *compactImporter) userDefinedValueType(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	underlyingType := n.node("underlyingType")
+
+	toReturn := &UserDefinedValueTypeDefinition{
+		ASTBuilder:   c.ASTBuilder,
+		Id:           id,
+		NodeType:     ast_pb.NodeType_USER_DEFINED_VALUE_TYPE,
+		Src:          c.src(n, "src", parentId),
+		Is:           true,
+		Type:         "type",
+		Name:         n.str("name"),
+		NameLocation: c.src(n, "nameLocation", id),
+		TypeName:     c.typeName(underlyingType, id),
+		TypeLocation: c.src(underlyingType, "src", id),
+	}
+	toReturn.TypeDescription = toReturn.TypeName.GetTypeDescription()
+
+	c.currentUserDefinedVariables = append(c.currentUserDefinedVariables, toReturn)
+	return toReturn
+}
+
+// compactVisibility converts the compiler visibility.
+func compactVisibility(visibility string) ast_pb.Visibility {
+	switch visibility {
+	case "internal":
+		return ast_pb.Visibility_INTERNAL
+	case "private":
+		return ast_pb.Visibility_PRIVATE
+	case "public":
+		return ast_pb.Visibility_PUBLIC
+	case "external":
+		return ast_pb.Visibility_EXTERNAL
+	default:
+		return ast_pb.Visibility_V_DEFAULT
+	}
+}
+
+// compactMutability converts the compiler state mutability.
+func compactMutability(mutability string) ast_pb.Mutability {
+	switch mutability {
+	case "payable":
+		return ast_pb.Mutability_PAYABLE
+	case "nonpayable":
+		return ast_pb.Mutability_NONPAYABLE
+	case "view":
+		return ast_pb.Mutability_VIEW
+	case "pure":
+		return ast_pb.Mutability_PURE
+	case "mutable":
+		return ast_pb.Mutability_MUTABLE
+	case "immutable":
+		return ast_pb.Mutability_IMMUTABLE
+	default:
+		return ast_pb.Mutability_M_DEFAULT
+	}
+}
+
+// compactStorageLocation converts the compiler storage location, returning the fallback for
+// variables without an explicit data location.
+func compactStorageLocation(location string, fallback ast_pb.StorageLocation) ast_pb.StorageLocation {
+	switch location {
+	case "memory":
+		return ast_pb.StorageLocation_MEMORY
+	case "storage":
+		return ast_pb.StorageLocation_STORAGE
+	case "calldata":
+		return ast_pb.StorageLocation_CALLDATA
+	default:
+		return fallback
+	}
+}
@@ -0,0 +1,610 @@
+package ast
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// expression converts the expression, returning nil for missing or unsupported expressions.
+func (c *compactImporter) expression(n compactNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	switch n.nodeType() {
+	case "Identifier":
+		return c.identifier(n, parentId)
+	case "Literal":
+		return c.literal(n, parentId)
+	case "ElementaryTypeNameExpression":
+		return c.elementaryTypeNameExpression(n, parentId)
+	case "BinaryOperation":
+		return c.binaryOperation(n, parentId)
+	case "UnaryOperation":
+		return c.unaryOperation(n, parentId)
+	case "Assignment":
+		return c.assignment(n, parentId)
+	case "Conditional":
+		return c.conditional(n, parentId)
+	case "TupleExpression":
+		return c.tupleExpression(n, parentId)
+	case "FunctionCall":
+		return c.functionCall(n, parentId)
+	case "FunctionCallOptions":
+		return c.functionCallOptions(n, parentId)
+	case "MemberAccess":
+		return c.memberAccess(n, parentId)
+	case "IndexAccess":
+		return c.indexAccess(n, parentId)
+	case "IndexRangeAccess":
+		return c.indexRangeAccess(n, parentId)
+	case "NewExpression":
+		id := c.id(n)
+		return &NewExpr{
+			ASTBuilder:      c.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_NEW_EXPRESSION,
+			Src:             c.src(n, "src", parentId),
+			ArgumentTypes:   c.argumentTypes(n),
+			TypeName:        c.typeName(n.node("typeName"), id),
+			TypeDescription: c.typeDescription(n),
+		}
+	default:
+		return c.unsupported(n, "compactImporter.expression")
+	}
+}
+
+// expressions converts the list of expressions, skipping the ones that cannot be converted.
+func (c *compactImporter) expressions(nodes []compactNode, parentId int64) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0, len(nodes))
+	for _, node := range nodes {
+		if expression := c.expression(node, parentId); expression != nil {
+			toReturn = append(toReturn, expression)
+		}
+	}
+	return toReturn
+}
+
+// argumentTypes returns the argument types the compiler resolved for the expression.
+func (c *compactImporter) argumentTypes(n compactNode) []*TypeDescription {
+	toReturn := make([]*TypeDescription, 0)
+	for _, argumentType := range n.nodes("argumentTypes") {
+		if argumentType == nil {
+			continue
+		}
+		toReturn = append(toReturn, &TypeDescription{
+			TypeIdentifier: argumentType.str("typeIdentifier"),
+			TypeString:     argumentType.str("typeString"),
+		})
+	}
+	return toReturn
+}
+
+// typeDescriptions returns the type descriptions of the expressions.
+func typeDescriptions(expressions ...Node[NodeType]) []*TypeDescription {
+	toReturn := make([]*TypeDescription, 0, len(expressions))
+	for _, expression := range expressions {
+		if expression != nil {
+			toReturn = append(toReturn, expression.GetTypeDescription())
+		}
+	}
+	return toReturn
+}
+
+// identifier converts the identifier.
+func (c *compactImporter) identifier(n compactNode, parentId int64) Node[NodeType] {
+	return &PrimaryExpression{
+		ASTBuilder:             c.ASTBuilder,
+		Id:                     c.id(n),
+		NodeType:               ast_pb.NodeType_IDENTIFIER,
+		Src:                    c.src(n, "src", parentId),
+		Name:                   n.str("name"),
+		TypeDescription:        c.typeDescription(n),
+		OverloadedDeclarations: n.ints("overloadedDeclarations"),
+		ReferencedDeclaration:  n.int("referencedDeclaration"),
+		Pure:                   n.bool("isPure"),
+		ArgumentTypes:          c.argumentTypes(n),
+	}
+}
+
+// literal converts the literal. Values are kept unquoted and number sub-denominations are
+// appended without whitespace, the same way the parser based builder stores them.
+func (c *compactImporter) literal(n compactNode, parentId int64) Node[NodeType] {
+	toReturn := &PrimaryExpression{
+		ASTBuilder:             c.ASTBuilder,
+		Id:                     c.id(n),
+		NodeType:               ast_pb.NodeType_LITERAL,
+		Src:                    c.src(n, "src", parentId),
+		Value:                  n.str("value"),
+		HexValue:               n.str("hexValue"),
+		TypeDescription:        c.typeDescription(n),
+		OverloadedDeclarations: make([]int64, 0),
+		Pure:                   n.bool("isPure"),
+		ArgumentTypes:          make([]*TypeDescription, 0),
+	}
+
+	switch n.str("kind") {
+	case "bool":
+		toReturn.Kind = ast_pb.NodeType_BOOLEAN
+		toReturn.Text = c.textContaining(toReturn.Src, toReturn.Value)
+	case "number":
+		toReturn.Kind = ast_pb.NodeType_NUMBER
+		toReturn.Text = c.textContaining(toReturn.Src, toReturn.Value)
+		toReturn.Value += n.str("subdenomination")
+	case "hexString":
+		toReturn.Kind = ast_pb.NodeType_HEX_STRING
+		toReturn.Value = toReturn.HexValue
+		toReturn.Text = c.textContaining(toReturn.Src, toReturn.HexValue)
+	case "unicodeString":
+		toReturn.Kind = ast_pb.NodeType_UNICODE_STRING_LITERAL
+		toReturn.Text = c.quotedText(toReturn.Src, toReturn.Value)
+	default:
+		toReturn.Kind = ast_pb.NodeType_STRING
+		toReturn.Text = c.quotedText(toReturn.Src, toReturn.Value)
+	}
+
+	return toReturn
+}
+
+// elementaryTypeNameExpression converts elementary type names used as expressions, such as
+// the callee of type conversions.
+func (c *compactImporter) elementaryTypeNameExpression(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	typeName := c.typeName(n.node("typeName"), id)
+
+	toReturn := &PrimaryExpression{
+		ASTBuilder:             c.ASTBuilder,
+		Id:                     id,
+		NodeType:               ast_pb.NodeType_IDENTIFIER,
+		Src:                    c.src(n, "src", parentId),
+		TypeName:               typeName,
+		TypeDescription:        c.typeDescription(n),
+		OverloadedDeclarations: make([]int64, 0),
+		Pure:                   n.bool("isPure"),
+		ArgumentTypes:          c.argumentTypes(n),
+	}
+
+	if typeName != nil {
+		toReturn.Name = typeName.Name
+	}
+
+	return toReturn
+}
+
+// binaryOperation converts the binary operation into the node the parser based builder uses for
+// the operator.
+func (c *compactImporter) binaryOperation(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+	left := c.expression(n.node("leftExpression"), id)
+	right := c.expression(n.node("rightExpression"), id)
+	typeDescription := c.typeDescription(n)
+
+	switch n.str("operator") {
+	case "&&":
+		return &AndOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_AND_OPERATION,
+			Src:              src,
+			Expressions:      []Node[NodeType]{left, right},
+			TypeDescriptions: typeDescriptions(left, right),
+		}
+	case "&":
+		return &BitAndOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_BIT_AND_OPERATION,
+			Src:              src,
+			Expressions:      []Node[NodeType]{left, right},
+			TypeDescriptions: typeDescriptions(left, right),
+		}
+	case "|":
+		return &BitOrOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_BIT_OR_OPERATION,
+			Src:              src,
+			Expressions:      []Node[NodeType]{left, right},
+			TypeDescriptions: typeDescriptions(left, right),
+		}
+	case "^":
+		return &BitXorOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_BIT_XOR_OPERATION,
+			Src:              src,
+			Expressions:      []Node[NodeType]{left, right},
+			TypeDescriptions: typeDescriptions(left, right),
+			TypeDescription:  typeDescription,
+		}
+	case "<<", ">>":
+		operator := ast_pb.NodeType_SHIFT_LEFT_OPERATION
+		if n.str("operator") == ">>" {
+			operator = ast_pb.NodeType_SHIFT_RIGHT_OPERATION
+		}
+		return &ShiftOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_SHIFT_OPERATION,
+			Src:              src,
+			Operator:         operator,
+			Expressions:      []Node[NodeType]{left, right},
+			TypeDescriptions: typeDescriptions(left, right),
+			TypeDescription:  typeDescription,
+		}
+	case "**":
+		return &ExprOperation{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_EXPRESSION_OPERATION,
+			Src:              src,
+			LeftExpression:   left,
+			RightExpression:  right,
+			TypeDescriptions: typeDescriptions(left, right),
+		}
+	}
+
+	return &BinaryOperation{
+		ASTBuilder:      c.ASTBuilder,
+		Id:              id,
+		Constant:        n.bool("isConstant"),
+		Pure:            n.bool("isPure"),
+		NodeType:        ast_pb.NodeType_BINARY_OPERATION,
+		Src:             src,
+		Operator:        compactBinaryOperator(n.str("operator")),
+		LeftExpression:  left,
+		RightExpression: right,
+		TypeDescription: typeDescription,
+	}
+}
+
+// unaryOperation converts prefix and suffix unary operations.
+func (c *compactImporter) unaryOperation(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+	expression := c.expression(n.node("subExpression"), id)
+
+	var operator ast_pb.Operator
+	switch n.str("operator") {
+	case "++":
+		operator = ast_pb.Operator_INCREMENT
+	case "--":
+		operator = ast_pb.Operator_DECREMENT
+	case "!":
+		operator = ast_pb.Operator_NOT
+	case "~":
+		operator = ast_pb.Operator_BIT_NOT
+	case "-":
+		operator = ast_pb.Operator_SUBTRACT
+	default:
+		// The delete operator has no dedicated operator, same as in the parser based builder.
+		operator = ast_pb.Operator_O_DEFAULT
+	}
+
+	if !n.bool("prefix") {
+		return &UnarySuffix{
+			ASTBuilder:            c.ASTBuilder,
+			Id:                    id,
+			NodeType:              ast_pb.NodeType_UNARY_OPERATION,
+			Kind:                  ast_pb.NodeType_KIND_UNARY_SUFFIX,
+			Src:                   src,
+			Operator:              operator,
+			Expression:            expression,
+			ReferencedDeclaration: n.node("subExpression").int("referencedDeclaration"),
+			TypeDescription:       c.typeDescription(n),
+			Constant:              n.bool("isConstant"),
+			LValue:                n.bool("isLValue"),
+			Pure:                  n.bool("isPure"),
+			LValueRequested:       n.bool("lValueRequested"),
+		}
+	}
+
+	return &UnaryPrefix{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		NodeType:              ast_pb.NodeType_UNARY_OPERATION,
+		Kind:                  ast_pb.NodeType_KIND_UNARY_PREFIX,
+		Src:                   src,
+		Operator:              operator,
+		Prefix:                true,
+		Constant:              n.bool("isConstant"),
+		LValue:                n.bool("isLValue"),
+		Pure:                  n.bool("isPure"),
+		LValueRequested:       n.bool("lValueRequested"),
+		ReferencedDeclaration: n.node("subExpression").int("referencedDeclaration"),
+		Expression:            expression,
+		TypeDescription:       c.typeDescription(n),
+	}
+}
+
+// assignment converts the assignment. The referenced declaration is the one of the assigned
+// variable.
+func (c *compactImporter) assignment(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	left := n.node("leftHandSide")
+
+	var operator ast_pb.Operator
+	switch n.str("operator") {
+	case "=":
+		operator = ast_pb.Operator_EQUAL
+	case "+=":
+		operator = ast_pb.Operator_PLUS_EQUAL
+	case "-=":
+		operator = ast_pb.Operator_MINUS_EQUAL
+	case "*=":
+		operator = ast_pb.Operator_MUL_EQUAL
+	case "/=":
+		operator = ast_pb.Operator_DIVISION
+	case "%=":
+		operator = ast_pb.Operator_MOD_EQUAL
+	case "&=":
+		operator = ast_pb.Operator_AND_EQUAL
+	case "|=":
+		operator = ast_pb.Operator_OR_EQUAL
+	case "^=":
+		operator = ast_pb.Operator_XOR_EQUAL
+	case "<<=":
+		operator = ast_pb.Operator_SHIFT_LEFT_EQUAL
+	case ">>=":
+		operator = ast_pb.Operator_POW_EQUAL
+	default:
+		operator = ast_pb.Operator_O_DEFAULT
+	}
+
+	return &Assignment{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		NodeType:              ast_pb.NodeType_ASSIGNMENT,
+		Src:                   c.src(n, "src", parentId),
+		Operator:              operator,
+		LeftExpression:        c.expression(left, id),
+		RightExpression:       c.expression(n.node("rightHandSide"), id),
+		ReferencedDeclaration: left.int("referencedDeclaration"),
+		TypeDescription:       c.typeDescription(n),
+		Text:                  c.textContaining(c.src(n, "src", parentId), n.str("operator")),
+	}
+}
+
+// conditional converts the ternary conditional expression.
+func (c *compactImporter) conditional(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	expressions := []Node[NodeType]{
+		c.expression(n.node("condition"), id),
+		c.expression(n.node("trueExpression"), id),
+		c.expression(n.node("falseExpression"), id),
+	}
+
+	return &Conditional{
+		ASTBuilder:       c.ASTBuilder,
+		Id:               id,
+		NodeType:         ast_pb.NodeType_CONDITIONAL_EXPRESSION,
+		Src:              c.src(n, "src", parentId),
+		Expressions:      expressions,
+		TypeDescriptions: typeDescriptions(expressions...),
+		TypeDescription:  c.typeDescription(n),
+	}
+}
+
+// tupleExpression converts tuples and inline arrays.
+func (c *compactImporter) tupleExpression(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	components := c.expressions(n.nodes("components"), id)
+
+	if n.bool("isInlineArray") {
+		return &InlineArray{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_INLINE_ARRAY,
+			Src:              c.src(n, "src", parentId),
+			TypeDescriptions: typeDescriptions(components...),
+			Expressions:      components,
+			Empty:            len(components) == 0,
+			TypeDescription:  c.typeDescription(n),
+		}
+	}
+
+	return &TupleExpression{
+		ASTBuilder:      c.ASTBuilder,
+		Id:              id,
+		NodeType:        ast_pb.NodeType_TUPLE_EXPRESSION,
+		Src:             c.src(n, "src", parentId),
+		Constant:        n.bool("isConstant"),
+		Pure:            n.bool("isPure"),
+		Components:      components,
+		TypeDescription: c.typeDescription(n),
+	}
+}
+
+// functionCall converts function calls, type conversions and struct constructor calls.
+// Conversions to address payable and type(X) expressions get the dedicated nodes of the parser
+// based builder.
+func (c *compactImporter) functionCall(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+	callee := n.node("expression")
+
+	switch {
+	case callee.nodeType() == "ElementaryTypeNameExpression" && callee.node("typeName").str("stateMutability") == "payable":
+		arguments := c.expressions(n.nodes("arguments"), id)
+		return &PayableConversion{
+			ASTBuilder:      c.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_PAYABLE_CONVERSION,
+			Src:             src,
+			Arguments:       arguments,
+			ArgumentTypes:   typeDescriptions(arguments...),
+			TypeDescription: c.typeDescription(n),
+			Payable:         true,
+		}
+	case callee.nodeType() == "Identifier" && callee.str("name") == "type" && len(n.nodes("arguments")) == 1:
+		toReturn := &MetaType{
+			ASTBuilder:      c.ASTBuilder,
+			Id:              id,
+			NodeType:        ast_pb.NodeType_IDENTIFIER,
+			Name:            "type",
+			Src:             src,
+			TypeDescription: c.typeDescription(n),
+		}
+
+		argument := n.nodes("arguments")[0]
+		switch argument.nodeType() {
+		case "ElementaryTypeNameExpression":
+			toReturn.TypeName = c.typeName(argument.node("typeName"), id)
+		case "Identifier":
+			argumentId := c.id(argument)
+			toReturn.TypeName = &TypeName{
+				ASTBuilder:            c.ASTBuilder,
+				Id:                    argumentId,
+				NodeType:              ast_pb.NodeType_USER_DEFINED_PATH_NAME,
+				Src:                   c.src(argument, "src", id),
+				Name:                  argument.str("name"),
+				ReferencedDeclaration: argument.int("referencedDeclaration"),
+				TypeDescription:       c.typeDescription(argument),
+				PathNode: &PathNode{
+					Id:                    argumentId,
+					Name:                  argument.str("name"),
+					NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+					ReferencedDeclaration: argument.int("referencedDeclaration"),
+					Src:                   c.src(argument, "src", id),
+				},
+			}
+		}
+
+		return toReturn
+	}
+
+	expression := c.expression(callee, id)
+	arguments := c.expressions(n.nodes("arguments"), id)
+
+	return &FunctionCall{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		NodeType:              ast_pb.NodeType_FUNCTION_CALL,
+		Kind:                  ast_pb.NodeType_FUNCTION_CALL,
+		Src:                   src,
+		ArgumentTypes:         typeDescriptions(arguments...),
+		Arguments:             arguments,
+		Names:                 n.strings("names"),
+		Expression:            expression,
+		ReferencedDeclaration: callee.int("referencedDeclaration"),
+		TypeDescription:       c.typeDescription(n),
+	}
+}
+
+// functionCallOptions converts the call options, such as {value: 1}, of external calls.
+func (c *compactImporter) functionCallOptions(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	callee := n.node("expression")
+
+	return &FunctionCallOption{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		NodeType:              ast_pb.NodeType_FUNCTION_CALL_OPTION,
+		Kind:                  ast_pb.NodeType_FUNCTION_CALL_OPTION,
+		Src:                   c.src(n, "src", parentId),
+		Expression:            c.expression(callee, id),
+		Names:                 n.strings("names"),
+		Options:               c.expressions(n.nodes("options"), id),
+		ReferencedDeclaration: callee.int("referencedDeclaration"),
+		TypeDescription:       c.typeDescription(n),
+	}
+}
+
+// memberAccess converts the member access expression.
+func (c *compactImporter) memberAccess(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &MemberAccessExpression{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		Constant:              n.bool("isConstant"),
+		LValue:                n.bool("isLValue"),
+		Pure:                  n.bool("isPure"),
+		LValueRequested:       n.bool("lValueRequested"),
+		NodeType:              ast_pb.NodeType_MEMBER_ACCESS,
+		Src:                   src,
+		Expression:            c.expression(n.node("expression"), id),
+		MemberName:            n.str("memberName"),
+		ArgumentTypes:         c.argumentTypes(n),
+		ReferencedDeclaration: n.int("referencedDeclaration"),
+		TypeDescription:       c.typeDescription(n),
+		Text:                  c.textContaining(src, "."+n.str("memberName")),
+	}
+
+	if location := c.location(n, "memberLocation", id); location != nil {
+		toReturn.MemberLocation = *location
+	}
+
+	return toReturn
+}
+
+// indexAccess converts the index access expression.
+func (c *compactImporter) indexAccess(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	base := c.expression(n.node("baseExpression"), id)
+	index := c.expression(n.node("indexExpression"), id)
+
+	return &IndexAccess{
+		ASTBuilder:            c.ASTBuilder,
+		Id:                    id,
+		NodeType:              ast_pb.NodeType_INDEX_ACCESS,
+		Src:                   c.src(n, "src", parentId),
+		IndexExpression:       index,
+		BaseExpression:        base,
+		TypeDescriptions:      typeDescriptions(base, index),
+		ReferencedDeclaration: n.node("baseExpression").int("referencedDeclaration"),
+		TypeDescription:       c.typeDescription(n),
+	}
+}
+
+// indexRangeAccess converts the slice expression, such as data[start:end].
+func (c *compactImporter) indexRangeAccess(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	base := c.expression(n.node("baseExpression"), id)
+	start := c.expression(n.node("startExpression"), id)
+	end := c.expression(n.node("endExpression"), id)
+
+	return &IndexRange{
+		ASTBuilder:       c.ASTBuilder,
+		Id:               id,
+		NodeType:         ast_pb.NodeType_INDEX_RANGE_ACCESS,
+		Src:              c.src(n, "src", parentId),
+		LeftExpression:   base,
+		StartExpression:  start,
+		EndExpression:    end,
+		TypeDescriptions: typeDescriptions(base, start, end),
+	}
+}
+
+// compactBinaryOperator converts operators of the binary operation node.
+func compactBinaryOperator(operator string) ast_pb.Operator {
+	switch operator {
+	case "+":
+		return ast_pb.Operator_ADDITION
+	case "-":
+		return ast_pb.Operator_SUBTRACTION
+	case "*":
+		return ast_pb.Operator_MULTIPLICATION
+	case "/":
+		return ast_pb.Operator_DIVISION
+	case "%":
+		return ast_pb.Operator_MODULO
+	case ">":
+		return ast_pb.Operator_GREATER_THAN
+	case ">=":
+		return ast_pb.Operator_GREATER_THAN_OR_EQUAL
+	case "<":
+		return ast_pb.Operator_LESS_THAN
+	case "<=":
+		return ast_pb.Operator_LESS_THAN_OR_EQUAL
+	case "==":
+		return ast_pb.Operator_EQUAL
+	case "!=":
+		return ast_pb.Operator_NOT_EQUAL
+	case "||":
+		return ast_pb.Operator_OR
+	default:
+		return ast_pb.Operator_O_DEFAULT
+	}
+}
@@ -0,0 +1,357 @@
+package ast
+
+import (
+	"strconv"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// block converts blocks and unchecked blocks. TypedProto is set only for blocks nested within
+// statement lists, as other bodies are described with their own proto message.
+func (c *compactImporter) block(n compactNode, parentId int64, typedProto bool) *BodyNode {
+	toReturn := &BodyNode{
+		ASTBuilder:  c.ASTBuilder,
+		Id:          c.id(n),
+		NodeType:    ast_pb.NodeType_BLOCK,
+		Src:         c.src(n, "src", parentId),
+		Implemented: true,
+		Statements:  make([]Node[NodeType], 0),
+		TypedProto:  typedProto,
+	}
+
+	if n.nodeType() == "UncheckedBlock" {
+		toReturn.NodeType = ast_pb.NodeType_UNCHECKED_BLOCK
+	}
+
+	for _, statement := range n.nodes("statements") {
+		if node := c.statement(statement, toReturn.Id); node != nil {
+			toReturn.Statements = append(toReturn.Statements, node)
+		}
+	}
+
+	return toReturn
+}
+
+// body converts bodies of control statements. Single statements are wrapped into a block, as
+// control statements of the package always hold blocks.
+func (c *compactImporter) body(n compactNode, parentId int64) *BodyNode {
+	if n == nil {
+		return nil
+	}
+
+	switch n.nodeType() {
+	case "Block", "UncheckedBlock":
+		return c.block(n, parentId, false)
+	}
+
+	toReturn := NewBodyNode(c.ASTBuilder, false)
+	toReturn.Src = c.src(n, "src", parentId)
+	toReturn.Implemented = true
+
+	if statement := c.statement(n, toReturn.Id); statement != nil {
+		toReturn.Statements = append(toReturn.Statements, statement)
+	}
+
+	return toReturn
+}
+
+// statement converts the statement. Expression statements are represented by the bare
+// expression, the same way the parser based builder does it.
+func (c *compactImporter) statement(n compactNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	switch n.nodeType() {
+	case "Block", "UncheckedBlock":
+		return c.block(n, parentId, true)
+	case "ExpressionStatement":
+		return c.expression(n.node("expression"), parentId)
+	case "VariableDeclarationStatement":
+		return c.variableDeclarationStatement(n, parentId)
+	case "IfStatement":
+		return c.ifStatement(n, parentId)
+	case "ForStatement":
+		return c.forStatement(n, parentId)
+	case "WhileStatement":
+		id := c.id(n)
+		return &WhileStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_WHILE_STATEMENT,
+			Kind:       ast_pb.NodeType_WHILE,
+			Src:        c.src(n, "src", parentId),
+			Condition:  c.expression(n.node("condition"), id),
+			Body:       c.body(n.node("body"), id),
+		}
+	case "DoWhileStatement":
+		id := c.id(n)
+		return &DoWhileStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_DO_WHILE_STATEMENT,
+			Src:        c.src(n, "src", parentId),
+			Condition:  c.expression(n.node("condition"), id),
+			Body:       c.body(n.node("body"), id),
+		}
+	case "TryStatement":
+		return c.tryStatement(n, parentId)
+	case "Return":
+		id := c.id(n)
+		return &ReturnStatement{
+			ASTBuilder:               c.ASTBuilder,
+			Id:                       id,
+			NodeType:                 ast_pb.NodeType_RETURN_STATEMENT,
+			Src:                      c.src(n, "src", parentId),
+			FunctionReturnParameters: n.int("functionReturnParameters"),
+			Expression:               c.expression(n.node("expression"), id),
+		}
+	case "EmitStatement":
+		id := c.id(n)
+		call := n.node("eventCall")
+		return &Emit{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_EMIT_STATEMENT,
+			Src:        c.src(n, "src", parentId),
+			Arguments:  c.expressions(call.nodes("arguments"), id),
+			Expression: c.expression(call.node("expression"), id),
+		}
+	case "RevertStatement":
+		id := c.id(n)
+		call := n.node("errorCall")
+		return &RevertStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_REVERT_STATEMENT,
+			Src:        c.src(n, "src", parentId),
+			Arguments:  c.expressions(call.nodes("arguments"), id),
+			Expression: c.expression(call.node("expression"), id),
+		}
+	case "Break":
+		return &BreakStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         c.id(n),
+			NodeType:   ast_pb.NodeType_BREAK,
+			Src:        c.src(n, "src", parentId),
+		}
+	case "Continue":
+		return &ContinueStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         c.id(n),
+			NodeType:   ast_pb.NodeType_CONTINUE,
+			Src:        c.src(n, "src", parentId),
+		}
+	case "PlaceholderStatement":
+		return &PrimaryExpression{
+			ASTBuilder:             c.ASTBuilder,
+			Id:                     c.id(n),
+			NodeType:               ast_pb.NodeType_PLACEHOLDER_STATEMENT,
+			Src:                    c.src(n, "src", parentId),
+			Name:                   "_",
+			OverloadedDeclarations: make([]int64, 0),
+			ArgumentTypes:          make([]*TypeDescription, 0),
+			TypeDescription: &TypeDescription{
+				TypeIdentifier: "t_placeholder_literal",
+				TypeString:     "t_placeholder",
+			},
+		}
+	case "InlineAssembly":
+		return c.inlineAssembly(n, parentId)
+	default:
+		return c.unsupported(n, "compactImporter.statement")
+	}
+}
+
+// variableDeclarationStatement converts local variable declarations. Omitted components of
+// tuple declarations keep a zero assignment, the same way the compiler keeps a null one.
+func (c *compactImporter) variableDeclarationStatement(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &VariableDeclaration{
+		ASTBuilder:   c.ASTBuilder,
+		Id:           id,
+		NodeType:     ast_pb.NodeType_VARIABLE_DECLARATION,
+		Src:          c.src(n, "src", parentId),
+		Assignments:  n.ints("assignments"),
+		Declarations: make([]*Declaration, 0),
+	}
+
+	for _, declaration := range n.nodes("declarations") {
+		if declaration != nil {
+			toReturn.Declarations = append(toReturn.Declarations, c.declaration(declaration, id))
+		}
+	}
+
+	if value := n.node("initialValue"); value != nil {
+		toReturn.InitialValue = c.expression(value, id)
+	}
+
+	c.currentVariables = append(c.currentVariables, toReturn)
+	return toReturn
+}
+
+// declaration converts the local variable declaration. Variables declared without a type name
+// get one made of the compiler resolved type.
+func (c *compactImporter) declaration(n compactNode, parentId int64) *Declaration {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &Declaration{
+		ASTBuilder:      c.ASTBuilder,
+		Id:              id,
+		StateMutability: ast_pb.Mutability_MUTABLE,
+		Name:            n.str("name"),
+		NodeType:        ast_pb.NodeType_VARIABLE_DECLARATION,
+		Scope:           n.int("scope"),
+		Src:             src,
+		NameLocation:    c.src(n, "nameLocation", id),
+		StorageLocation: compactStorageLocation(n.str("storageLocation"), ast_pb.StorageLocation_DEFAULT),
+		TypeName:        c.typeName(n.node("typeName"), id),
+		Visibility:      ast_pb.Visibility_INTERNAL,
+	}
+
+	if toReturn.TypeName == nil {
+		toReturn.TypeName = &TypeName{
+			ASTBuilder:      c.ASTBuilder,
+			Id:              c.GetNextID(),
+			NodeType:        ast_pb.NodeType_ELEMENTARY_TYPE_NAME,
+			Src:             src,
+			TypeDescription: c.typeDescription(n),
+		}
+		toReturn.TypeName.Src.ParentIndex = id
+		if toReturn.TypeName.TypeDescription != nil {
+			toReturn.TypeName.Name = toReturn.TypeName.TypeDescription.TypeString
+		}
+	}
+
+	c.currentVariables = append(c.currentVariables, toReturn)
+	return toReturn
+}
+
+// ifStatement converts the if statement. Else if branches are kept as nested if statements.
+func (c *compactImporter) ifStatement(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &IfStatement{
+		ASTBuilder: c.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_IF_STATEMENT,
+		Src:        c.src(n, "src", parentId),
+		Condition:  c.expression(n.node("condition"), id),
+		Body:       c.body(n.node("trueBody"), id),
+	}
+
+	if falseBody := n.node("falseBody"); falseBody != nil {
+		if falseBody.nodeType() == "IfStatement" {
+			toReturn.Else = c.ifStatement(falseBody, id)
+		} else {
+			toReturn.Else = c.body(falseBody, id)
+		}
+	}
+
+	return toReturn
+}
+
+// forStatement converts the for statement.
+func (c *compactImporter) forStatement(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &ForStatement{
+		ASTBuilder: c.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_FOR_STATEMENT,
+		Src:        c.src(n, "src", parentId),
+		Condition:  c.expression(n.node("condition"), id),
+		Body:       c.body(n.node("body"), id),
+	}
+
+	if initialiser := n.node("initializationExpression"); initialiser != nil {
+		toReturn.Initialiser = c.statement(initialiser, id)
+	}
+
+	if closure := n.node("loopExpression"); closure != nil {
+		toReturn.Closure = c.statement(closure, id)
+	}
+
+	return toReturn
+}
+
+// tryStatement converts the try statement. The compiler keeps the success block as the first
+// clause, while the package keeps it as the body of the statement.
+func (c *compactImporter) tryStatement(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+	src := c.src(n, "src", parentId)
+
+	toReturn := &TryStatement{
+		ASTBuilder:  c.ASTBuilder,
+		Id:          id,
+		NodeType:    ast_pb.NodeType_TRY_STATEMENT,
+		Kind:        ast_pb.NodeType_TRY,
+		Src:         src,
+		Expression:  c.expression(n.node("externalCall"), id),
+		Clauses:     make([]Node[NodeType], 0),
+		Implemented: true,
+	}
+
+	for i, clause := range n.nodes("clauses") {
+		if i == 0 {
+			toReturn.Body = c.body(clause.node("block"), id)
+			toReturn.Returns = clause.node("parameters") != nil
+			toReturn.ReturnParameters = c.parameterList(clause.node("parameters"), id, src, ast_pb.StorageLocation_MEMORY)
+			continue
+		}
+
+		clauseId := c.id(clause)
+		clauseSrc := c.src(clause, "src", id)
+		toReturn.Clauses = append(toReturn.Clauses, &CatchStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         clauseId,
+			Name:       clause.str("errorName"),
+			NodeType:   ast_pb.NodeType_TRY_CATCH_CLAUSE,
+			Kind:       ast_pb.NodeType_CATCH,
+			Src:        clauseSrc,
+			Body:       c.body(clause.node("block"), clauseId),
+			Parameters: c.parameterList(clause.node("parameters"), clauseId, clauseSrc, ast_pb.StorageLocation_MEMORY),
+		})
+	}
+
+	if toReturn.Body == nil {
+		toReturn.Body = NewBodyNode(c.ASTBuilder, false)
+		toReturn.Body.Src = src
+		toReturn.Body.Src.ParentIndex = id
+	}
+
+	if toReturn.ReturnParameters == nil {
+		toReturn.ReturnParameters = c.parameterList(nil, id, src, ast_pb.StorageLocation_MEMORY)
+	}
+
+	return toReturn
+}
+
+// inlineAssembly converts the inline assembly statement. Flags are quoted, as they are written
+// in the source code.
+func (c *compactImporter) inlineAssembly(n compactNode, parentId int64) Node[NodeType] {
+	id := c.id(n)
+
+	toReturn := &Yul{
+		ASTBuilder: c.ASTBuilder,
+		Id:         id,
+		NodeType:   ast_pb.NodeType_ASSEMBLY_STATEMENT,
+		Src:        c.src(n, "src", parentId),
+	}
+
+	for _, flag := range n.strings("flags") {
+		toReturn.Flags = append(toReturn.Flags, strconv.Quote(flag))
+	}
+
+	toReturn.Body = c.yulBlock(n.node("AST"), id)
+	if toReturn.Body == nil {
+		toReturn.Body = NewBodyNode(c.ASTBuilder, false)
+		toReturn.Body.NodeType = ast_pb.NodeType_YUL_BLOCK
+		toReturn.Body.Src = toReturn.Src
+		toReturn.Body.Src.ParentIndex = id
+	}
+
+	return toReturn
+}
@@ -0,0 +1,351 @@
+package ast
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/tests"
+)
+
+// buildCompactOutput assembles the solc --standard-json output from the compact ASTs printed by
+// solc --ast-compact-json into the test fixtures, returning it together with the sources.
+func buildCompactOutput(t *testing.T, entry string, fixtures ...string) ([]byte, *solgo.Sources) {
+	sources := newTestSources(entry)
+
+	// Compiler prints headers in between the ASTs of the sources.
+	lines := make([]string, 0)
+	for _, fixture := range fixtures {
+		contract := tests.ReadContractFileForTest(t, "ast/"+fixture)
+		content, err := os.ReadFile(filepath.Join(filepath.Dir(contract.Path), fixture+".solc.ast.json"))
+		require.NoError(t, err)
+
+		for _, line := range strings.Split(string(content), "\n") {
+			if !strings.HasPrefix(line, "=======") && !strings.HasPrefix(line, "JSON AST") {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	outputSources := make(map[string]any)
+	decoder := json.NewDecoder(strings.NewReader(strings.Join(lines, "\n")))
+	for decoder.More() {
+		var unit map[string]any
+		require.NoError(t, decoder.Decode(&unit))
+
+		path := unit["absolutePath"].(string)
+		sources.SourceUnits = append(sources.SourceUnits, newTestSourceUnit(t, strings.TrimSuffix(path, ".sol")))
+
+		outputSources[path] = map[string]any{
+			"id":  len(outputSources),
+			"ast": unit,
+		}
+	}
+
+	output, err := json.Marshal(map[string]any{"sources": outputSources})
+	require.NoError(t, err)
+
+	return output, sources
+}
+
+// getCompactFunction returns the function with the provided name from the contract of the unit.
+func getCompactFunction(t *testing.T, unit *SourceUnit[Node[ast_pb.SourceUnit]], name string) *Function {
+	for _, node := range unit.GetContract().GetNodes() {
+		if function, ok := node.(*Function); ok && function.GetName() == name {
+			return function
+		}
+	}
+
+	require.Failf(t, "function not found", "function %s", name)
+	return nil
+}
+
+func TestImportFromCompactJSON(t *testing.T) {
+	output, sources := buildCompactOutput(t, "SimpleStorage", "SimpleStorage", "MathLib")
+
+	b := NewAstBuilder(nil, sources)
+	root, err := b.ImportFromCompactJSON(context.TODO(), output)
+	require.NoError(t, err)
+	require.NotNil(t, root)
+	assert.Equal(t, root, b.GetRoot())
+	assert.Equal(t, root, b.GetTree().GetRoot())
+
+	require.Len(t, root.GetSourceUnits(), 2)
+	storage := root.GetSourceUnitByName("SimpleStorage")
+	library := root.GetSourceUnitByName("MathLib")
+	require.NotNil(t, storage)
+	require.NotNil(t, library)
+
+	// Source unit and contract ids are the ones assigned by the compiler.
+	assert.Equal(t, int64(43), storage.GetId())
+	assert.Equal(t, int64(155), library.GetId())
+	assert.Equal(t, storage.GetId(), root.GetEntrySourceUnit())
+	assert.Equal(t, int64(42), storage.GetContract().GetId())
+	assert.Equal(t, int64(154), library.GetContract().GetId())
+	assert.IsType(t, &Library{}, library.GetContract())
+	assert.Equal(t, "MIT", storage.GetLicense())
+
+	// Nodes the compiler does not number get ids after the compiler ones.
+	assert.Greater(t, root.GetId(), int64(155))
+
+	// Pragmas and imports stay at the top of the source unit.
+	require.Len(t, storage.GetNodes(), 3)
+	pragma, ok := storage.GetNodes()[0].(*Pragma)
+	require.True(t, ok)
+	assert.Equal(t, "pragmasolidity^0.8.0;", pragma.GetText())
+	imp, ok := storage.GetNodes()[1].(*Import)
+	require.True(t, ok)
+	assert.Equal(t, "./MathLib.sol", imp.GetFile())
+	assert.Equal(t, int64(155), imp.GetSourceUnit())
+
+	// Source locations get lines and columns from the sources.
+	increment := getCompactFunction(t, storage, "increment")
+	assert.Equal(t, int64(12), increment.GetSrc().GetLine())
+	assert.Equal(t, int64(4), increment.GetSrc().GetColumn())
+	assert.Equal(t, storage.GetContract().GetId(), increment.GetSrc().GetParentIndex())
+	assert.Equal(t, "increment(uint)", increment.GetSignatureRaw())
+
+	// Types and references are the ones resolved by the compiler.
+	require.Len(t, increment.GetBody().GetStatements(), 1)
+	assignment, ok := increment.GetBody().GetStatements()[0].(*Assignment)
+	require.True(t, ok)
+	assert.Equal(t, "uint256", assignment.GetTypeDescription().GetString())
+
+	stored, ok := assignment.LeftExpression.(*PrimaryExpression)
+	require.True(t, ok)
+	assert.Equal(t, "storedData", stored.GetName())
+	assert.Equal(t, assignment.ReferencedDeclaration, stored.GetReferencedDeclaration())
+	assert.Equal(t, stored.GetReferencedDeclaration(), b.GetTree().GetById(stored.GetReferencedDeclaration()).GetId())
+
+	call, ok := assignment.RightExpression.(*FunctionCall)
+	require.True(t, ok)
+	member, ok := call.GetExpression().(*MemberAccessExpression)
+	require.True(t, ok)
+	assert.Equal(t, "add", member.GetMemberName())
+	assert.Equal(t, getCompactFunction(t, library, "add").GetId(), member.GetReferencedDeclaration())
+	assert.Equal(t, "t_uint256", call.GetArgumentTypes()[0].GetIdentifier())
+
+	add := getCompactFunction(t, library, "add")
+	assert.Equal(t, ast_pb.Visibility_INTERNAL, add.GetVisibility())
+	assert.Equal(t, ast_pb.Mutability_PURE, add.GetStateMutability())
+	require.Len(t, add.GetParameters().GetParameters(), 2)
+	assert.Equal(t, "t_uint256", add.GetParameters().GetParameters()[0].GetTypeDescription().GetIdentifier())
+	require.Len(t, add.GetReturnParameters().GetParameters(), 1)
+
+	// Imported trees can be walked and serialized like the parser based ones.
+	assert.NotNil(t, b.ToProto())
+	_, err = b.ToJSON()
+	require.NoError(t, err)
+}
+
+func TestImportFromCompactJSONStatements(t *testing.T) {
+	output, sources := buildCompactOutput(t, "Lottery", "Lottery")
+
+	b := NewAstBuilder(nil, sources)
+	root, err := b.ImportFromCompactJSON(context.TODO(), output)
+	require.NoError(t, err)
+
+	// Every contract of the file gets its own source unit, the first one keeping the file id.
+	require.Len(t, root.GetSourceUnits(), 2)
+	dummy := root.GetSourceUnitByName("IDummyContract")
+	lottery := root.GetSourceUnitByName("Lottery")
+	require.NotNil(t, dummy)
+	require.NotNil(t, lottery)
+	assert.IsType(t, &Interface{}, dummy.GetContract())
+	assert.Equal(t, lottery.GetId(), root.GetEntrySourceUnit())
+	assert.NotEqual(t, dummy.GetNodes()[0].GetId(), lottery.GetNodes()[0].GetId())
+
+	kinds := make(map[string]int)
+	for _, node := range lottery.GetContract().GetNodes() {
+		kinds[reflect.TypeOf(node).Elem().Name()]++
+	}
+	assert.Equal(t, map[string]int{
+		"StateVariableDeclaration": 4,
+		"EnumDefinition":           1,
+		"StructDefinition":         1,
+		"EventDefinition":          4,
+		"ErrorDefinition":          6,
+		"ModifierDefinition":       2,
+		"Fallback":                 1,
+		"Receive":                  1,
+		"Constructor":              1,
+		"Function":                 9,
+	}, kinds)
+
+	join := getCompactFunction(t, lottery, "join")
+	require.Len(t, join.GetModifiers(), 2)
+	assert.Equal(t, "inState", join.GetModifiers()[0].GetName())
+	assert.Equal(t, ast_pb.Mutability_PAYABLE, join.GetStateMutability())
+
+	revert, ok := join.GetBody().GetStatements()[0].(*IfStatement).GetBody().(*BodyNode).GetStatements()[0].(*RevertStatement)
+	require.True(t, ok)
+	assert.Equal(t, "NoValueProvided", revert.GetExpression().(*PrimaryExpression).GetName())
+	assert.IsType(t, &Emit{}, join.GetBody().GetStatements()[3])
+
+	finish := getCompactFunction(t, lottery, "finishLottery")
+	statementTypes := make([]string, 0)
+	for _, statement := range finish.GetBody().GetStatements() {
+		statementTypes = append(statementTypes, reflect.TypeOf(statement).Elem().Name())
+	}
+	assert.Equal(t, []string{
+		"Assignment", "VariableDeclaration", "VariableDeclaration", "VariableDeclaration",
+		"WhileStatement", "IfStatement", "Emit", "VariableDeclaration", "FunctionCall",
+	}, statementTypes)
+
+	transfer := finish.GetBody().GetStatements()[8].(*FunctionCall)
+	assert.IsType(t, &PayableConversion{}, transfer.GetExpression().(*MemberAccessExpression).GetExpression())
+
+	external := getCompactFunction(t, lottery, "callExternalFunction")
+	try, ok := external.GetBody().GetStatements()[1].(*TryStatement)
+	require.True(t, ok)
+	require.Len(t, try.GetClauses(), 1)
+	assert.Len(t, try.GetBody().GetStatements(), 1)
+	assert.Len(t, try.GetClauses()[0].(*CatchStatement).GetParameters().GetParameters(), 1)
+
+	assembly := getCompactFunction(t, lottery, "dummyFunctionAssembly")
+	yul, ok := assembly.GetBody().GetStatements()[0].(*Yul)
+	require.True(t, ok)
+	require.Len(t, yul.GetBody().GetStatements(), 1)
+	yulAssignment, ok := yul.GetBody().GetStatements()[0].(*YulAssignment)
+	require.True(t, ok)
+	assert.Equal(t, "result", yulAssignment.GetVariableNames()[0].GetName())
+	assert.Equal(t, "add", yulAssignment.GetValue().(*YulFunctionCallStatement).GetFunctionName().GetName())
+
+	assert.NotNil(t, b.ToProto())
+}
+
+func TestImportFromCompactJSONBaseContracts(t *testing.T) {
+	unit := `{
+		"nodeType": "SourceUnit", "id": 10, "absolutePath": "Token.sol", "src": "0:60:0",
+		"nodes": [
+			{"nodeType": "PragmaDirective", "id": 1, "literals": ["solidity", "^", "0.8", ".0"], "src": "0:23:0"},
+			{
+				"nodeType": "ContractDefinition", "id": 3, "name": "Base", "contractKind": "contract",
+				"abstract": true, "fullyImplemented": true, "linearizedBaseContracts": [3],
+				"baseContracts": [], "nodes": [], "src": "24:16:0", "nameLocation": "33:4:0"
+			},
+			{
+				"nodeType": "ContractDefinition", "id": 9, "name": "Token", "contractKind": "contract",
+				"fullyImplemented": true, "linearizedBaseContracts": [9, 3], "nodes": [], "src": "41:19:0",
+				"baseContracts": [{
+					"nodeType": "InheritanceSpecifier", "id": 5, "src": "55:4:0",
+					"baseName": {"nodeType": "IdentifierPath", "id": 4, "name": "Base", "referencedDeclaration": 3, "src": "55:4:0"}
+				}]
+			}
+		]
+	}`
+
+	b := NewAstBuilder(nil, nil)
+	root, err := b.ImportFromCompactJSON(context.TODO(), []byte(unit))
+	require.NoError(t, err)
+	require.Len(t, root.GetSourceUnits(), 2)
+
+	base := root.GetSourceUnitByName("Base")
+	token := root.GetSourceUnitByName("Token")
+	require.NotNil(t, base)
+	require.NotNil(t, token)
+	assert.Equal(t, int64(10), base.GetId())
+	assert.Greater(t, token.GetId(), int64(10))
+	assert.Equal(t, token.GetId(), root.GetEntrySourceUnit())
+
+	// Pragmas are copied into every source unit of the file under new ids.
+	assert.Equal(t, int64(1), base.GetNodes()[0].GetId())
+	assert.Greater(t, token.GetNodes()[0].GetId(), int64(10))
+	assert.Equal(t, token.GetId(), token.GetNodes()[0].GetSrc().GetParentIndex())
+
+	// Base names reference the source unit of the base, keeping the contract referenced by the
+	// compiler aside.
+	require.Len(t, token.GetBaseContracts(), 1)
+	baseName := token.GetBaseContracts()[0].BaseName
+	assert.Equal(t, base.GetId(), baseName.ReferencedDeclaration)
+	assert.Equal(t, int64(3), baseName.ContractReferencedDeclaration)
+	assert.Equal(t, []int64{9, 3}, token.GetContract().(*Contract).GetLinearizedBaseContracts())
+}
+
+func TestImportFromCompactJSONSingleSourceUnit(t *testing.T) {
+	contract := tests.ReadContractFileForTest(t, "ast/MathLib")
+	content, err := os.ReadFile(filepath.Join(filepath.Dir(contract.Path), "MathLib.solc.ast.json"))
+	require.NoError(t, err)
+
+	// Without sources lines and columns cannot be resolved, while offsets still can.
+	b := NewAstBuilder(nil, nil)
+	root, err := b.ImportFromCompactJSON(context.TODO(), content)
+	require.NoError(t, err)
+	require.Len(t, root.GetSourceUnits(), 1)
+
+	unit := root.GetSourceUnits()[0]
+	assert.Equal(t, "MathLib", unit.GetName())
+	assert.Equal(t, unit.GetId(), root.GetEntrySourceUnit())
+
+	mul := getCompactFunction(t, unit, "mul")
+	assert.Zero(t, mul.GetSrc().GetLine())
+	assert.NotZero(t, mul.GetSrc().GetStart())
+	assert.Equal(t, mul.GetSrc().GetStart()+mul.GetSrc().GetLength()-1, mul.GetSrc().GetEnd())
+
+	ifStatement, ok := mul.GetBody().GetStatements()[0].(*IfStatement)
+	require.True(t, ok)
+	assert.IsType(t, &BodyNode{}, ifStatement.GetBody())
+
+	declaration, ok := mul.GetBody().GetStatements()[1].(*VariableDeclaration)
+	require.True(t, ok)
+	require.Len(t, declaration.GetDeclarations(), 1)
+	assert.Equal(t, "c", declaration.GetDeclarations()[0].GetName())
+	assert.Equal(t, []int64{declaration.GetDeclarations()[0].GetId()}, declaration.GetAssignments())
+}
+
+func TestImportFromCompactJSONErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		contains string
+	}{
+		{
+			name:  "InvalidJSON",
+			input: "{",
+		},
+		{
+			name:     "CompilationError",
+			input:    `{"errors":[{"severity":"error","message":"ParserError: Expected ';'"}]}`,
+			contains: "Expected ';'",
+		},
+		{
+			name:     "MissingSources",
+			input:    `{"errors":[{"severity":"warning","message":"unused variable"}]}`,
+			contains: "no sources",
+		},
+		{
+			name:     "MissingAst",
+			input:    `{"sources":{"A.sol":{"id":0}}}`,
+			contains: "A.sol",
+		},
+		{
+			name:     "LegacyAst",
+			input:    `{"sources":{"A.sol":{"id":0,"legacyAST":{"name":"SourceUnit","children":[]}}}}`,
+			contains: "legacy",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewAstBuilder(nil, nil).ImportFromCompactJSON(context.TODO(), []byte(testCase.input))
+			require.Error(t, err)
+			assert.True(t, strings.Contains(err.Error(), testCase.contains), err.Error())
+		})
+	}
+}
+
+func TestImportFromCompactJSONCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewAstBuilder(nil, nil).ImportFromCompactJSON(ctx, []byte(`{"sources":{"A.sol":{"id":0,"ast":{"nodeType":"SourceUnit","id":1,"src":"0:0:0","absolutePath":"A.sol","nodes":[]}}}}`))
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,119 @@
+package ast
+
+import (
+	"fmt"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// typeName converts elementary, user defined, array, mapping and function type names.
+// Names follow the parser based builder, as they are used to compute function signatures.
+func (c *compactImporter) typeName(n compactNode, parentId int64) *TypeName {
+	if n == nil {
+		return nil
+	}
+
+	id := c.id(n)
+	toReturn := &TypeName{
+		ASTBuilder:      c.ASTBuilder,
+		Id:              id,
+		Src:             c.src(n, "src", parentId),
+		TypeDescription: c.typeDescription(n),
+	}
+
+	switch n.nodeType() {
+	case "ElementaryTypeName":
+		toReturn.NodeType = ast_pb.NodeType_ELEMENTARY_TYPE_NAME
+		toReturn.Name = n.str("name")
+		switch n.str("stateMutability") {
+		case "payable":
+			toReturn.StateMutability = ast_pb.Mutability_PAYABLE
+		case "nonpayable":
+			toReturn.StateMutability = ast_pb.Mutability_NONPAYABLE
+		}
+		if toReturn.Name == "address" && toReturn.StateMutability == ast_pb.Mutability_PAYABLE {
+			toReturn.Name = "addresspayable"
+		}
+	case "UserDefinedTypeName":
+		toReturn.NodeType = ast_pb.NodeType_USER_DEFINED_PATH_NAME
+		toReturn.Name = n.str("name")
+		toReturn.ReferencedDeclaration = n.int("referencedDeclaration")
+
+		if path := n.node("pathNode"); path != nil {
+			toReturn.Name = path.str("name")
+			toReturn.PathNode = &PathNode{
+				Id:                    c.id(path),
+				Name:                  path.str("name"),
+				NodeType:              ast_pb.NodeType_IDENTIFIER_PATH,
+				ReferencedDeclaration: path.int("referencedDeclaration"),
+				Src:                   c.src(path, "src", id),
+				TypeDescription:       toReturn.TypeDescription,
+			}
+		}
+	case "ArrayTypeName":
+		base := c.typeName(n.node("baseType"), id)
+		toReturn.NodeType = base.NodeType
+		toReturn.PathNode = base.PathNode
+		toReturn.ReferencedDeclaration = base.ReferencedDeclaration
+		toReturn.StateMutability = base.StateMutability
+
+		length := ""
+		if lengthNode := n.node("length"); lengthNode != nil {
+			toReturn.Expression = c.expression(lengthNode, id)
+			if length = c.text(c.src(lengthNode, "src", id)); length == "" {
+				length = lengthNode.str("value")
+			}
+		}
+		toReturn.Name = fmt.Sprintf("%s[%s]", base.Name, length)
+	case "Mapping":
+		toReturn.NodeType = ast_pb.NodeType_MAPPING_TYPE_NAME
+		toReturn.KeyType = c.typeName(n.node("keyType"), id)
+		toReturn.ValueType = c.typeName(n.node("valueType"), id)
+		toReturn.KeyNameLocation = c.location(n, "keyNameLocation", id)
+		toReturn.ValueNameLocation = c.location(n, "valueNameLocation", id)
+	case "FunctionTypeName":
+		toReturn.NodeType = ast_pb.NodeType_FUNCTION_TYPE_NAME
+		toReturn.Name = "function"
+		toReturn.Expression = c.functionTypeName(n, id, toReturn.Src)
+		if toReturn.TypeDescription == nil {
+			toReturn.TypeDescription = toReturn.Expression.GetTypeDescription()
+		}
+	default:
+		c.unsupported(n, "compactImporter.typeName")
+	}
+
+	if toReturn.TypeDescription == nil {
+		if typeString, typeIdentifier, found := normalizeTypeDescriptionWithStatus(toReturn.Name); found {
+			toReturn.TypeDescription = &TypeDescription{
+				TypeString:     typeString,
+				TypeIdentifier: typeIdentifier,
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// functionTypeName converts the function type name into an unimplemented function, the same way
+// the parser based builder represents function types.
+func (c *compactImporter) functionTypeName(n compactNode, parentId int64, src SrcNode) *Function {
+	toReturn := NewFunction(c.ASTBuilder)
+	toReturn.Id = c.GetNextID()
+	toReturn.Src = src
+	toReturn.Src.ParentIndex = parentId
+	toReturn.Scope = c.contractId
+	toReturn.Visibility = compactVisibility(n.str("visibility"))
+	toReturn.StateMutability = compactMutability(n.str("stateMutability"))
+	toReturn.Parameters = c.parameterList(n.node("parameterTypes"), toReturn.Id, src, ast_pb.StorageLocation_MEMORY)
+	toReturn.ReturnParameters = c.parameterList(n.node("returnParameterTypes"), toReturn.Id, src, ast_pb.StorageLocation_MEMORY)
+
+	body := NewBodyNode(c.ASTBuilder, false)
+	body.Src = src
+	body.Src.ParentIndex = toReturn.Id
+	toReturn.Body = body
+
+	toReturn.TypeDescription = toReturn.buildTypeDescription()
+	toReturn.ComputeSignature()
+
+	return toReturn
+}
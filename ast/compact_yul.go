@@ -0,0 +1,237 @@
+package ast
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// yulBlock converts the top level block of inline assembly. Yul nodes have no compiler ids, so
+// all of them get new ones.
+func (c *compactImporter) yulBlock(n compactNode, parentId int64) *BodyNode {
+	if n == nil {
+		return nil
+	}
+
+	toReturn := NewBodyNode(c.ASTBuilder, false)
+	toReturn.NodeType = ast_pb.NodeType_YUL_BLOCK
+	toReturn.Src = c.src(n, "src", parentId)
+	toReturn.Implemented = true
+
+	for _, statement := range n.nodes("statements") {
+		if node := c.yulStatement(statement, toReturn.Id); node != nil {
+			toReturn.Statements = append(toReturn.Statements, node)
+		}
+	}
+
+	return toReturn
+}
+
+// yulStatement converts the Yul statement. Statements are not wrapped into YulStatement nodes,
+// as the compiler does not know about them.
+func (c *compactImporter) yulStatement(n compactNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	id := c.GetNextID()
+	src := c.src(n, "src", parentId)
+
+	switch n.nodeType() {
+	case "YulBlock":
+		toReturn := &YulBlockStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_BLOCK,
+			Src:        src,
+			Statements: make([]Node[NodeType], 0),
+		}
+		for _, statement := range n.nodes("statements") {
+			if node := c.yulStatement(statement, id); node != nil {
+				toReturn.Statements = append(toReturn.Statements, node)
+			}
+		}
+		return toReturn
+	case "YulVariableDeclaration":
+		return &YulVariable{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_VARIABLE_DECLARATION,
+			Src:        src,
+			Let:        true,
+			Value:      c.yulExpression(n.node("value"), id),
+			Variables:  c.yulIdentifiers(n.nodes("variables"), id),
+		}
+	case "YulAssignment":
+		return &YulAssignment{
+			ASTBuilder:    c.ASTBuilder,
+			Id:            id,
+			NodeType:      ast_pb.NodeType_YUL_ASSIGNMENT,
+			Src:           src,
+			VariableNames: c.yulIdentifiers(n.nodes("variableNames"), id),
+			Value:         c.yulExpression(n.node("value"), id),
+		}
+	case "YulExpressionStatement":
+		return c.yulExpression(n.node("expression"), parentId)
+	case "YulIf":
+		return &YulIfStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_IF,
+			Src:        src,
+			Condition:  c.yulExpression(n.node("condition"), id),
+			Body:       c.yulStatement(n.node("body"), id),
+		}
+	case "YulSwitch":
+		toReturn := &YulSwitchStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_SWITCH,
+			Src:        src,
+			Expression: c.yulExpression(n.node("expression"), id),
+			Cases:      make([]Node[NodeType], 0),
+		}
+		for _, switchCase := range n.nodes("cases") {
+			// Default case has the "default" string in place of the case value.
+			if _, ok := switchCase["value"].(string); ok {
+				toReturn.Default = c.yulStatement(switchCase.node("body"), id)
+				continue
+			}
+
+			caseId := c.GetNextID()
+			toReturn.Cases = append(toReturn.Cases, &YulSwitchCaseStatement{
+				ASTBuilder: c.ASTBuilder,
+				Id:         caseId,
+				NodeType:   ast_pb.NodeType_YUL_SWITCH_CASE,
+				Src:        c.src(switchCase, "src", id),
+				Case:       c.yulExpression(switchCase.node("value"), caseId),
+				Body:       c.yulStatement(switchCase.node("body"), caseId),
+			})
+		}
+		return toReturn
+	case "YulForLoop":
+		return &YulForStatement{
+			ASTBuilder: c.ASTBuilder,
+			Id:         id,
+			NodeType:   ast_pb.NodeType_YUL_FOR,
+			Src:        src,
+			Pre:        c.yulStatement(n.node("pre"), id),
+			Condition:  c.yulExpression(n.node("condition"), id),
+			Post:       c.yulStatement(n.node("post"), id),
+			Body:       c.yulStatement(n.node("body"), id),
+		}
+	case "YulFunctionDefinition":
+		return &YulFunctionDefinition{
+			ASTBuilder:       c.ASTBuilder,
+			Id:               id,
+			NodeType:         ast_pb.NodeType_YUL_FUNCTION_DEFINITION,
+			Src:              src,
+			Name:             n.str("name"),
+			Arguments:        c.yulIdentifiers(n.nodes("parameters"), id),
+			ReturnParameters: c.yulIdentifiers(n.nodes("returnVariables"), id),
+			Body:             c.yulStatement(n.node("body"), id),
+		}
+	case "YulBreak":
+		return &YulBreakStatement{ASTBuilder: c.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_BREAK, Src: src}
+	case "YulContinue":
+		return &YulContinueStatement{ASTBuilder: c.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_CONTINUE, Src: src}
+	case "YulLeave":
+		return &YulLeaveStatement{ASTBuilder: c.ASTBuilder, Id: id, NodeType: ast_pb.NodeType_YUL_LEAVE, Src: src}
+	default:
+		return c.unsupported(n, "compactImporter.yulStatement")
+	}
+}
+
+// yulExpression converts Yul function calls, identifiers and literals.
+func (c *compactImporter) yulExpression(n compactNode, parentId int64) Node[NodeType] {
+	if n == nil {
+		return nil
+	}
+
+	switch n.nodeType() {
+	case "YulFunctionCall":
+		id := c.GetNextID()
+		toReturn := &YulFunctionCallStatement{
+			ASTBuilder:   c.ASTBuilder,
+			Id:           id,
+			NodeType:     ast_pb.NodeType_YUL_FUNCTION_CALL,
+			Src:          c.src(n, "src", parentId),
+			FunctionName: c.yulIdentifier(n.node("functionName"), id),
+			Arguments:    make([]Node[NodeType], 0),
+		}
+		for _, argument := range n.nodes("arguments") {
+			if expression := c.yulExpression(argument, id); expression != nil {
+				toReturn.Arguments = append(toReturn.Arguments, expression)
+			}
+		}
+		return toReturn
+	case "YulIdentifier":
+		return c.yulIdentifier(n, parentId)
+	case "YulLiteral":
+		return c.yulLiteral(n, parentId)
+	default:
+		return c.unsupported(n, "compactImporter.yulExpression")
+	}
+}
+
+// yulIdentifier converts Yul identifiers and typed names.
+func (c *compactImporter) yulIdentifier(n compactNode, parentId int64) *YulIdentifier {
+	return &YulIdentifier{
+		ASTBuilder: c.ASTBuilder,
+		Id:         c.GetNextID(),
+		NodeType:   ast_pb.NodeType_YUL_IDENTIFIER,
+		Src:        c.src(n, "src", parentId),
+		Name:       n.str("name"),
+	}
+}
+
+// yulIdentifiers converts the list of Yul identifiers or typed names.
+func (c *compactImporter) yulIdentifiers(nodes []compactNode, parentId int64) []*YulIdentifier {
+	toReturn := make([]*YulIdentifier, 0, len(nodes))
+	for _, node := range nodes {
+		toReturn = append(toReturn, c.yulIdentifier(node, parentId))
+	}
+	return toReturn
+}
+
+// yulLiteral converts the Yul literal. Values follow the parser based builder: strings keep
+// their quotes and hex numbers keep the hex text as the hex value and the decimal as the value.
+func (c *compactImporter) yulLiteral(n compactNode, parentId int64) Node[NodeType] {
+	toReturn := &YulLiteralStatement{
+		ASTBuilder: c.ASTBuilder,
+		Id:         c.GetNextID(),
+		NodeType:   ast_pb.NodeType_YUL_LITERAL,
+		Src:        c.src(n, "src", parentId),
+		Value:      n.str("value"),
+	}
+	text := c.textContaining(toReturn.Src, toReturn.Value)
+
+	switch n.str("kind") {
+	case "bool":
+		toReturn.Kind = ast_pb.NodeType_BOOLEAN
+	case "number":
+		toReturn.Kind = ast_pb.NodeType_DECIMAL_NUMBER
+		if strings.HasPrefix(toReturn.Value, "0x") {
+			toReturn.Kind = ast_pb.NodeType_HEX_NUMBER
+			toReturn.HexValue = toReturn.Value
+			if value, ok := new(big.Int).SetString(strings.TrimPrefix(toReturn.Value, "0x"), 16); ok {
+				toReturn.Value = value.String()
+			}
+		}
+	default:
+		if strings.HasPrefix(text, "hex") {
+			toReturn.Kind = ast_pb.NodeType_HEX_STRING
+			toReturn.HexValue = text
+		} else {
+			toReturn.Kind = ast_pb.NodeType_STRING
+			toReturn.Value = strconv.Quote(toReturn.Value)
+			if text != "" {
+				toReturn.Value = text
+			}
+		}
+	}
+
+	return toReturn
+}
@@ -54,6 +54,9 @@ func (f *Conditional) GetSrc() SrcNode {
 // GetTypeDescription returns the type description associated with the Conditional.
 // TODO: Perhaps attaching at the end type descriptions to the conditional?
 func (f *Conditional) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return &TypeDescription{
 		TypeString:     "conditional",
 		TypeIdentifier: "$_t_conditional",
@@ -128,6 +131,12 @@ func (f *Conditional) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &f.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
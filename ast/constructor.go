@@ -307,14 +307,6 @@ func (c *Constructor) Parse(
 		bodyNode := NewBodyNode(c.ASTBuilder, false)
 		bodyNode.ParseBlock(unit, contractNode, c, ctx.Block())
 		c.Body = bodyNode
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(c.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, c, uncheckedCtx)
-				c.Body.Statements = append(c.Body.Statements, bodyNode)
-			}
-		}
 	}
 
 	c.currentFunctions = append(c.currentFunctions, c)
@@ -409,7 +409,7 @@ func (c *Contract) Parse(unitCtx *parser.SourceUnitContext, ctx *parser.Contract
 			Length:      int64(ctx.Identifier().GetStop().GetStop() - ctx.Identifier().GetStart().GetStart() + 1),
 			ParentIndex: contractId,
 		},
-		Abstract:                false,
+		Abstract:                ctx.Abstract() != nil,
 		NodeType:                ast_pb.NodeType_CONTRACT_DEFINITION,
 		Kind:                    ast_pb.NodeType_KIND_CONTRACT,
 		LinearizedBaseContracts: make([]int64, 0),
@@ -13,6 +13,31 @@ and generate Solidity code. It serves as a foundation for building tools, analyz
 compilers, and other applications that require deep understanding and processing of
 Solidity contracts.
 
+The Tree can be rewritten in place with InsertBefore, InsertAfter, Replace, Remove and Wrap.
+Existing node ids are preserved, new nodes without an id get one, and source locations and
+parent indexes of the affected source unit are kept consistent. Lists are reallocated on
+insertion, so siblings inserted from a NodeVisitor are not visited by the ongoing Walk.
+
+Besides the parser, the tree can be imported with ImportFromCompactJSON from the compact AST
+the solc compiler produces with --standard-json. Node ids, ReferencedDeclaration and
+TypeDescription are then the ones resolved by the compiler, and sources given to the builder
+are used to fill in lines, columns and the original text of literals.
+
+The builder implements solgo.IncrementalListener, so when a source is changed with Parser.Reparse,
+only the changed source and the sources importing it are built again. Source units of the other
+sources are kept as they are, with locations moved to match the new combined source.
+
+Once references are resolved, the SymbolTable of the builder holds nested scopes of source units,
+contracts, functions, blocks and Yul blocks. Identifiers are looked up through them following
+shadowing, the C3 linearization of base contracts and using for directives, and declarations
+found this way are filled into ReferencedDeclaration of the nodes left unresolved.
+
+The TypeChecker then types expressions following the rules of the compiler: integer literals are
+rational constants, implicit conversions are checked for assignments, arguments and returns, and
+operators of user defined value types resolve to the functions attached with using {f as +} for.
+Exact type descriptions replace the approximated ones, and type errors are available from
+GetTypeErrors of the builder.
+
 Note: The ast package is designed to be used in conjunction with a Solidity parser,
 provided by ANTLR, to generate the initial AST from Solidity source code.
 It then enriches the AST with additional information and functionality specific to the
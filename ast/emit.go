@@ -12,11 +12,12 @@ import (
 type Emit struct {
 	*ASTBuilder
 
-	Id         int64            `json:"id"`         // Unique identifier of the emit statement node.
-	NodeType   ast_pb.NodeType  `json:"nodeType"`   // Type of the node.
-	Src        SrcNode          `json:"src"`        // Source location information.
-	Arguments  []Node[NodeType] `json:"arguments"`  // List of arguments for the emit statement.
-	Expression Node[NodeType]   `json:"expression"` // Expression node associated with the emit statement.
+	Id         int64            `json:"id"`              // Unique identifier of the emit statement node.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`        // Type of the node.
+	Src        SrcNode          `json:"src"`             // Source location information.
+	Arguments  []Node[NodeType] `json:"arguments"`       // List of arguments for the emit statement.
+	Names      []string         `json:"names,omitempty"` // Argument names in case of named arguments such as emit E({a: 1}).
+	Expression Node[NodeType]   `json:"expression"`      // Expression node associated with the emit statement.
 }
 
 // NewEmitStatement creates a new instance of Emit with the provided ASTBuilder.
@@ -54,6 +55,12 @@ func (e *Emit) GetArguments() []Node[NodeType] {
 	return e.Arguments
 }
 
+// GetNames returns the argument names of the emit statement. It's empty unless the event is emitted
+// with named arguments, in which case the names are in the same order as the arguments.
+func (e *Emit) GetNames() []string {
+	return e.Names
+}
+
 // GetExpression returns the expression node associated with the emit statement.
 func (e *Emit) GetExpression() Node[NodeType] {
 	return e.Expression
@@ -124,6 +131,12 @@ func (e *Emit) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &e.Names); err != nil {
+			return err
+		}
+	}
+
 	if expression, ok := tempMap["expression"]; ok {
 		if err := json.Unmarshal(expression, &e.Expression); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -182,7 +195,13 @@ func (e *Emit) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]],
 
 	expression := NewExpression(e.ASTBuilder)
 
-	for _, argumentCtx := range ctx.CallArgumentList().AllExpression() {
+	argumentCtxs := ctx.CallArgumentList().AllExpression()
+	for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+		e.Names = append(e.Names, namedArgumentCtx.GetName().GetText())
+		argumentCtxs = append(argumentCtxs, namedArgumentCtx.GetValue())
+	}
+
+	for _, argumentCtx := range argumentCtxs {
 		argument := expression.Parse(unit, contractNode, fnNode, bodyNode, nil, e, e.GetId(), argumentCtx)
 		e.Arguments = append(e.Arguments, argument)
 	}
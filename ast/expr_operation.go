@@ -12,12 +12,13 @@ import (
 type ExprOperation struct {
 	*ASTBuilder
 
-	Id               int64              `json:"id"`               // Unique identifier for the expression operation
-	NodeType         ast_pb.NodeType    `json:"nodeType"`         // Type of the node (EXPRESSION_OPERATION for expression operation)
-	Src              SrcNode            `json:"src"`              // Source information about the expression operation
-	LeftExpression   Node[NodeType]     `json:"leftExpression"`   // Left expression in the operation
-	RightExpression  Node[NodeType]     `json:"rightExpression"`  // Right expression in the operation
-	TypeDescriptions []*TypeDescription `json:"typeDescriptions"` // Type descriptions of the expressions
+	Id               int64              `json:"id"`                        // Unique identifier for the expression operation
+	NodeType         ast_pb.NodeType    `json:"nodeType"`                  // Type of the node (EXPRESSION_OPERATION for expression operation)
+	Src              SrcNode            `json:"src"`                       // Source information about the expression operation
+	LeftExpression   Node[NodeType]     `json:"leftExpression"`            // Left expression in the operation
+	RightExpression  Node[NodeType]     `json:"rightExpression"`           // Right expression in the operation
+	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`          // Type descriptions of the expressions
+	TypeDescription  *TypeDescription   `json:"typeDescription,omitempty"` // Type description of the operation result
 }
 
 // NewExprOperationExpression creates a new ExprOperation instance.
@@ -52,6 +53,9 @@ func (f *ExprOperation) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description of the expression operation.
 func (f *ExprOperation) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return f.TypeDescriptions[0]
 }
 
@@ -140,6 +144,12 @@ func (f *ExprOperation) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &f.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 
 }
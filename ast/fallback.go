@@ -215,14 +215,6 @@ func (f *Fallback) Parse(
 		bodyNode := NewBodyNode(f.ASTBuilder, false)
 		bodyNode.ParseBlock(unit, contractNode, f, ctx.Block())
 		f.Body = bodyNode
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(f.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, f, uncheckedCtx)
-				f.Body.Statements = append(f.Body.Statements, bodyNode)
-			}
-		}
 	}
 
 	return f
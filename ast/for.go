@@ -248,14 +248,10 @@ func (f *ForStatement) Parse(
 		bodyNode := NewBodyNode(f.ASTBuilder, false)
 		bodyNode.ParseBlock(unit, contractNode, f, ctx.Statement().Block())
 		f.Body = bodyNode
-
-		if ctx.Statement().Block() != nil && ctx.Statement().Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Statement().Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(f.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, f, uncheckedCtx)
-				f.Body.Statements = append(f.Body.Statements, bodyNode)
-			}
-		}
+	} else if ctx.Statement() != nil && ctx.Statement().Block() == nil {
+		bodyNode := NewBodyNode(f.ASTBuilder, false)
+		bodyNode.ParseStatement(unit, contractNode, f, ctx.Statement())
+		f.Body = bodyNode
 	} else {
 		bodyNode := NewBodyNode(f.ASTBuilder, false)
 		f.Body = bodyNode
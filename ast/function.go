@@ -469,20 +469,6 @@ func (f *Function) Parse(
 		if !bodyNode.Implemented {
 			f.Implemented = false
 		}
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(f.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, f, uncheckedCtx)
-				f.Body.Statements = append(f.Body.Statements, bodyNode)
-
-				// In case at any point we discover that the function is not implemented, we set the
-				// implemented flag to false.
-				if !bodyNode.Implemented {
-					f.Implemented = false
-				}
-			}
-		}
 	} else {
 		bodyNode := NewBodyNode(f.ASTBuilder, false)
 		bodyNode.Src = f.Src
@@ -499,6 +485,27 @@ func (f *Function) Parse(
 	return f
 }
 
+// ParseGlobal parses the free function defined at the top level of a source unit. Free functions
+// do not belong to any contract, so the function is its own scope while its body is parsed.
+func (f *Function) ParseGlobal(ctx *parser.FunctionDefinitionContext) Node[NodeType] {
+	f.Parse(nil, f, nil, ctx)
+	f.Scope = 0
+	f.Src.ParentIndex = 0
+	f.globalDefinitions = append(f.globalDefinitions, f)
+	return f
+}
+
+// EnterFunctionDefinition is called when the ASTBuilder enters a function definition. Functions of
+// contracts are parsed together with the contract body, so only free functions are handled here.
+func (b *ASTBuilder) EnterFunctionDefinition(ctx *parser.FunctionDefinitionContext) {
+	if _, ok := ctx.GetParent().(*parser.SourceUnitContext); !ok {
+		return
+	}
+
+	function := NewFunction(b)
+	function.ParseGlobal(ctx)
+}
+
 // ParseTypeName parses the source code and constructs the Function node for TypeName.
 func (f *Function) ParseTypeName(
 	unit *SourceUnit[Node[ast_pb.SourceUnit]],
@@ -21,6 +21,7 @@ type FunctionCall struct {
 	Src                   SrcNode            `json:"src"`                             // Source location of the node.
 	ArgumentTypes         []*TypeDescription `json:"argumentTypes"`                   // Types of the arguments.
 	Arguments             []Node[NodeType]   `json:"arguments"`                       // Arguments of the function call.
+	Names                 []string           `json:"names,omitempty"`                 // Argument names in case of a named argument call such as f({a: 1}).
 	Expression            Node[NodeType]     `json:"expression"`                      // Expression of the function call.
 	ReferencedDeclaration int64              `json:"referencedDeclaration,omitempty"` // Referenced declaration of the function call.
 	TypeDescription       *TypeDescription   `json:"typeDescription"`                 // Type description of the function call.
@@ -73,6 +74,12 @@ func (f *FunctionCall) GetArguments() []Node[NodeType] {
 	return f.Arguments
 }
 
+// GetNames returns the argument names of the FunctionCall node. It's empty unless the call is made
+// with named arguments, in which case the names are in the same order as the arguments.
+func (f *FunctionCall) GetNames() []string {
+	return f.Names
+}
+
 // GetArgumentTypes returns the types of the arguments of the FunctionCall node.
 func (f *FunctionCall) GetArgumentTypes() []*TypeDescription {
 	return f.ArgumentTypes
@@ -191,6 +198,12 @@ func (f *FunctionCall) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &f.Names); err != nil {
+			return err
+		}
+	}
+
 	if expression, ok := tempMap["expression"]; ok {
 		if err := json.Unmarshal(expression, &f.Expression); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -295,7 +308,13 @@ func (f *FunctionCall) Parse(
 	}
 
 	if ctx.CallArgumentList() != nil {
-		for _, expressionCtx := range ctx.CallArgumentList().AllExpression() {
+		argumentCtxs := ctx.CallArgumentList().AllExpression()
+		for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+			f.Names = append(f.Names, namedArgumentCtx.GetName().GetText())
+			argumentCtxs = append(argumentCtxs, namedArgumentCtx.GetValue())
+		}
+
+		for _, expressionCtx := range argumentCtxs {
 			expr := expression.Parse(unit, contractNode, fnNode, bodyNode, nil, f, f.GetId(), expressionCtx)
 			f.Arguments = append(
 				f.Arguments,
@@ -366,6 +385,8 @@ type FunctionCallOption struct {
 	Kind                  ast_pb.NodeType  `json:"kind"`                            // Kind of the node.
 	Src                   SrcNode          `json:"src"`                             // Source location of the node.
 	Expression            Node[NodeType]   `json:"expression"`                      // Expression of the function call.
+	Names                 []string         `json:"names,omitempty"`                 // Names of the call options such as value or gas.
+	Options               []Node[NodeType] `json:"options,omitempty"`               // Values of the call options, in the same order as names.
 	ReferencedDeclaration int64            `json:"referencedDeclaration,omitempty"` // Referenced declaration of the function call.
 	TypeDescription       *TypeDescription `json:"typeDescription"`                 // Type description of the function call.
 }
@@ -418,9 +439,21 @@ func (f *FunctionCallOption) GetTypeDescription() *TypeDescription {
 	return f.TypeDescription
 }
 
-// GetNodes returns a slice of nodes that includes the expression of the FunctionCallOption node.
+// GetNames returns the names of the call options, such as value, gas or salt.
+func (f *FunctionCallOption) GetNames() []string {
+	return f.Names
+}
+
+// GetOptions returns the values of the call options, in the same order as GetNames.
+func (f *FunctionCallOption) GetOptions() []Node[NodeType] {
+	return f.Options
+}
+
+// GetNodes returns a slice of nodes that includes the expression and option values of the FunctionCallOption node.
 func (f *FunctionCallOption) GetNodes() []Node[NodeType] {
-	return []Node[NodeType]{f.Expression}
+	toReturn := []Node[NodeType]{f.Expression}
+	toReturn = append(toReturn, f.Options...)
+	return toReturn
 }
 
 // GetReferenceDeclaration returns the referenced declaration of the FunctionCallOption node.
@@ -491,6 +524,37 @@ func (f *FunctionCallOption) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &f.Names); err != nil {
+			return err
+		}
+	}
+
+	if options, ok := tempMap["options"]; ok {
+		var nodes []json.RawMessage
+		if err := json.Unmarshal(options, &nodes); err != nil {
+			return err
+		}
+
+		for _, tempNode := range nodes {
+			var tempNodeMap map[string]json.RawMessage
+			if err := json.Unmarshal(tempNode, &tempNodeMap); err != nil {
+				return err
+			}
+
+			var tempNodeType ast_pb.NodeType
+			if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+				return err
+			}
+
+			node, err := unmarshalNode(tempNode, tempNodeType)
+			if err != nil {
+				return err
+			}
+			f.Options = append(f.Options, node)
+		}
+	}
+
 	return nil
 }
 
@@ -562,5 +626,12 @@ func (f *FunctionCallOption) Parse(
 		f.TypeDescription = f.Expression.GetTypeDescription()
 	}
 
+	for _, namedArgumentCtx := range ctx.AllNamedArgument() {
+		f.Names = append(f.Names, namedArgumentCtx.GetName().GetText())
+		f.Options = append(f.Options, expression.Parse(
+			unit, contractNode, fnNode, bodyNode, nil, f, f.GetId(), namedArgumentCtx.GetValue(),
+		))
+	}
+
 	return f
 }
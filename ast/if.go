@@ -6,17 +6,23 @@ import (
 	v3 "github.com/cncf/xds/go/xds/type/v3"
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
 	"github.com/unpackdev/solgo/parser"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-// IfStatement represents an if statement node in the abstract syntax tree.
+// IfStatement represents an if statement node in the abstract syntax tree. The protobuf message of
+// if statements has no field for the else branch, which is added under the else key of the value of
+// its typed struct instead.
 type IfStatement struct {
 	*ASTBuilder
 
-	Id        int64           `json:"id"`        // Unique identifier of the if statement node.
-	NodeType  ast_pb.NodeType `json:"nodeType"`  // Type of the node.
-	Src       SrcNode         `json:"src"`       // Source location information.
-	Condition Node[NodeType]  `json:"condition"` // Condition node.
-	Body      Node[NodeType]  `json:"body"`      // Body node.
+	Id        int64           `json:"id"`             // Unique identifier of the if statement node.
+	NodeType  ast_pb.NodeType `json:"nodeType"`       // Type of the node.
+	Src       SrcNode         `json:"src"`            // Source location information.
+	Condition Node[NodeType]  `json:"condition"`      // Condition node.
+	Body      Node[NodeType]  `json:"body"`           // Body node.
+	Else      Node[NodeType]  `json:"else,omitempty"` // Else branch node (body or chained if statement), if any.
 }
 
 // NewIfStatement creates a new instance of IfStatement with the provided ASTBuilder.
@@ -61,9 +67,13 @@ func (i *IfStatement) GetTypeDescription() *TypeDescription {
 	}
 }
 
-// GetNodes returns a list of nodes associated with the if statement (condition and body).
+// GetNodes returns a list of nodes associated with the if statement (condition, body and else branch).
 func (i *IfStatement) GetNodes() []Node[NodeType] {
-	return []Node[NodeType]{i.Condition, i.Body}
+	toReturn := []Node[NodeType]{i.Condition, i.Body}
+	if i.Else != nil {
+		toReturn = append(toReturn, i.Else)
+	}
+	return toReturn
 }
 
 // GetBody returns the body node of the if statement.
@@ -71,6 +81,12 @@ func (i *IfStatement) GetBody() Node[NodeType] {
 	return i.Body
 }
 
+// GetElse returns the else branch of the if statement. It is either a body node, another if statement
+// in case of `else if` chains, or nil when the if statement has no else branch.
+func (i *IfStatement) GetElse() Node[NodeType] {
+	return i.Else
+}
+
 // UnmarshalJSON unmarshals the JSON data into a IfStatement.
 func (i *IfStatement) UnmarshalJSON(data []byte) error {
 	var tempMap map[string]json.RawMessage
@@ -136,10 +152,29 @@ func (i *IfStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if elseBody, ok := tempMap["else"]; ok {
+		var tempNodeMap map[string]json.RawMessage
+		if err := json.Unmarshal(elseBody, &tempNodeMap); err != nil {
+			return err
+		}
+
+		var tempNodeType ast_pb.NodeType
+		if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+			return err
+		}
+
+		node, err := unmarshalNode(elseBody, tempNodeType)
+		if err != nil {
+			return err
+		}
+		i.Else = node
+	}
+
 	return nil
 }
 
-// ToProto converts the IfStatement node to its corresponding protobuf representation.
+// ToProto converts the IfStatement node to its corresponding protobuf representation. The else
+// branch is not part of the protobuf message and is left out.
 func (i *IfStatement) ToProto() NodeType {
 	proto := ast_pb.If{
 		Id:        i.GetId(),
@@ -152,7 +187,25 @@ func (i *IfStatement) ToProto() NodeType {
 		proto.Body = i.GetBody().ToProto().(*ast_pb.Body)
 	}
 
-	return NewTypedStruct(&proto, "If")
+	toReturn := NewTypedStruct(&proto, "If")
+	if toReturn == nil || IsNilNode(i.GetElse()) {
+		return toReturn
+	}
+
+	// Else branch is either a body or a chained if statement, which are both protobuf messages.
+	elseProto, ok := any(i.GetElse().ToProto()).(protoreflect.ProtoMessage)
+	if !ok {
+		zap.L().Error("failed to convert else branch of if statement to proto", zap.Int64("id", i.GetId()))
+		return nil
+	}
+
+	elseStruct := newStruct(elseProto)
+	if elseStruct == nil {
+		return nil
+	}
+	toReturn.Value.Fields["else"] = structpb.NewStructValue(elseStruct)
+
+	return toReturn
 }
 
 // Parse parses the if statement context and populates the IfStatement fields.
@@ -176,26 +229,33 @@ func (i *IfStatement) Parse(
 	i.Condition = expression.Parse(unit, contractNode, fnNode, bodyNode, nil, i, i.GetId(), ctx.Expression())
 
 	body := NewBodyNode(i.ASTBuilder, false)
-	if len(ctx.AllStatement()) > 0 {
-		for _, statementCtx := range ctx.AllStatement() {
-			if statementCtx.IsEmpty() {
-				continue
-			}
-
-			if statementCtx.Block() != nil {
-				body.ParseBlock(unit, contractNode, fnNode, statementCtx.Block())
-				break
-			}
+	i.Body = body
 
+	statements := ctx.AllStatement()
+	if len(statements) > 0 && !statements[0].IsEmpty() {
+		if statements[0].Block() != nil {
+			body.ParseBlock(unit, contractNode, fnNode, statements[0].Block())
+		} else {
 			// There can be single-statement conditional...
-			body.parseStatements(unit, contractNode, fnNode, statementCtx.GetChild(0))
-
-			i.Body = body
+			body.ParseStatement(unit, contractNode, fnNode, statements[0])
 		}
+	}
 
-		i.Body = body
-	} else {
-		i.Body = body
+	if len(statements) > 1 && !statements[1].IsEmpty() {
+		if ifCtx, ok := statements[1].IfStatement().(*parser.IfStatementContext); ok {
+			// Chained `else if` statements are kept as a nested if statement rather than being wrapped into
+			// a body so that the original structure can be reconstructed.
+			elseIf := NewIfStatement(i.ASTBuilder)
+			i.Else = elseIf.Parse(unit, contractNode, fnNode, bodyNode, ifCtx)
+		} else {
+			elseBody := NewBodyNode(i.ASTBuilder, false)
+			if statements[1].Block() != nil {
+				elseBody.ParseBlock(unit, contractNode, fnNode, statements[1].Block())
+			} else {
+				elseBody.ParseStatement(unit, contractNode, fnNode, statements[1])
+			}
+			i.Else = elseBody
+		}
 	}
 
 	return i
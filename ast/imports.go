@@ -22,6 +22,14 @@ type Import struct {
 	As           string          `json:"as"`                     // Alias of the imported unit.
 	UnitAliases  []string        `json:"unitAliases"`            // Alias of the imported unit.
 	SourceUnit   int64           `json:"sourceUnit"`             // Source unit identifier.
+	Wildcard     bool            `json:"wildcard,omitempty"`     // Whether the import is in the form of `import * as X from "..."`.
+	Symbols      []*ImportSymbol `json:"symbols,omitempty"`      // Symbols imported in the form of `import {A, B as C} from "..."`.
+}
+
+// ImportSymbol represents a single symbol imported by name, optionally under an alias.
+type ImportSymbol struct {
+	Name  string `json:"name"`            // Name of the imported symbol.
+	Alias string `json:"alias,omitempty"` // Alias of the imported symbol, if any.
 }
 
 // SetReferenceDescriptor sets the reference descriptions of the Import node.
@@ -104,6 +112,16 @@ func (i *Import) GetUnitAliases() []string {
 	return i.UnitAliases
 }
 
+// IsWildcard returns whether all symbols of the imported unit are imported under a single alias.
+func (i *Import) IsWildcard() bool {
+	return i.Wildcard
+}
+
+// GetSymbols returns the symbols imported by name.
+func (i *Import) GetSymbols() []*ImportSymbol {
+	return i.Symbols
+}
+
 // GetAs returns the alias of the imported unit.
 func (i *Import) GetAs() string {
 	return i.As
@@ -212,14 +230,26 @@ func parseImportPathsForSourceUnit(
 			}
 
 			if importCtx.As() != nil {
-				importNode.UnitAlias = importCtx.As().GetText()
+				importNode.As = importCtx.As().GetText()
+			}
+
+			if importCtx.Mul() != nil {
+				importNode.Wildcard = true
 			}
 
 			if importCtx.SymbolAliases() != nil {
 				for _, aliasCtx := range importCtx.SymbolAliases().AllImportAliases() {
+					symbol := &ImportSymbol{}
+					if aliasCtx.GetSymbol() != nil {
+						symbol.Name = aliasCtx.GetSymbol().GetText()
+					}
+
 					if aliasCtx.GetAlias() != nil {
+						symbol.Alias = aliasCtx.GetAlias().GetText()
 						importNode.UnitAliases = append(importNode.UnitAliases, aliasCtx.GetAlias().GetText())
 					}
+
+					importNode.Symbols = append(importNode.Symbols, symbol)
 				}
 			}
 
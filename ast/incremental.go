@@ -0,0 +1,466 @@
+package ast
+
+import (
+	"reflect"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/parser"
+)
+
+// reparseState holds the state of the builder while some of the sources are parsed again.
+// Source units, globals and comments are kept per source they were parsed from, so that the
+// tree can be put back together in the order of the combined source.
+type reparseState struct {
+	reparsed    *solgo.ReparsedSources
+	parser      *parser.SolidityParser // Parser of the full parse, restored once reparse is done.
+	unitRange   *solgo.SourceRange     // Range of the source currently walked.
+	unitsMark   int                    // Index of the first source unit of the source currently walked.
+	globalsMark int                    // Index of the first global of the source currently walked.
+	units       map[*solgo.SourceUnit][]*SourceUnit[Node[ast_pb.SourceUnit]]
+	globals     map[*solgo.SourceUnit][]Node[NodeType]
+	comments    map[*solgo.SourceUnit][]*Comment
+	removedIds  map[int64]bool   // Ids of all of the nodes parsed from the reparsed sources.
+	removedRefs map[string]int64 // Ids of removed source units and their contracts, by name.
+	remappedIds map[int64]int64  // Ids of removed source units and their contracts, mapped to the new ones.
+}
+
+// EnterReparse implements solgo.IncrementalListener. Nodes parsed from the reparsed sources are
+// removed from the tree, while locations of the rest are moved to match the new combined source.
+// Reparsed sources are parsed on their own, so unlike on the full parse, pragmas and imports of the
+// preceding sources are not copied into their source units.
+func (b *ASTBuilder) EnterReparse(reparsed *solgo.ReparsedSources) {
+	root := b.tree.GetRoot()
+	if root == nil {
+		root = NewRootNode(b, 0, make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0), make([]*Comment, 0))
+		b.tree.SetRoot(root)
+	}
+
+	state := &reparseState{
+		reparsed:    reparsed,
+		parser:      b.parser,
+		units:       make(map[*solgo.SourceUnit][]*SourceUnit[Node[ast_pb.SourceUnit]]),
+		globals:     make(map[*solgo.SourceUnit][]Node[NodeType]),
+		comments:    make(map[*solgo.SourceUnit][]*Comment),
+		removedIds:  make(map[int64]bool),
+		removedRefs: make(map[string]int64),
+		remappedIds: make(map[int64]int64),
+	}
+
+	b.sourceUnits = make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0)
+	for _, unit := range root.SourceUnits {
+		source, keep := state.classify(unit.GetSrc())
+		if !keep {
+			state.remove(unit)
+			state.removedRefs[unit.GetName()] = unit.GetId()
+			if unit.GetContract() != nil {
+				state.removedRefs[unit.GetName()+"."] = unit.GetContract().GetId()
+			}
+			continue
+		}
+
+		state.moveUnit(unit, source)
+		state.units[source] = append(state.units[source], unit)
+		b.sourceUnits = append(b.sourceUnits, unit)
+	}
+
+	b.globalDefinitions = make([]Node[NodeType], 0)
+	for _, global := range root.Globals {
+		source, keep := state.classify(global.GetSrc())
+		if !keep {
+			state.remove(global)
+			continue
+		}
+
+		state.move(global, source)
+		state.globals[source] = append(state.globals[source], global)
+		b.globalDefinitions = append(b.globalDefinitions, global)
+	}
+
+	for _, comment := range root.Comments {
+		source, keep := state.classify(comment.GetSrc())
+		if !keep {
+			continue
+		}
+
+		state.move(comment, source)
+		state.comments[source] = append(state.comments[source], comment)
+	}
+
+	for nodeId := range b.resolver.UnprocessedNodes {
+		if state.removedIds[nodeId] {
+			delete(b.resolver.UnprocessedNodes, nodeId)
+		}
+	}
+
+	b.collectDeclarations()
+	b.reparse = state
+}
+
+// EnterReparsedUnit implements solgo.IncrementalListener. Comments of the source are read from the
+// token stream of the provided parser.
+func (b *ASTBuilder) EnterReparsedUnit(unitRange *solgo.SourceRange, parser *parser.SolidityParser) {
+	if b.reparse == nil {
+		return
+	}
+
+	b.parser = parser
+	b.comments = make([]*Comment, 0)
+	b.commentsParsed = false
+	b.reparse.unitRange = unitRange
+	b.reparse.unitsMark = len(b.sourceUnits)
+	b.reparse.globalsMark = len(b.globalDefinitions)
+}
+
+// ExitReparse implements solgo.IncrementalListener. It puts the tree back together in the order of
+// the combined source and marks references to the removed nodes to be resolved again.
+func (b *ASTBuilder) ExitReparse(reparsed *solgo.ReparsedSources) {
+	state := b.reparse
+	if state == nil {
+		return
+	}
+
+	// Anything that can not be matched with any of the sources, such as nodes added with the
+	// rewriting API, is kept at the end.
+	root := b.tree.GetRoot()
+	sourceUnits := make([]*SourceUnit[Node[ast_pb.SourceUnit]], 0, len(root.SourceUnits))
+	globals := make([]Node[NodeType], 0, len(root.Globals))
+	comments := make([]*Comment, 0, len(root.Comments))
+	sources := make([]*solgo.SourceUnit, 0, len(reparsed.Current)+1)
+	for _, unitRange := range reparsed.Current {
+		sources = append(sources, unitRange.Unit)
+	}
+	for _, source := range append(sources, nil) {
+		sourceUnits = append(sourceUnits, state.units[source]...)
+		globals = append(globals, state.globals[source]...)
+		comments = append(comments, state.comments[source]...)
+	}
+
+	root.SourceUnits = sourceUnits
+	root.Globals = globals
+	root.Comments = comments
+	b.sourceUnits = sourceUnits
+
+	for _, unit := range sourceUnits {
+		if oldId, ok := state.removedRefs[unit.GetName()]; ok {
+			state.remappedIds[oldId] = unit.GetId()
+			if oldContractId, ok := state.removedRefs[unit.GetName()+"."]; ok && unit.GetContract() != nil {
+				state.remappedIds[oldContractId] = unit.GetContract().GetId()
+			}
+		}
+	}
+
+	for _, unit := range sourceUnits {
+		if state.isReparsed(unit.GetSrc()) {
+			continue
+		}
+
+		symbols := make([]Symbol, 0, len(unit.ExportedSymbols))
+		for _, symbol := range unit.ExportedSymbols {
+			if state.removedIds[symbol.Id] {
+				if newId, ok := state.remappedIds[symbol.Id]; ok {
+					symbol.Id = newId
+				} else {
+					continue
+				}
+			}
+			symbols = append(symbols, symbol)
+		}
+		unit.ExportedSymbols = symbols
+
+		b.relinkReferences(unit)
+	}
+
+	for _, global := range globals {
+		if !state.isReparsed(global.GetSrc()) {
+			b.relinkReferences(global)
+		}
+	}
+
+	if state.removedIds[root.EntrySourceUnit] {
+		root.EntrySourceUnit = state.remappedIds[root.EntrySourceUnit]
+	}
+
+	b.parser = state.parser
+	b.comments = nil
+	b.commentsParsed = false
+	b.reparse = nil
+}
+
+// exitReparsedSourceUnit moves the nodes parsed from the source currently walked, whose locations
+// are relative to the source, to their location within the combined source.
+func (b *ASTBuilder) exitReparsedSourceUnit() {
+	state := b.reparse
+	source := state.unitRange.Unit
+	delta, lineDelta := state.unitRange.Start, state.unitRange.Line-1
+
+	for _, unit := range b.sourceUnits[state.unitsMark:] {
+		moveLocations(reflect.ValueOf(unit), delta, lineDelta, make(map[uintptr]bool))
+		state.units[source] = append(state.units[source], unit)
+	}
+
+	for _, global := range b.globalDefinitions[state.globalsMark:] {
+		moveLocations(reflect.ValueOf(global), delta, lineDelta, make(map[uintptr]bool))
+		state.globals[source] = append(state.globals[source], global)
+	}
+
+	for _, comment := range b.comments {
+		moveLocations(reflect.ValueOf(comment), delta, lineDelta, make(map[uintptr]bool))
+		state.comments[source] = append(state.comments[source], comment)
+	}
+}
+
+// relinkReferences points references of the kept node to the new source units and contracts. Other
+// references to the removed nodes are resolved again by name, if the node has one.
+func (b *ASTBuilder) relinkReferences(node Node[NodeType]) {
+	state := b.reparse
+	remap := func(field reflect.Value) bool {
+		if !state.removedIds[field.Int()] {
+			return true
+		}
+		newId, ok := state.remappedIds[field.Int()]
+		field.SetInt(newId)
+		return ok
+	}
+
+	walkStructs(reflect.ValueOf(node), make(map[uintptr]bool), func(value reflect.Value) {
+		for _, name := range []string{"SourceUnit", "ContractReferencedDeclaration"} {
+			if field := value.FieldByName(name); field.IsValid() && field.Kind() == reflect.Int64 {
+				remap(field)
+			}
+		}
+
+		for _, name := range []string{"LinearizedBaseContracts", "ContractDependencies"} {
+			if field := value.FieldByName(name); field.IsValid() && field.Type() == reflect.TypeOf([]int64{}) {
+				ids := make([]int64, 0, field.Len())
+				for _, id := range field.Interface().([]int64) {
+					if newId, ok := state.remappedIds[id]; ok {
+						id = newId
+					} else if state.removedIds[id] {
+						continue
+					}
+					ids = append(ids, id)
+				}
+				field.Set(reflect.ValueOf(ids))
+			}
+		}
+
+		field := value.FieldByName("ReferencedDeclaration")
+		if !field.IsValid() || field.Kind() != reflect.Int64 || remap(field) {
+			return
+		}
+
+		if referencing, ok := value.Addr().Interface().(Node[NodeType]); ok {
+			if name := referencedName(referencing); name != "" {
+				b.resolver.UnprocessedNodes[referencing.GetId()] = UnprocessedNode{
+					Id:   referencing.GetId(),
+					Name: name,
+					Node: referencing,
+				}
+			}
+		}
+	})
+}
+
+// collectDeclarations fills the declarations used while resolving references with the ones found
+// in the tree, as they are cleaned up once references of the full parse are resolved.
+func (b *ASTBuilder) collectDeclarations() {
+	b.currentImports = make([]Node[NodeType], 0)
+	b.currentStateVariables = make([]*StateVariableDeclaration, 0)
+	b.currentUserDefinedVariables = make([]*UserDefinedValueTypeDefinition, 0)
+	b.currentEvents = make([]Node[NodeType], 0)
+	b.currentEnums = make([]Node[NodeType], 0)
+	b.currentStructs = make([]Node[NodeType], 0)
+	b.currentErrors = make([]Node[NodeType], 0)
+	b.currentModifiers = make([]Node[NodeType], 0)
+	b.currentFunctions = make([]Node[NodeType], 0)
+	b.currentVariables = make([]Node[NodeType], 0)
+
+	nodes := make([]Node[NodeType], 0, len(b.sourceUnits)+len(b.globalDefinitions))
+	for _, unit := range b.sourceUnits {
+		nodes = append(nodes, unit)
+	}
+	nodes = append(nodes, b.globalDefinitions...)
+
+	visited := make(map[Node[NodeType]]bool)
+	for _, node := range nodes {
+		walkNodeTree(node, func(n Node[NodeType]) bool {
+			if visited[n] {
+				return false
+			}
+			visited[n] = true
+
+			switch declaration := n.(type) {
+			case *Import:
+				b.currentImports = append(b.currentImports, declaration)
+			case *StateVariableDeclaration:
+				b.currentStateVariables = append(b.currentStateVariables, declaration)
+			case *UserDefinedValueTypeDefinition:
+				b.currentUserDefinedVariables = append(b.currentUserDefinedVariables, declaration)
+			case *EventDefinition:
+				b.currentEvents = append(b.currentEvents, declaration)
+			case *EnumDefinition:
+				b.currentEnums = append(b.currentEnums, declaration)
+			case *StructDefinition:
+				b.currentStructs = append(b.currentStructs, declaration)
+			case *ErrorDefinition:
+				b.currentErrors = append(b.currentErrors, declaration)
+			case *ModifierDefinition:
+				b.currentModifiers = append(b.currentModifiers, declaration)
+			case *Function, *Constructor:
+				b.currentFunctions = append(b.currentFunctions, declaration)
+			case *VariableDeclaration, *Declaration, *Parameter:
+				b.currentVariables = append(b.currentVariables, declaration)
+			}
+			return true
+		})
+	}
+}
+
+// classify returns the source the location belongs to, based on the combined source before the
+// change, and whether nodes at the location are kept.
+func (s *reparseState) classify(src SrcNode) (*solgo.SourceUnit, bool) {
+	for _, unitRange := range s.reparsed.Previous {
+		if unitRange.Contains(src.Start) {
+			return unitRange.Unit, !s.reparsed.IsReparsed(unitRange.Unit)
+		}
+	}
+	return nil, true
+}
+
+// isReparsed returns whether the location, within the combined source after the change, belongs
+// to one of the reparsed sources.
+func (s *reparseState) isReparsed(src SrcNode) bool {
+	for _, unitRange := range s.reparsed.Current {
+		if unitRange.Contains(src.Start) {
+			return s.reparsed.IsReparsed(unitRange.Unit)
+		}
+	}
+	return false
+}
+
+// remove records ids of the node and all of its descendants as removed.
+func (s *reparseState) remove(node Node[NodeType]) {
+	walkNodeTree(node, func(n Node[NodeType]) bool {
+		s.removedIds[n.GetId()] = true
+		return true
+	})
+}
+
+// move moves locations of the kept value by the distance its source moved within the combined source.
+func (s *reparseState) move(value interface{}, source *solgo.SourceUnit) {
+	if source == nil {
+		return
+	}
+
+	var previous, current *solgo.SourceRange
+	for _, unitRange := range s.reparsed.Previous {
+		if unitRange.Unit == source {
+			previous = unitRange
+		}
+	}
+	for _, unitRange := range s.reparsed.Current {
+		if unitRange.Unit == source {
+			current = unitRange
+		}
+	}
+
+	if previous != nil && current != nil {
+		moveLocations(reflect.ValueOf(value), current.Start-previous.Start, current.Line-previous.Line, make(map[uintptr]bool))
+	}
+}
+
+// moveUnit moves locations of the kept source unit. Pragmas and imports close to the contract are
+// copied into the unit even when they belong to the preceding source, so each of them is moved on
+// its own, or dropped if it belongs to one of the reparsed sources.
+func (s *reparseState) moveUnit(unit *SourceUnit[Node[ast_pb.SourceUnit]], source *solgo.SourceUnit) {
+	nodes := unit.Nodes
+	unit.Nodes = make([]Node[NodeType], 0, len(nodes))
+	s.move(unit, source)
+
+	for _, node := range nodes {
+		if node == unit.GetContract() {
+			s.move(node, source)
+			unit.Nodes = append(unit.Nodes, node)
+			continue
+		}
+
+		if nodeSource, keep := s.classify(node.GetSrc()); keep {
+			s.move(node, nodeSource)
+			unit.Nodes = append(unit.Nodes, node)
+		}
+	}
+}
+
+// referencedName returns the name the node references its declaration by.
+func referencedName(node Node[NodeType]) string {
+	switch named := node.(type) {
+	case *MemberAccessExpression:
+		return named.GetMemberName()
+	case interface{ GetName() string }:
+		return named.GetName()
+	}
+	return ""
+}
+
+// moveLocations moves all of the source locations found within the value, including the ones of
+// helper structures and name locations, by the delta and the line delta. Unset locations are kept.
+func moveLocations(value reflect.Value, delta int64, lineDelta int64, visited map[uintptr]bool) {
+	if delta == 0 && lineDelta == 0 {
+		return
+	}
+
+	walkStructs(value, visited, func(src reflect.Value) {
+		if src.Type() != reflect.TypeOf(SrcNode{}) {
+			return
+		}
+
+		location := src.Addr().Interface().(*SrcNode)
+		if location.Line == 0 && location.Start == 0 && location.Length == 0 {
+			return
+		}
+		location.Start += delta
+		location.End += delta
+		location.Line += lineDelta
+	})
+}
+
+// walkStructs calls the visit function for every addressable structure reachable from the value
+// through the fields visible in JSON. Fields skipped in JSON hold references to nodes owned
+// elsewhere and are not followed.
+func walkStructs(value reflect.Value, visited map[uintptr]bool, visit func(value reflect.Value)) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+
+		if value.Kind() == reflect.Ptr {
+			if visited[value.Pointer()] {
+				return
+			}
+			visited[value.Pointer()] = true
+		}
+
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			walkStructs(value.Index(i), visited, visit)
+		}
+	case reflect.Struct:
+		if !value.CanAddr() {
+			return
+		}
+
+		visit(value)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() || field.Anonymous || field.Tag.Get("json") == "-" {
+				continue
+			}
+			walkStructs(value.Field(i), visited, visit)
+		}
+	}
+}
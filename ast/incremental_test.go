@@ -0,0 +1,180 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/tests"
+)
+
+// buildIncrementalTree parses the sources used by the incremental tests. Context does not depend on
+// any of the other sources and comes last, so its nodes have to be moved on changes.
+func buildIncrementalTree(t *testing.T, contents map[string]string) (*solgo.Parser, *ASTBuilder) {
+	sources := newTestSources("SimpleStorage")
+	for _, name := range []string{"MathLib", "SimpleStorage", "Context"} {
+		unit := newTestSourceUnit(t, name)
+		if changed, ok := contents[name]; ok {
+			unit.Content = changed
+		}
+		sources.SourceUnits = append(sources.SourceUnits, unit)
+	}
+
+	parser, astBuilder := parseTestSources(t, sources)
+	require.Empty(t, astBuilder.ResolveReferences())
+
+	return parser, astBuilder
+}
+
+// describeTree lists types, locations and referenced locations of all nodes in the tree, which
+// unlike ids do not depend on the order the nodes were parsed in. Directives copied into the units
+// from the preceding sources are skipped, as they are not carried over on reparse.
+func describeTree(b *ASTBuilder) []string {
+	ranges := b.sources.GetCombinedSourceRanges()
+	sourceOf := func(src SrcNode) *solgo.SourceUnit {
+		for _, unitRange := range ranges {
+			if unitRange.Contains(src.Start) {
+				return unitRange.Unit
+			}
+		}
+		return nil
+	}
+
+	toReturn := make([]string, 0)
+	for _, unit := range b.GetRoot().GetSourceUnits() {
+		walkNodeTree(unit, func(node Node[NodeType]) bool {
+			if node != unit.GetContract() && sourceOf(node.GetSrc()) != sourceOf(unit.GetSrc()) {
+				return false
+			}
+
+			description := fmt.Sprintf("%s %s", node.GetType(), describeSrc(node.GetSrc()))
+			if field := nodeField(node, "ReferencedDeclaration"); field.IsValid() && field.Int() != 0 {
+				if referenced := b.GetTree().GetById(field.Int()); referenced != nil {
+					description += fmt.Sprintf(" -> %s %s", referenced.GetType(), describeSrc(referenced.GetSrc()))
+				}
+			}
+			toReturn = append(toReturn, description)
+			return true
+		})
+	}
+
+	for _, comment := range b.GetRoot().GetComments() {
+		toReturn = append(toReturn, fmt.Sprintf("%s %s", comment.GetType(), describeSrc(comment.GetSrc())))
+	}
+
+	return toReturn
+}
+
+// foreignDirectives counts the pragmas and imports of the unit with the given name that were copied
+// from the preceding sources.
+func foreignDirectives(b *ASTBuilder, name string) int {
+	unit := b.GetRoot().GetSourceUnitByName(name)
+	if unit == nil {
+		return 0
+	}
+
+	var unitRange *solgo.SourceRange
+	for _, candidate := range b.sources.GetCombinedSourceRanges() {
+		if candidate.Contains(unit.GetContract().GetSrc().Start) {
+			unitRange = candidate
+		}
+	}
+
+	toReturn := 0
+	for _, node := range unit.GetNodes() {
+		switch node.(type) {
+		case *Pragma, *Import:
+			if unitRange == nil || !unitRange.Contains(node.GetSrc().Start) {
+				toReturn++
+			}
+		}
+	}
+	return toReturn
+}
+
+// describeSrc describes the location, without the parent index which depends on ids.
+func describeSrc(src SrcNode) string {
+	return fmt.Sprintf("%d:%d %d-%d", src.Line, src.Column, src.Start, src.End)
+}
+
+func TestAstReparse(t *testing.T) {
+	mathLib := tests.ReadContractFileForTest(t, "ast/MathLib").Content
+	simpleStorage := tests.ReadContractFileForTest(t, "ast/SimpleStorage").Content
+
+	testCases := []struct {
+		name     string
+		unit     string
+		content  string
+		reparsed []string
+	}{
+		{
+			name:     "Unit without dependents",
+			unit:     "SimpleStorage",
+			content:  strings.Replace(simpleStorage, "uint storedData;", "uint storedData;\n\n    // Reset the stored data.\n    function reset() public {\n        storedData = 0;\n    }", 1),
+			reparsed: []string{"SimpleStorage"},
+		},
+		{
+			name:     "Unit with dependents",
+			unit:     "MathLib",
+			content:  strings.Replace(mathLib, "library MathLib {", "library MathLib {\n    // Maximum value.\n    uint constant MAX = 2**256 - 1;\n", 1),
+			reparsed: []string{"MathLib", "SimpleStorage"},
+		},
+		{
+			name:     "Shorter unit",
+			unit:     "MathLib",
+			content:  mathLib[:strings.Index(mathLib, "    // Safely subtract")] + "}\n",
+			reparsed: []string{"MathLib", "SimpleStorage"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			parser, astBuilder := buildIncrementalTree(t, nil)
+			root := astBuilder.GetRoot()
+			kept := root.GetSourceUnitByName("Context")
+			entryName := root.GetSourceUnitById(root.GetEntrySourceUnit()).GetName()
+
+			reparsed, err := parser.Reparse(testCase.unit, testCase.content)
+			require.NoError(t, err)
+			assert.Empty(t, reparsed.SyntaxErrors)
+
+			names := make([]string, 0)
+			for _, unit := range reparsed.Units {
+				names = append(names, unit.GetName())
+			}
+			assert.Equal(t, testCase.reparsed, names)
+
+			assert.Empty(t, astBuilder.ResolveReferences())
+			assert.Equal(t, 0, astBuilder.GetResolver().GetUnprocessedCount())
+
+			// Nodes of the units that were not parsed again are kept.
+			assert.Same(t, root, astBuilder.GetRoot())
+			assert.Same(t, kept, root.GetSourceUnitByName("Context"))
+			assert.Equal(t, entryName, root.GetSourceUnitById(root.GetEntrySourceUnit()).GetName())
+
+			_, expected := buildIncrementalTree(t, map[string]string{testCase.unit: testCase.content})
+			assert.Equal(t, describeTree(expected), describeTree(astBuilder))
+		})
+	}
+}
+
+func TestAstReparseDirectives(t *testing.T) {
+	context := tests.ReadContractFileForTest(t, "ast/Context").Content
+	content := strings.Replace(context, "abstract contract Context {", "// Execution context.\nabstract contract Context {", 1)
+
+	parser, astBuilder := buildIncrementalTree(t, nil)
+	assert.Equal(t, 1, foreignDirectives(astBuilder, "Context"))
+
+	_, err := parser.Reparse("Context", content)
+	require.NoError(t, err)
+	assert.Empty(t, astBuilder.ResolveReferences())
+
+	// Unlike on the full parse, the import of the preceding source is not copied into the reparsed unit.
+	_, expected := buildIncrementalTree(t, map[string]string{"Context": content})
+	assert.Equal(t, describeTree(expected), describeTree(astBuilder))
+	assert.Equal(t, 1, foreignDirectives(expected, "Context"))
+	assert.Zero(t, foreignDirectives(astBuilder, "Context"))
+}
@@ -17,7 +17,10 @@ type IndexRange struct {
 	Src              SrcNode            `json:"src"`
 	LeftExpression   Node[NodeType]     `json:"leftExpression"`
 	RightExpression  Node[NodeType]     `json:"rightExpression"`
+	StartExpression  Node[NodeType]     `json:"startExpression,omitempty"`
+	EndExpression    Node[NodeType]     `json:"endExpression,omitempty"`
 	TypeDescriptions []*TypeDescription `json:"typeDescriptions"`
+	TypeDescription  *TypeDescription   `json:"typeDescription,omitempty"`
 }
 
 // NewIndexRange creates a new instance of IndexRange with initialized values.
@@ -53,12 +56,19 @@ func (f *IndexRange) GetSrc() SrcNode {
 
 // GetTypeDescription returns the type description associated with the IndexRange.
 func (f *IndexRange) GetTypeDescription() *TypeDescription {
+	if f.TypeDescription != nil {
+		return f.TypeDescription
+	}
 	return f.TypeDescriptions[0]
 }
 
 // GetNodes returns the list of nodes within the IndexRange.
 func (f *IndexRange) GetNodes() []Node[NodeType] {
-	return []Node[NodeType]{f.LeftExpression, f.RightExpression}
+	toReturn := []Node[NodeType]{f.LeftExpression, f.RightExpression}
+	if f.EndExpression != nil && f.EndExpression != f.RightExpression {
+		toReturn = append(toReturn, f.EndExpression)
+	}
+	return toReturn
 }
 
 // GetLeftExpression returns the left expression of the IndexRange.
@@ -71,6 +81,16 @@ func (f *IndexRange) GetRightExpression() Node[NodeType] {
 	return f.RightExpression
 }
 
+// GetStartExpression returns the start index of the range, or nil if it is omitted as in `x[:end]`.
+func (f *IndexRange) GetStartExpression() Node[NodeType] {
+	return f.StartExpression
+}
+
+// GetEndExpression returns the end index of the range, or nil if it is omitted as in `x[start:]`.
+func (f *IndexRange) GetEndExpression() Node[NodeType] {
+	return f.EndExpression
+}
+
 func (f *IndexRange) UnmarshalJSON(data []byte) error {
 	var tempMap map[string]json.RawMessage
 	if err := json.Unmarshal(data, &tempMap); err != nil {
@@ -135,12 +155,46 @@ func (f *IndexRange) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	for _, key := range []string{"startExpression", "endExpression"} {
+		raw, ok := tempMap[key]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		var tempNodeMap map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &tempNodeMap); err != nil {
+			return err
+		}
+
+		var tempNodeType ast_pb.NodeType
+		if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+			return err
+		}
+
+		node, err := unmarshalNode(raw, tempNodeType)
+		if err != nil {
+			return err
+		}
+
+		if key == "startExpression" {
+			f.StartExpression = node
+		} else {
+			f.EndExpression = node
+		}
+	}
+
 	if typeDescriptions, ok := tempMap["typeDescriptions"]; ok {
 		if err := json.Unmarshal(typeDescriptions, &f.TypeDescriptions); err != nil {
 			return err
 		}
 	}
 
+	if typeDescription, ok := tempMap["typeDescription"]; ok {
+		if err := json.Unmarshal(typeDescription, &f.TypeDescription); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -201,6 +255,19 @@ func (f *IndexRange) Parse(
 	f.LeftExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.Expression(0))
 	f.RightExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.Expression(1))
 
+	// Both range bounds are optional so the right expression alone cannot tell which one of them is present.
+	if ctx.GetStartIndex() != nil {
+		f.StartExpression = f.RightExpression
+	}
+
+	if ctx.GetEndIndex() != nil {
+		if ctx.GetStartIndex() == nil {
+			f.EndExpression = f.RightExpression
+		} else {
+			f.EndExpression = expression.Parse(unit, contractNode, fnNode, bodyNode, vDeclar, f, f.GetId(), ctx.GetEndIndex())
+		}
+	}
+
 	f.TypeDescriptions = append(f.TypeDescriptions, f.LeftExpression.GetTypeDescription())
 	f.TypeDescriptions = append(f.TypeDescriptions, f.RightExpression.GetTypeDescription())
 
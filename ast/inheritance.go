@@ -89,6 +89,15 @@ func parseInheritanceFromCtx(b *ASTBuilder, unit *SourceUnit[Node[ast_pb.SourceU
 			}
 		}
 
+		if specifierCtx.CallArgumentList() != nil {
+			expression := NewExpression(b)
+			for _, argumentCtx := range specifierCtx.CallArgumentList().AllExpression() {
+				if argument := expression.Parse(unit, contractNode, nil, nil, nil, nil, baseContract.GetId(), argumentCtx); argument != nil {
+					baseContract.Arguments = append(baseContract.Arguments, argument)
+				}
+			}
+		}
+
 		toReturn = append(toReturn, baseContract)
 	}
 
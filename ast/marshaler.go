@@ -15,6 +15,23 @@ import (
 // with the appropriate type URL and structpb.Value.
 // It returns the created TypedStruct instance or nil in case of errors during marshaling or unmarshaling.
 func NewTypedStruct(m protoreflect.ProtoMessage, protoType string) *v3.TypedStruct {
+	s := newStruct(m)
+	if s == nil {
+		return nil
+	}
+
+	return &v3.TypedStruct{
+		TypeUrl: fmt.Sprintf(
+			"github.com/unpackdev/protos/unpack.v1.ast.%s",
+			protoType,
+		),
+		Value: s,
+	}
+}
+
+// newStruct converts the given protoreflect.ProtoMessage into a structpb.Struct through its JSON
+// representation. It returns nil in case of errors during marshaling or unmarshaling.
+func newStruct(m protoreflect.ProtoMessage) *structpb.Struct {
 	jsonBytes, err := protojson.Marshal(m)
 	if err != nil {
 		zap.L().Error("failed to marshal proto to json", zap.Error(err))
@@ -27,11 +44,5 @@ func NewTypedStruct(m protoreflect.ProtoMessage, protoType string) *v3.TypedStru
 		return nil
 	}
 
-	return &v3.TypedStruct{
-		TypeUrl: fmt.Sprintf(
-			"github.com/unpackdev/protos/unpack.v1.ast.%s",
-			protoType,
-		),
-		Value: s,
-	}
+	return s
 }
@@ -12,6 +12,7 @@ type MetaType struct {
 	Id                    int64            `json:"id"`                              // Unique identifier of the meta-type node.
 	NodeType              ast_pb.NodeType  `json:"nodeType"`                        // Type of the node.
 	Name                  string           `json:"name"`                            // Name of the meta-type.
+	TypeName              *TypeName        `json:"typeName,omitempty"`              // Type name the meta-type is queried for, such as X in type(X).
 	Src                   SrcNode          `json:"src"`                             // Source location information.
 	ReferencedDeclaration int64            `json:"referencedDeclaration,omitempty"` // Referenced declaration identifier.
 	TypeDescription       *TypeDescription `json:"typeDescription"`                 // Type description of the meta-type.
@@ -53,6 +54,11 @@ func (m *MetaType) GetName() string {
 	return m.Name
 }
 
+// GetTypeName returns the type name the meta-type is queried for.
+func (m *MetaType) GetTypeName() *TypeName {
+	return m.TypeName
+}
+
 // GetTypeDescription returns the type description of the meta-type.
 func (m *MetaType) GetTypeDescription() *TypeDescription {
 	return m.TypeDescription
@@ -60,6 +66,9 @@ func (m *MetaType) GetTypeDescription() *TypeDescription {
 
 // GetNodes returns a slice of nodes associated with the meta-type.
 func (m *MetaType) GetNodes() []Node[NodeType] {
+	if m.TypeName != nil {
+		return []Node[NodeType]{m.TypeName}
+	}
 	return []Node[NodeType]{}
 }
 
@@ -115,5 +124,11 @@ func (m *MetaType) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]],
 		TypeString: ctx.Type().GetText(),
 	}
 
+	if ctx.TypeName() != nil {
+		typeName := NewTypeName(m.ASTBuilder)
+		typeName.Parse(unit, fnNode, m.GetId(), ctx.TypeName())
+		m.TypeName = typeName
+	}
+
 	return m
 }
@@ -220,14 +220,6 @@ func (m *ModifierDefinition) ParseDefinition(
 		bodyNode := NewBodyNode(m.ASTBuilder, false)
 		bodyNode.ParseBlock(unit, contractNode, m, ctx.Block())
 		m.Body = bodyNode
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(m.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, m, uncheckedCtx)
-				m.Body.Statements = append(m.Body.Statements, bodyNode)
-			}
-		}
 	}
 
 	m.currentModifiers = append(m.currentModifiers, m)
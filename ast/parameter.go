@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/goccy/go-json"
 
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
@@ -271,7 +272,18 @@ func (p *Parameter) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node
 
 	typeName := NewTypeName(p.ASTBuilder)
 	typeName.WithParameterList(plNode)
-	typeName.Parse(unit, fnNode, p.GetId(), ctx.TypeName())
+
+	// Parser skips the `byte` alias of bytes1 in front of the parameter, taking the name of the
+	// parameter for its type, e.g. `byte b` is parsed as an unnamed parameter of type b.
+	if token := p.byteAliasBefore(ctx); token != nil && ctx.Identifier() == nil && ctx.TypeName() != nil && ctx.TypeName().IdentifierPath() != nil {
+		p.Name = ctx.TypeName().GetText()
+		p.Src.Column = int64(token.GetColumn())
+		p.Src.Start = int64(token.GetStart())
+		p.Src.Length = p.Src.End - p.Src.Start + 1
+		typeName.ParseByteAlias(unit, fnNode, p.GetId(), token)
+	} else {
+		typeName.Parse(unit, fnNode, p.GetId(), ctx.TypeName())
+	}
 
 	if typeName.TypeDescription != nil {
 		switch typeName.TypeDescription.TypeIdentifier {
@@ -440,3 +452,26 @@ func (p *Parameter) getStorageLocationFromCtx(ctx *parser.ParameterDeclarationCo
 
 	return ast_pb.StorageLocation_MEMORY
 }
+
+// byteAliasBefore returns the `byte` keyword skipped by the parser right in front of the parameter
+// declaration, if any.
+func (p *Parameter) byteAliasBefore(ctx *parser.ParameterDeclarationContext) antlr.Token {
+	if p.parser == nil {
+		return nil
+	}
+
+	stream := p.parser.GetTokenStream()
+	for index := ctx.GetStart().GetTokenIndex() - 1; index >= 0; index-- {
+		token := stream.Get(index)
+		if token.GetChannel() != antlr.TokenDefaultChannel {
+			continue
+		}
+
+		if token.GetTokenType() == parser.SolidityParserReservedKeywords && token.GetText() == "byte" {
+			return token
+		}
+		return nil
+	}
+
+	return nil
+}
@@ -513,6 +513,20 @@ func (p *PrimaryExpression) Parse(
 		}
 	}
 
+	// Number literals with a subdenomination, such as 1 ether or 24 hours, are constants of their
+	// value in wei or seconds.
+	if subDenominationCtx := ctx.LiteralWithSubDenomination(); subDenominationCtx != nil && subDenominationCtx.NumberLiteral() != nil {
+		p.NodeType = ast_pb.NodeType_LITERAL
+		p.Kind = ast_pb.NodeType_NUMBER
+		p.Pure = true
+		p.Value = subDenominationCtx.NumberLiteral().GetText()
+		if subDenominationCtx.SubDenomination() != nil {
+			p.Value += " " + subDenominationCtx.SubDenomination().GetText()
+		}
+		p.HexValue = hex.EncodeToString([]byte(p.Value))
+		p.TypeDescription = numberLiteralType(p.Value).ToTypeDescription()
+	}
+
 	if fnNode != nil && p.TypeDescription == nil {
 		if fn, ok := fnNode.(*Function); ok {
 			if fn.GetParameters() != nil {
@@ -294,14 +294,6 @@ func (f *Receive) Parse(
 
 		bodyNode.ParseBlock(unit, contractNode, f, ctx.Block())
 		f.Body = bodyNode
-
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(f.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, f, uncheckedCtx)
-				f.Body.Statements = append(f.Body.Statements, bodyNode)
-			}
-		}
 	}
 
 	return f
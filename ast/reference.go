@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
@@ -150,7 +151,10 @@ func (r *Resolver) Resolve() []error {
 	// and because of it, it B will never discover A. In order to ensure that is not the case here,
 	// we are going to iterate few times through unprocessed references...
 	for i := 0; i <= 3; i++ {
-		for nodeId, node := range r.UnprocessedNodes {
+		// Nodes are visited in the order of their ids, as the references resolved first are used
+		// to resolve the following ones and map order would make the result vary between runs.
+		for _, nodeId := range r.unprocessedIds() {
+			node := r.UnprocessedNodes[nodeId]
 			if rNodeId, rNodeType := r.resolveByNode(node.Name, node.Node); rNodeType != nil {
 				if updated := r.tree.UpdateNodeReferenceById(nodeId, rNodeId, rNodeType); updated {
 					delete(r.UnprocessedNodes, nodeId)
@@ -172,7 +176,8 @@ func (r *Resolver) Resolve() []error {
 		}
 	}
 
-	for nodeId, node := range r.UnprocessedNodes {
+	for _, nodeId := range r.unprocessedIds() {
+		node := r.UnprocessedNodes[nodeId]
 		if node.ErrFindRef {
 			errors = append(
 				errors,
@@ -195,6 +200,16 @@ func (r *Resolver) Resolve() []error {
 	return errors
 }
 
+// unprocessedIds returns ids of the unprocessed nodes in ascending order.
+func (r *Resolver) unprocessedIds() []int64 {
+	toReturn := make([]int64, 0, len(r.UnprocessedNodes))
+	for nodeId := range r.UnprocessedNodes {
+		toReturn = append(toReturn, nodeId)
+	}
+	sort.Slice(toReturn, func(i, j int) bool { return toReturn[i] < toReturn[j] })
+	return toReturn
+}
+
 // resolveImportDirectives resolves import directives in the AST.
 func (r *Resolver) resolveImportDirectives() {
 	for _, sourceNode := range r.sourceUnits {
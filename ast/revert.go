@@ -12,11 +12,12 @@ import (
 type RevertStatement struct {
 	*ASTBuilder
 
-	Id         int64            `json:"id"`         // Unique identifier for the RevertStatement node.
-	NodeType   ast_pb.NodeType  `json:"nodeType"`   // Type of the AST node.
-	Src        SrcNode          `json:"src"`        // Source location information.
-	Arguments  []Node[NodeType] `json:"arguments"`  // List of argument expressions.
-	Expression Node[NodeType]   `json:"expression"` // Expression within the revert statement.
+	Id         int64            `json:"id"`              // Unique identifier for the RevertStatement node.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`        // Type of the AST node.
+	Src        SrcNode          `json:"src"`             // Source location information.
+	Arguments  []Node[NodeType] `json:"arguments"`       // List of argument expressions.
+	Names      []string         `json:"names,omitempty"` // Argument names in case of named arguments such as revert E({a: 1}).
+	Expression Node[NodeType]   `json:"expression"`      // Expression within the revert statement.
 }
 
 // NewRevertStatement creates a new RevertStatement node with a given ASTBuilder.
@@ -55,6 +56,12 @@ func (r *RevertStatement) GetArguments() []Node[NodeType] {
 	return r.Arguments
 }
 
+// GetNames returns the argument names of the revert statement. It's empty unless the error is
+// raised with named arguments, in which case the names are in the same order as the arguments.
+func (r *RevertStatement) GetNames() []string {
+	return r.Names
+}
+
 // GetExpression returns the expression within the revert statement.
 func (r *RevertStatement) GetExpression() Node[NodeType] {
 	return r.Expression
@@ -118,6 +125,12 @@ func (r *RevertStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if names, ok := tempMap["names"]; ok {
+		if err := json.Unmarshal(names, &r.Names); err != nil {
+			return err
+		}
+	}
+
 	if expression, ok := tempMap["expression"]; ok {
 		if err := json.Unmarshal(expression, &r.Expression); err != nil {
 			var tempNodeMap map[string]json.RawMessage
@@ -186,7 +199,13 @@ func (r *RevertStatement) Parse(
 	expression := NewExpression(r.ASTBuilder)
 
 	if ctx.CallArgumentList() != nil {
-		for _, expressionCtx := range ctx.CallArgumentList().AllExpression() {
+		argumentCtxs := ctx.CallArgumentList().AllExpression()
+		for _, namedArgumentCtx := range ctx.CallArgumentList().AllNamedArgument() {
+			r.Names = append(r.Names, namedArgumentCtx.GetName().GetText())
+			argumentCtxs = append(argumentCtxs, namedArgumentCtx.GetValue())
+		}
+
+		for _, expressionCtx := range argumentCtxs {
 			r.Arguments = append(
 				r.Arguments,
 				expression.Parse(
@@ -0,0 +1,609 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// nodeSlot describes the place within the tree holding a node: a struct field or an element of
+// a slice field. Nodes are owned through plain fields (e.g. IfStatement.Body), slices of nodes
+// (e.g. BodyNode.Statements) or helper structures such as ParameterList.
+type nodeSlot struct {
+	owner    Node[NodeType]                       // Closest node owning the slot, nil for source units.
+	parentId int64                                // Id of the structure holding the slot, used as parent index.
+	unit     *SourceUnit[Node[ast_pb.SourceUnit]] // Source unit the slot belongs to.
+	field    reflect.Value                        // Settable field holding the node or the slice of nodes.
+	index    int                                  // Index within the slice field or -1 for a plain field.
+	optional bool                                 // Whether the plain field may be left empty, e.g. IfStatement.Else.
+}
+
+// optionalFields lists the plain fields which can be left empty even though they are not marked
+// with omitempty, by the name of the structure and the field.
+var optionalFields = map[string]bool{
+	"ForStatement.Initialiser":              true,
+	"ForStatement.Condition":                true,
+	"ForStatement.Closure":                  true,
+	"ReturnStatement.Expression":            true,
+	"StateVariableDeclaration.InitialValue": true,
+}
+
+// get returns the node currently held by the slot.
+func (s *nodeSlot) get() Node[NodeType] {
+	value := s.field
+	if s.index >= 0 {
+		value = s.field.Index(s.index)
+	}
+
+	if node, ok := value.Interface().(Node[NodeType]); ok {
+		return node
+	}
+
+	return nil
+}
+
+// set stores the node within the slot, making sure the node type fits the field.
+func (s *nodeSlot) set(node Node[NodeType]) error {
+	target := s.field.Type()
+	if s.index >= 0 {
+		target = target.Elem()
+	}
+
+	value, err := nodeValue(node, target)
+	if err != nil {
+		return err
+	}
+
+	if s.index >= 0 {
+		s.field.Index(s.index).Set(value)
+	} else {
+		s.field.Set(value)
+	}
+
+	return nil
+}
+
+// insert stores the node into the slice field of the slot at the provided index.
+func (s *nodeSlot) insert(index int, node Node[NodeType]) error {
+	if s.index < 0 {
+		return fmt.Errorf("node is not part of a list, unable to insert sibling of type %T", node)
+	}
+
+	value, err := nodeValue(node, s.field.Type().Elem())
+	if err != nil {
+		return err
+	}
+
+	// A new slice is allocated on purpose so that walks ranging over the old one are not affected.
+	length := s.field.Len()
+	slice := reflect.MakeSlice(s.field.Type(), 0, length+1)
+	slice = reflect.AppendSlice(slice, s.field.Slice(0, index))
+	slice = reflect.Append(slice, value)
+	slice = reflect.AppendSlice(slice, s.field.Slice(index, length))
+	s.field.Set(slice)
+
+	return nil
+}
+
+// remove removes the node held by the slot. Optional plain fields are set to nil, while required
+// ones cannot be left empty.
+func (s *nodeSlot) remove() error {
+	if s.index < 0 {
+		if !s.optional {
+			return fmt.Errorf("node of type %T is required by its parent and cannot be removed", s.get())
+		}
+		s.field.Set(reflect.Zero(s.field.Type()))
+		return nil
+	}
+
+	length := s.field.Len()
+	slice := reflect.MakeSlice(s.field.Type(), 0, length-1)
+	slice = reflect.AppendSlice(slice, s.field.Slice(0, s.index))
+	slice = reflect.AppendSlice(slice, s.field.Slice(s.index+1, length))
+	s.field.Set(slice)
+
+	return nil
+}
+
+// nodeValue converts the node into a reflected value assignable to the target type.
+func nodeValue(node Node[NodeType], target reflect.Type) (reflect.Value, error) {
+	value := reflect.ValueOf(node)
+	if !value.Type().AssignableTo(target) {
+		return reflect.Value{}, fmt.Errorf("node of type %T cannot be stored as %s", node, target)
+	}
+
+	return value, nil
+}
+
+// GetParent returns the node owning the provided node or nil if the node is a source unit or
+// is not part of the tree.
+func (t *Tree) GetParent(node Node[NodeType]) Node[NodeType] {
+	if slot := t.findSlot(node); slot != nil {
+		return slot.owner
+	}
+
+	return nil
+}
+
+// Replace replaces the node with a new one. The new node takes over the source location of the
+// replaced node and the offsets of the rest of the sources are shifted by the difference in
+// length of the two nodes. Nodes without an id get a new one, while existing ids are preserved.
+func (t *Tree) Replace(node Node[NodeType], replacement Node[NodeType]) error {
+	if replacement == nil {
+		return errors.New("replacement node is not set")
+	}
+
+	slot, err := t.slotOf(node)
+	if err != nil {
+		return err
+	}
+
+	if err := t.checkDetached(replacement, node); err != nil {
+		return err
+	}
+
+	if err := slot.set(replacement); err != nil {
+		return err
+	}
+
+	t.replaceGlobal(node, replacement)
+	t.attach(slot, replacement, node.GetSrc().GetStart())
+	t.shiftSrc(replacement, node.GetSrc().GetStart(), node.GetSrc().GetLength(), replacement.GetSrc().GetLength())
+
+	return nil
+}
+
+// Remove removes the node from the tree. Nodes held by a list, such as statements, are removed
+// from the list, while nodes held by an optional plain field, such as an else branch, are set to
+// nil. Nodes required by their parent, such as the condition of an if statement, cannot be removed
+// and have to be replaced instead. The offsets of the rest of the sources are shifted by the length
+// of the removed node.
+func (t *Tree) Remove(node Node[NodeType]) error {
+	slot, err := t.slotOf(node)
+	if err != nil {
+		return err
+	}
+
+	if err := slot.remove(); err != nil {
+		return err
+	}
+
+	t.replaceGlobal(node, nil)
+	t.shiftSrc(nil, node.GetSrc().GetStart(), node.GetSrc().GetLength(), 0)
+
+	return nil
+}
+
+// InsertBefore inserts the node right before its sibling. The sibling has to be held by a list,
+// such as a statement of a body or a member of a contract.
+func (t *Tree) InsertBefore(sibling Node[NodeType], node Node[NodeType]) error {
+	return t.insert(sibling, node, false)
+}
+
+// InsertAfter inserts the node right after its sibling. The sibling has to be held by a list,
+// such as a statement of a body or a member of a contract.
+func (t *Tree) InsertAfter(sibling Node[NodeType], node Node[NodeType]) error {
+	return t.insert(sibling, node, true)
+}
+
+// Wrap replaces the node with the node returned by the wrap function, which has to contain the
+// original node, e.g. wrapping a statement into an if statement or an unchecked block. The
+// wrapper takes over the source location of the wrapped node, which keeps its id and location.
+func (t *Tree) Wrap(node Node[NodeType], wrap func(node Node[NodeType]) Node[NodeType]) error {
+	slot, err := t.slotOf(node)
+	if err != nil {
+		return err
+	}
+
+	wrapper := wrap(node)
+	if wrapper == nil {
+		return errors.New("wrap function returned no node")
+	}
+
+	if findSlotIn(wrapper, node) == nil {
+		return fmt.Errorf("wrapper of type %T does not contain the wrapped node %d", wrapper, node.GetId())
+	}
+
+	if err := t.checkDetached(wrapper, node); err != nil {
+		return err
+	}
+
+	if err := slot.set(wrapper); err != nil {
+		return err
+	}
+
+	t.replaceGlobal(node, wrapper)
+
+	t.assignIds(wrapper)
+	src := node.GetSrc()
+	src.ParentIndex = slot.parentId
+	setSrc(wrapper, src)
+	t.relinkParents(wrapper, node)
+
+	return nil
+}
+
+// insert inserts the node before or after its sibling.
+func (t *Tree) insert(sibling Node[NodeType], node Node[NodeType], after bool) error {
+	if node == nil {
+		return errors.New("node to insert is not set")
+	}
+
+	slot, err := t.slotOf(sibling)
+	if err != nil {
+		return err
+	}
+
+	if err := t.checkDetached(node, nil); err != nil {
+		return err
+	}
+
+	index, offset := slot.index, sibling.GetSrc().GetStart()
+	if after {
+		index, offset = slot.index+1, sibling.GetSrc().GetEnd()+1
+	}
+
+	if err := slot.insert(index, node); err != nil {
+		return err
+	}
+
+	t.attach(slot, node, offset)
+	t.shiftSrc(node, offset, 0, node.GetSrc().GetLength())
+
+	return nil
+}
+
+// slotOf returns the slot holding the node or an error if the node is not part of the tree.
+func (t *Tree) slotOf(node Node[NodeType]) (*nodeSlot, error) {
+	if node == nil {
+		return nil, errors.New("node is not set")
+	}
+
+	slot := t.findSlot(node)
+	if slot == nil {
+		return nil, fmt.Errorf("node %d of type %T is not part of the tree", node.GetId(), node)
+	}
+
+	return slot, nil
+}
+
+// checkDetached makes sure that the node, with the exception of the allowed subtree, is not
+// already part of the tree, as the same node cannot be held by two parents.
+func (t *Tree) checkDetached(node Node[NodeType], allowed Node[NodeType]) error {
+	var err error
+	walkNodeTree(node, func(n Node[NodeType]) bool {
+		if allowed != nil && n == allowed {
+			return false
+		}
+
+		if t.findSlot(n) != nil {
+			err = fmt.Errorf("node %d of type %T is already part of the tree", n.GetId(), n)
+			return false
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+// attach assigns ids to the nodes of the newly attached subtree, moves the subtree to the provided
+// source offset and links the parent indexes of its nodes.
+func (t *Tree) attach(slot *nodeSlot, node Node[NodeType], offset int64) {
+	t.assignIds(node)
+
+	moveSrc(node, offset-node.GetSrc().GetStart())
+
+	src := node.GetSrc()
+	src.ParentIndex = slot.parentId
+	setSrc(node, src)
+
+	t.relinkParents(node, nil)
+}
+
+// assignIds assigns new ids to all nodes of the subtree that do not have one yet and records
+// the ids of the subtree, so that they can be looked up by GetById.
+func (t *Tree) assignIds(node Node[NodeType]) {
+	if t.rewritten == nil {
+		t.rewritten = make(map[int64]bool)
+	}
+
+	walkNodeTree(node, func(n Node[NodeType]) bool {
+		if n.GetId() == 0 && t.ASTBuilder != nil {
+			setId(n, t.GetNextID())
+		}
+		t.rewritten[n.GetId()] = true
+		return true
+	})
+}
+
+// relinkParents sets the parent index of all nodes of the subtree to the id of the structure
+// holding them. The stop subtree, if provided, is linked to its new parent but not descended into.
+func (t *Tree) relinkParents(node Node[NodeType], stop Node[NodeType]) {
+	walker := newSlotWalker(func(slot *nodeSlot) bool {
+		child := slot.get()
+		src := child.GetSrc()
+		src.ParentIndex = slot.parentId
+		setSrc(child, src)
+		return child != stop
+	})
+	walker.walk(reflect.ValueOf(node), node, node.GetId(), nil)
+}
+
+// shiftSrc updates the source locations of all source units after the source range starting at
+// the offset with the old length was replaced by a range with the new length. Offsets are positions
+// within the combined source, so nodes after the range are moved, including the ones of the source
+// units that follow, while nodes containing the range are resized. The skip subtree, holding the
+// nodes of the new range, is left untouched.
+func (t *Tree) shiftSrc(skip Node[NodeType], offset, oldLength, newLength int64) {
+	delta := newLength - oldLength
+	if t.astRoot == nil || delta == 0 {
+		return
+	}
+
+	end := offset + oldLength
+	visit := func(n Node[NodeType]) bool {
+		if skip != nil && n == skip {
+			return false
+		}
+
+		src := n.GetSrc()
+		if src.Start == 0 && src.End == 0 && src.Length == 0 {
+			// Nodes without a source location, e.g. created by previous rewrites.
+			return true
+		}
+
+		contains := src.Start <= offset && src.End >= end-1
+		if oldLength == 0 {
+			contains = src.Start < offset && src.End >= offset
+		}
+
+		switch {
+		case src.Start >= end:
+			src.Start += delta
+			src.End += delta
+		case contains:
+			src.End += delta
+			src.Length += delta
+		default:
+			return true
+		}
+
+		setSrc(n, src)
+		return true
+	}
+
+	for _, unit := range t.astRoot.GetSourceUnits() {
+		walkNodeTree(unit, visit)
+	}
+}
+
+// replaceGlobal keeps the global nodes of the root in sync with the rewritten tree.
+func (t *Tree) replaceGlobal(node Node[NodeType], replacement Node[NodeType]) {
+	if t.astRoot == nil {
+		return
+	}
+
+	globals := make([]Node[NodeType], 0, len(t.astRoot.Globals))
+	for _, global := range t.astRoot.Globals {
+		if global == node {
+			if replacement == nil {
+				continue
+			}
+			global = replacement
+		}
+		globals = append(globals, global)
+	}
+
+	t.astRoot.Globals = globals
+}
+
+// findSlot searches all of the source units of the tree for the slot holding the node.
+func (t *Tree) findSlot(node Node[NodeType]) *nodeSlot {
+	if t.astRoot == nil || node == nil {
+		return nil
+	}
+
+	var found *nodeSlot
+	walker := newSlotWalker(nil)
+	walker.visit = func(slot *nodeSlot) bool {
+		if slot.get() == node {
+			found = slot
+			walker.stopped = true
+		}
+		return true
+	}
+
+	for i, unit := range t.astRoot.SourceUnits {
+		if Node[NodeType](unit) == node {
+			return &nodeSlot{
+				parentId: t.astRoot.GetId(),
+				unit:     unit,
+				field:    reflect.ValueOf(t.astRoot).Elem().FieldByName("SourceUnits"),
+				index:    i,
+			}
+		}
+
+		if walker.walk(reflect.ValueOf(unit), unit, unit.GetId(), unit); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// findSlotIn searches the subtree of the root node for the slot holding the node.
+func findSlotIn(root Node[NodeType], node Node[NodeType]) *nodeSlot {
+	var found *nodeSlot
+	walker := newSlotWalker(nil)
+	walker.visit = func(slot *nodeSlot) bool {
+		if slot.get() == node {
+			found = slot
+			walker.stopped = true
+		}
+		return true
+	}
+	walker.walk(reflect.ValueOf(root), root, root.GetId(), nil)
+
+	return found
+}
+
+// byReflectedId searches all of the source units for the node with the provided id, including
+// the nodes that are not exposed through GetNodes.
+func (t *Tree) byReflectedId(id int64) Node[NodeType] {
+	for _, unit := range t.astRoot.GetSourceUnits() {
+		var found Node[NodeType]
+		walkNodeTree(unit, func(n Node[NodeType]) bool {
+			if n.GetId() == id {
+				found = n
+			}
+			return found == nil
+		})
+
+		if found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// walkNodeTree calls the visit function for the node and all of its descendants, as discovered
+// through the fields of the nodes. Returning false from the visit function skips the descendants
+// of the node.
+func walkNodeTree(node Node[NodeType], visit func(node Node[NodeType]) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	walker := newSlotWalker(func(slot *nodeSlot) bool {
+		return visit(slot.get())
+	})
+	walker.walk(reflect.ValueOf(node), node, node.GetId(), nil)
+}
+
+// slotWalker visits all of the slots holding nodes, as discovered through the fields of the nodes.
+// Fields skipped in JSON hold references to nodes owned elsewhere, e.g. TypeName.ParentNode, and
+// are not followed.
+type slotWalker struct {
+	visit   func(slot *nodeSlot) bool // Visit returns whether the node held by the slot is descended into.
+	visited map[uintptr]bool          // Visited structures, by pointer.
+	stopped bool                      // Stopped is set to stop the walk.
+}
+
+// newSlotWalker creates a new slotWalker with the provided visit function.
+func newSlotWalker(visit func(slot *nodeSlot) bool) *slotWalker {
+	return &slotWalker{
+		visit:   visit,
+		visited: make(map[uintptr]bool),
+	}
+}
+
+// walk visits the slots found within the value, which belongs to the owner node.
+func (w *slotWalker) walk(value reflect.Value, owner Node[NodeType], parentId int64, unit *SourceUnit[Node[ast_pb.SourceUnit]]) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+
+		if value.Kind() == reflect.Ptr {
+			if w.visited[value.Pointer()] {
+				return
+			}
+			w.visited[value.Pointer()] = true
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	if id := value.FieldByName("Id"); id.IsValid() && id.Kind() == reflect.Int64 {
+		parentId = id.Int()
+	}
+
+	for i := 0; i < value.NumField() && !w.stopped; i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Anonymous || field.Tag.Get("json") == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		switch fieldValue.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			optional := strings.Contains(field.Tag.Get("json"), ",omitempty") || optionalFields[value.Type().Name()+"."+field.Name]
+			w.walkSlot(&nodeSlot{owner: owner, parentId: parentId, unit: unit, field: fieldValue, index: -1, optional: optional})
+		case reflect.Slice:
+			for j := 0; j < fieldValue.Len() && !w.stopped; j++ {
+				w.walkSlot(&nodeSlot{owner: owner, parentId: parentId, unit: unit, field: fieldValue, index: j})
+			}
+		}
+	}
+}
+
+// walkSlot visits a single slot. Nodes are passed to the visit function, while helper structures
+// such as ParameterList are searched for nodes on behalf of their owner.
+func (w *slotWalker) walkSlot(slot *nodeSlot) {
+	value := slot.field
+	if slot.index >= 0 {
+		value = slot.field.Index(slot.index)
+	}
+
+	if (value.Kind() != reflect.Interface && value.Kind() != reflect.Ptr) || value.IsNil() {
+		return
+	}
+
+	node := slot.get()
+	if node == nil {
+		w.walk(value, slot.owner, slot.parentId, slot.unit)
+		return
+	}
+
+	if w.visit(slot) && !w.stopped {
+		w.walk(value, node, node.GetId(), slot.unit)
+	}
+}
+
+// moveSrc moves the source locations of all nodes of the subtree by the delta.
+func moveSrc(node Node[NodeType], delta int64) {
+	if delta == 0 {
+		return
+	}
+
+	walkNodeTree(node, func(n Node[NodeType]) bool {
+		src := n.GetSrc()
+		src.Start += delta
+		src.End += delta
+		setSrc(n, src)
+		return true
+	})
+}
+
+// setSrc sets the source location of the node.
+func setSrc(node Node[NodeType], src SrcNode) {
+	if field := nodeField(node, "Src"); field.IsValid() && field.Type() == reflect.TypeOf(src) {
+		field.Set(reflect.ValueOf(src))
+	}
+}
+
+// setId sets the id of the node.
+func setId(node Node[NodeType], id int64) {
+	if field := nodeField(node, "Id"); field.IsValid() && field.Kind() == reflect.Int64 {
+		field.SetInt(id)
+	}
+}
+
+// nodeField returns the settable field of the node with the provided name.
+func nodeField(node Node[NodeType], name string) reflect.Value {
+	value := reflect.ValueOf(node)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	return value.Elem().FieldByName(name)
+}
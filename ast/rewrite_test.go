@@ -0,0 +1,219 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// buildRewriteTree parses the SimpleStorage contract used by the rewrite tests.
+func buildRewriteTree(t *testing.T) *ASTBuilder {
+	_, astBuilder := parseTestSources(t, newTestSources("SimpleStorage",
+		newTestSourceUnit(t, "SimpleStorage"),
+		newTestSourceUnit(t, "MathLib"),
+	))
+	astBuilder.ResolveReferences()
+
+	return astBuilder
+}
+
+// getRewriteFunction returns the function of the SimpleStorage contract with the provided name.
+func getRewriteFunction(t *testing.T, b *ASTBuilder, name string) *Function {
+	contract, ok := b.GetRoot().GetSourceUnitByName("SimpleStorage").GetContract().(*Contract)
+	require.True(t, ok)
+
+	for _, node := range contract.GetNodes() {
+		if function, ok := node.(*Function); ok && function.GetName() == name {
+			return function
+		}
+	}
+
+	require.Failf(t, "function not found", "function %s", name)
+	return nil
+}
+
+// newRequireCall creates a detached require(x) call with the provided source length.
+func newRequireCall(b *ASTBuilder, length int64) *FunctionCall {
+	return &FunctionCall{
+		ASTBuilder: b,
+		NodeType:   ast_pb.NodeType_FUNCTION_CALL,
+		Kind:       ast_pb.NodeType_FUNCTION_CALL,
+		Src:        SrcNode{Length: length, End: length - 1},
+		Expression: &PrimaryExpression{
+			ASTBuilder: b,
+			NodeType:   ast_pb.NodeType_IDENTIFIER,
+			Name:       "require",
+		},
+		Arguments: []Node[NodeType]{
+			&PrimaryExpression{
+				ASTBuilder: b,
+				NodeType:   ast_pb.NodeType_IDENTIFIER,
+				Name:       "x",
+			},
+		},
+	}
+}
+
+func TestTreeInsertAndRemove(t *testing.T) {
+	b := buildRewriteTree(t)
+	tree := b.GetTree()
+
+	increment := getRewriteFunction(t, b, "increment")
+	decrement := getRewriteFunction(t, b, "decrement")
+	contract := tree.GetParent(increment)
+	require.NotNil(t, contract)
+
+	statement := increment.GetBody().GetStatements()[0]
+	statementSrc := statement.GetSrc()
+	incrementSrc := increment.GetSrc()
+	decrementSrc := decrement.GetSrc()
+	contractSrc := contract.GetSrc()
+
+	call := newRequireCall(b, 11)
+	require.NoError(t, tree.InsertBefore(statement, call))
+
+	// Ids are assigned to the new nodes and the nodes can be found by id.
+	assert.NotZero(t, call.GetId())
+	assert.NotZero(t, call.Arguments[0].GetId())
+	assert.NotEqual(t, call.GetId(), call.Arguments[0].GetId())
+	assert.Equal(t, call, tree.GetById(call.GetId()))
+	assert.Equal(t, call.Arguments[0], tree.GetById(call.Arguments[0].GetId()))
+	assert.Equal(t, statement, tree.GetById(statement.GetId()))
+
+	// The new node is linked to its parents.
+	assert.Equal(t, []Node[NodeType]{call, statement}, increment.GetBody().GetStatements())
+	assert.Equal(t, Node[NodeType](increment.GetBody()), tree.GetParent(call))
+	assert.Equal(t, increment.GetBody().GetId(), call.GetSrc().GetParentIndex())
+	assert.Equal(t, call.GetId(), call.Arguments[0].GetSrc().GetParentIndex())
+
+	// The new node takes the place of the statement which is moved together with the rest of
+	// the source unit, while the enclosing nodes grow.
+	assert.Equal(t, statementSrc.GetStart(), call.GetSrc().GetStart())
+	assert.Equal(t, statementSrc.GetStart()+11, statement.GetSrc().GetStart())
+	assert.Equal(t, statementSrc.GetEnd()+11, statement.GetSrc().GetEnd())
+	assert.Equal(t, incrementSrc.GetStart(), increment.GetSrc().GetStart())
+	assert.Equal(t, incrementSrc.GetLength()+11, increment.GetSrc().GetLength())
+	assert.Equal(t, decrementSrc.GetStart()+11, decrement.GetSrc().GetStart())
+	assert.Equal(t, contractSrc.GetLength()+11, contract.GetSrc().GetLength())
+
+	// Nodes can be inserted only into lists and only once.
+	assert.Error(t, tree.InsertAfter(increment.GetBody(), newRequireCall(b, 0)))
+	assert.Error(t, tree.InsertAfter(statement, call))
+	assert.Error(t, tree.InsertAfter(newRequireCall(b, 0), newRequireCall(b, 0)))
+
+	require.NoError(t, tree.Remove(call))
+	assert.Nil(t, tree.GetParent(call))
+	assert.Equal(t, []Node[NodeType]{statement}, increment.GetBody().GetStatements())
+	assert.Equal(t, statementSrc, statement.GetSrc())
+	assert.Equal(t, incrementSrc, increment.GetSrc())
+	assert.Equal(t, decrementSrc, decrement.GetSrc())
+	assert.Equal(t, contractSrc, contract.GetSrc())
+
+	assert.Error(t, tree.Remove(call))
+}
+
+func TestTreeReplaceAndWrap(t *testing.T) {
+	b := buildRewriteTree(t)
+	tree := b.GetTree()
+
+	increment := getRewriteFunction(t, b, "increment")
+	decrement := getRewriteFunction(t, b, "decrement")
+
+	statement := increment.GetBody().GetStatements()[0]
+	statementSrc := statement.GetSrc()
+	decrementSrc := decrement.GetSrc()
+
+	call := newRequireCall(b, statementSrc.GetLength()+5)
+	require.NoError(t, tree.Replace(statement, call))
+	assert.Equal(t, []Node[NodeType]{call}, increment.GetBody().GetStatements())
+	assert.Nil(t, tree.GetParent(statement))
+	assert.Equal(t, statementSrc.GetStart(), call.GetSrc().GetStart())
+	assert.Equal(t, statementSrc.GetParentIndex(), call.GetSrc().GetParentIndex())
+	assert.Equal(t, decrementSrc.GetStart()+5, decrement.GetSrc().GetStart())
+
+	// Replacement has to fit the field holding the replaced node.
+	assert.Error(t, tree.Replace(increment.GetBody(), newRequireCall(b, 0)))
+
+	var wrapper *BodyNode
+	require.NoError(t, tree.Wrap(call, func(node Node[NodeType]) Node[NodeType] {
+		wrapper = &BodyNode{
+			ASTBuilder: b,
+			NodeType:   ast_pb.NodeType_UNCHECKED_BLOCK,
+			Statements: []Node[NodeType]{node},
+		}
+		return wrapper
+	}))
+
+	callSrc := call.GetSrc()
+	assert.NotZero(t, wrapper.GetId())
+	assert.Equal(t, []Node[NodeType]{wrapper}, increment.GetBody().GetStatements())
+	assert.Equal(t, Node[NodeType](wrapper), tree.GetParent(call))
+	assert.Equal(t, wrapper.GetId(), callSrc.GetParentIndex())
+	assert.Equal(t, increment.GetBody().GetId(), wrapper.GetSrc().GetParentIndex())
+	assert.Equal(t, callSrc.GetStart(), wrapper.GetSrc().GetStart())
+	assert.Equal(t, callSrc.GetLength(), wrapper.GetSrc().GetLength())
+	assert.Equal(t, Node[NodeType](wrapper), tree.GetById(wrapper.GetId()))
+
+	// Wrapper has to hold the wrapped node.
+	assert.Error(t, tree.Wrap(wrapper, func(node Node[NodeType]) Node[NodeType] {
+		return newRequireCall(b, 0)
+	}))
+}
+
+func TestTreeRemoveRequired(t *testing.T) {
+	b := buildRewriteTree(t)
+	tree := b.GetTree()
+
+	var call *FunctionCall
+	walkNodeTree(getRewriteFunction(t, b, "increment"), func(n Node[NodeType]) bool {
+		if c, ok := n.(*FunctionCall); ok && call == nil {
+			call = c
+		}
+		return true
+	})
+	require.NotNil(t, call)
+
+	// Nodes required by their parent are left in place.
+	expression := call.GetExpression()
+	assert.Error(t, tree.Remove(expression))
+	assert.Equal(t, expression, call.GetExpression())
+	assert.Equal(t, Node[NodeType](call), tree.GetParent(expression))
+
+	// Optional nodes, such as the value of a return statement, can be removed.
+	get := getRewriteFunction(t, b, "get")
+	statement, ok := get.GetBody().GetStatements()[0].(*ReturnStatement)
+	require.True(t, ok)
+	require.NoError(t, tree.Remove(statement.GetExpression()))
+	assert.Nil(t, statement.GetExpression())
+}
+
+func TestTreeShiftAcrossSourceUnits(t *testing.T) {
+	b := buildRewriteTree(t)
+	tree := b.GetTree()
+
+	var add *Function
+	for _, node := range b.GetRoot().GetSourceUnitByName("MathLib").GetContract().GetNodes() {
+		if function, ok := node.(*Function); ok && function.GetName() == "add" {
+			add = function
+		}
+	}
+	require.NotNil(t, add)
+
+	unit := b.GetRoot().GetSourceUnitByName("SimpleStorage")
+	increment := getRewriteFunction(t, b, "increment")
+	statement := add.GetBody().GetStatements()[0]
+	require.Less(t, statement.GetSrc().GetStart(), unit.GetSrc().GetStart())
+
+	unitSrc := unit.GetSrc()
+	incrementSrc := increment.GetSrc()
+
+	// Offsets are positions within the combined source, so the source units following the
+	// rewritten one are moved as well.
+	require.NoError(t, tree.InsertBefore(statement, newRequireCall(b, 11)))
+	assert.Equal(t, unitSrc.GetStart()+11, unit.GetSrc().GetStart())
+	assert.Equal(t, unitSrc.GetEnd()+11, unit.GetSrc().GetEnd())
+	assert.Equal(t, incrementSrc.GetStart()+11, increment.GetSrc().GetStart())
+	assert.Equal(t, incrementSrc.GetLength(), increment.GetSrc().GetLength())
+}
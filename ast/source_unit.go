@@ -294,9 +294,13 @@ func (s *SourceUnit[T]) UnmarshalJSON(data []byte) error {
 
 // EnterSourceUnit is called when the ASTBuilder enters a source unit context.
 // It initializes a new root node and source units based on the context.
+// While reparsing, source units are added to the existing root node instead.
 func (b *ASTBuilder) EnterSourceUnit(ctx *parser.SourceUnitContext) {
-	rootNode := NewRootNode(b, 0, b.sourceUnits, b.comments)
-	b.tree.SetRoot(rootNode)
+	rootNode := b.tree.GetRoot()
+	if b.reparse == nil {
+		rootNode = NewRootNode(b, 0, b.sourceUnits, b.comments)
+		b.tree.SetRoot(rootNode)
+	}
 
 	for _, child := range ctx.GetChildren() {
 		if interfaceCtx, ok := child.(*parser.InterfaceDefinitionContext); ok {
@@ -328,6 +332,11 @@ func (b *ASTBuilder) EnterSourceUnit(ctx *parser.SourceUnitContext) {
 // ExitSourceUnit is called when the ASTBuilder exits a source unit context.
 // It appends the source units to the root node.
 func (b *ASTBuilder) ExitSourceUnit(ctx *parser.SourceUnitContext) {
+	if b.reparse != nil {
+		b.exitReparsedSourceUnit()
+		return
+	}
+
 	b.tree.AppendRootNodes(b.sourceUnits...)
 	b.tree.AppendGlobalNodes(b.globalDefinitions...)
 }
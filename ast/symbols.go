@@ -1,5 +1,12 @@
 package ast
 
+import (
+	"sort"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+)
+
 // Symbol represents a symbol in the Solidity abstract syntax tree (AST).
 type Symbol struct {
 	Id           int64  `json:"id"`            // Unique identifier for the symbol
@@ -30,3 +37,437 @@ func (s Symbol) GetName() string {
 func (s Symbol) GetAbsolutePath() string {
 	return s.AbsolutePath
 }
+
+// ScopeKind is the kind of the lexical scope within the SymbolTable.
+type ScopeKind int
+
+const (
+	// ScopeGlobal holds top level declarations of all of the source units.
+	ScopeGlobal ScopeKind = iota
+	// ScopeSourceUnit holds top level declarations and import aliases of a single source unit.
+	ScopeSourceUnit
+	// ScopeContract holds members of a contract, library or interface.
+	ScopeContract
+	// ScopeFunction holds parameters of a function, constructor, modifier, fallback or receive.
+	ScopeFunction
+	// ScopeBlock holds local variables of a block or a for statement.
+	ScopeBlock
+	// ScopeYulBlock holds variables and functions of an inline assembly block.
+	ScopeYulBlock
+)
+
+// String returns the name of the scope kind.
+func (k ScopeKind) String() string {
+	switch k {
+	case ScopeGlobal:
+		return "global"
+	case ScopeSourceUnit:
+		return "source_unit"
+	case ScopeContract:
+		return "contract"
+	case ScopeFunction:
+		return "function"
+	case ScopeBlock:
+		return "block"
+	case ScopeYulBlock:
+		return "yul_block"
+	default:
+		return "unknown"
+	}
+}
+
+// Scope is a lexical scope of the symbol table. It holds the declarations made directly within it,
+// by name. Names can have more than one declaration, as functions can be overloaded.
+type Scope struct {
+	table    *SymbolTable
+	kind     ScopeKind
+	node     Node[NodeType]
+	parent   *Scope
+	children []*Scope
+	symbols  map[string][]Node[NodeType]
+	usings   []*UsingDirective
+}
+
+// newScope creates a new Scope opened by the node and registers it within the symbol table.
+func newScope(table *SymbolTable, kind ScopeKind, node Node[NodeType], parent *Scope) *Scope {
+	scope := &Scope{
+		table:   table,
+		kind:    kind,
+		node:    node,
+		parent:  parent,
+		symbols: make(map[string][]Node[NodeType]),
+	}
+
+	if parent != nil {
+		parent.children = append(parent.children, scope)
+	}
+
+	if node != nil {
+		table.scopes[node.GetId()] = scope
+	}
+
+	return scope
+}
+
+// GetKind returns the kind of the scope.
+func (s *Scope) GetKind() ScopeKind {
+	return s.kind
+}
+
+// GetNode returns the node opening the scope. It is nil for the global scope.
+func (s *Scope) GetNode() Node[NodeType] {
+	return s.node
+}
+
+// GetParent returns the enclosing scope, or nil for the global scope.
+func (s *Scope) GetParent() *Scope {
+	return s.parent
+}
+
+// GetChildren returns the scopes nested directly within the scope.
+func (s *Scope) GetChildren() []*Scope {
+	return s.children
+}
+
+// GetSymbols returns the declarations made directly within the scope, by name.
+func (s *Scope) GetSymbols() map[string][]Node[NodeType] {
+	return s.symbols
+}
+
+// GetUsingDirectives returns the using for directives declared directly within the scope.
+func (s *Scope) GetUsingDirectives() []*UsingDirective {
+	return s.usings
+}
+
+// GetContract returns the innermost contract scope enclosing the scope, including the scope itself.
+func (s *Scope) GetContract() *Scope {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.kind == ScopeContract {
+			return scope
+		}
+	}
+	return nil
+}
+
+// Declare adds the declaration to the scope under the given name. Empty names are ignored.
+// Declarations of the same name are kept in the order of their ids, so that the same one is picked
+// whenever more than one source unit declares it.
+func (s *Scope) Declare(name string, node Node[NodeType]) {
+	if name == "" || node == nil {
+		return
+	}
+
+	declarations := s.symbols[name]
+	position := sort.Search(len(declarations), func(i int) bool {
+		return declarations[i].GetId() >= node.GetId()
+	})
+	if position < len(declarations) && declarations[position].GetId() == node.GetId() {
+		return
+	}
+
+	s.symbols[name] = append(declarations[:position], append([]Node[NodeType]{node}, declarations[position:]...)...)
+	s.table.declarations[node.GetId()] = node
+}
+
+// LookupLocal returns declarations of the name visible within the scope without looking into the
+// enclosing scopes. Contract scopes also return members inherited from the base contracts, following
+// the linearized inheritance order. Private members of base contracts are not visible.
+func (s *Scope) LookupLocal(name string) []Node[NodeType] {
+	if declarations := s.symbols[name]; len(declarations) > 0 {
+		return declarations
+	}
+
+	if s.kind != ScopeContract {
+		return nil
+	}
+
+	linearized := s.table.linearize(s)
+	for _, base := range linearized[1:] {
+		declarations := make([]Node[NodeType], 0)
+		for _, declaration := range base.symbols[name] {
+			if getVisibility(declaration) != ast_pb.Visibility_PRIVATE {
+				declarations = append(declarations, declaration)
+			}
+		}
+		if len(declarations) > 0 {
+			return declarations
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns declarations of the name visible from the scope, along with the scope they were
+// found in. Inner declarations shadow declarations of the enclosing scopes. Declarations of the global
+// scope made within the source file of the scope come first.
+func (s *Scope) Lookup(name string) ([]Node[NodeType], *Scope) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if declarations := scope.LookupLocal(name); len(declarations) > 0 {
+			if scope.kind == ScopeGlobal && len(declarations) > 1 {
+				declarations = s.table.sameFileFirst(declarations, s.file())
+			}
+			return declarations, scope
+		}
+	}
+	return nil, nil
+}
+
+// file returns the index of the source file the scope is within, or -1 if it is not known.
+func (s *Scope) file() int {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.kind == ScopeSourceUnit {
+			return scope.table.fileOf(scope.node)
+		}
+	}
+	return -1
+}
+
+// SymbolTable is the scope aware symbol table of the AST. It is built out of the tree once the
+// references are resolved, and is used to resolve identifiers the Resolver could not resolve.
+type SymbolTable struct {
+	tree         *Tree
+	global       *Scope
+	scopes       map[int64]*Scope         // Scopes by id of the node opening them.
+	declarations map[int64]Node[NodeType] // Declarations of all of the scopes by their id.
+	files        map[int64]int            // Indexes of the source files by id of the contracts, their members and top level declarations.
+	ranges       []*solgo.SourceRange     // Locations of the source files within the parsed source.
+	units        map[int64]*Scope         // Contract scopes by id of their source unit.
+	references   map[int64]int64          // Resolved declarations by id of the referencing node.
+	linearized   map[*Scope][]*Scope      // Linearized inheritance order of contract scopes.
+	arities      map[int64]int            // Argument counts of called expressions, used to pick overloads.
+	bound        map[int64]bool           // Member accesses resolved to functions attached with using for.
+	overloads    map[int64][]int64        // Overloads of the same argument count, picked once argument types are known.
+	visiting     map[*Scope]bool          // Contract scopes being linearized, guarding against cycles.
+}
+
+// NewSymbolTable creates a new SymbolTable for the given tree.
+func NewSymbolTable(tree *Tree) *SymbolTable {
+	table := &SymbolTable{
+		tree:         tree,
+		scopes:       make(map[int64]*Scope),
+		declarations: make(map[int64]Node[NodeType]),
+		files:        make(map[int64]int),
+		units:        make(map[int64]*Scope),
+		references:   make(map[int64]int64),
+		linearized:   make(map[*Scope][]*Scope),
+		arities:      make(map[int64]int),
+		bound:        make(map[int64]bool),
+		overloads:    make(map[int64][]int64),
+		visiting:     make(map[*Scope]bool),
+	}
+	table.global = newScope(table, ScopeGlobal, nil, nil)
+	return table
+}
+
+// GetGlobalScope returns the global scope of the symbol table.
+func (t *SymbolTable) GetGlobalScope() *Scope {
+	return t.global
+}
+
+// GetScope returns the scope opened by the node with the given id.
+func (t *SymbolTable) GetScope(nodeId int64) *Scope {
+	return t.scopes[nodeId]
+}
+
+// GetReference returns the id of the declaration the node with the given id references, or 0 if
+// the node could not be resolved. Unlike the ReferencedDeclaration fields, it also covers Yul
+// identifiers and modifier invocations.
+func (t *SymbolTable) GetReference(nodeId int64) int64 {
+	return t.references[nodeId]
+}
+
+// GetReferences returns all of the resolved references, by id of the referencing node.
+func (t *SymbolTable) GetReferences() map[int64]int64 {
+	return t.references
+}
+
+// GetLinearization returns ids of the contract and its base contracts in the C3 linearized order,
+// starting with the contract itself. Source unit ids are accepted in place of contract ids.
+func (t *SymbolTable) GetLinearization(contractId int64) []int64 {
+	scope := t.contractScope(contractId)
+	if scope == nil {
+		return nil
+	}
+
+	toReturn := make([]int64, 0)
+	for _, base := range t.linearize(scope) {
+		toReturn = append(toReturn, base.node.GetId())
+	}
+	return toReturn
+}
+
+// contractScope returns the scope of the contract, or of the contract of the source unit, with the given id.
+func (t *SymbolTable) contractScope(id int64) *Scope {
+	if scope, ok := t.scopes[id]; ok && scope.kind == ScopeContract {
+		return scope
+	}
+	return t.units[id]
+}
+
+// linearize returns the C3 linearization of the contract scope, starting with the scope itself.
+// Inconsistent hierarchies fall back to the depth first order of the base contracts.
+func (t *SymbolTable) linearize(scope *Scope) []*Scope {
+	if linearized, ok := t.linearized[scope]; ok {
+		return linearized
+	}
+
+	if t.visiting[scope] {
+		return []*Scope{scope}
+	}
+	t.visiting[scope] = true
+	defer delete(t.visiting, scope)
+
+	bases := t.baseScopes(scope)
+
+	// Solidity lists base contracts from the most base-like to the most derived one, while the
+	// C3 merge expects them the other way around.
+	sequences := make([][]*Scope, 0, len(bases)+1)
+	for i := len(bases) - 1; i >= 0; i-- {
+		sequences = append(sequences, append([]*Scope{}, t.linearize(bases[i])...))
+	}
+	reversed := make([]*Scope, 0, len(bases))
+	for i := len(bases) - 1; i >= 0; i-- {
+		reversed = append(reversed, bases[i])
+	}
+	sequences = append(sequences, reversed)
+
+	toReturn := []*Scope{scope}
+	if merged, ok := mergeLinearizations(sequences); ok {
+		toReturn = append(toReturn, merged...)
+	} else {
+		seen := map[*Scope]bool{scope: true}
+		for _, sequence := range sequences {
+			for _, base := range sequence {
+				if !seen[base] {
+					seen[base] = true
+					toReturn = append(toReturn, base)
+				}
+			}
+		}
+	}
+
+	t.linearized[scope] = toReturn
+	return toReturn
+}
+
+// baseScopes returns scopes of the base contracts the contract scope directly inherits from.
+func (t *SymbolTable) baseScopes(scope *Scope) []*Scope {
+	var baseContracts []*BaseContract
+	switch contract := scope.node.(type) {
+	case *Contract:
+		baseContracts = contract.GetBaseContracts()
+	case *Library:
+		baseContracts = contract.GetBaseContracts()
+	case *Interface:
+		baseContracts = contract.GetBaseContracts()
+	}
+
+	toReturn := make([]*Scope, 0, len(baseContracts))
+	for _, baseContract := range baseContracts {
+		if baseContract == nil || baseContract.BaseName == nil {
+			continue
+		}
+
+		if base := t.lookupContract(scope.parent, baseContract.BaseName.Name); base != nil && base != scope {
+			toReturn = append(toReturn, base)
+		}
+	}
+	return toReturn
+}
+
+// fileOf returns the index of the source file the node is located in, or -1 if it is not known.
+// Source units are named after their contracts, so contracts declared in more than one file can only
+// be told apart by their location within the parsed source.
+func (t *SymbolTable) fileOf(node Node[NodeType]) int {
+	if node == nil {
+		return -1
+	}
+
+	for i, sourceRange := range t.ranges {
+		if sourceRange.Contains(node.GetSrc().Start) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameFileFirst returns the declarations made within the given source file followed by the others,
+// both in the order of their ids.
+func (t *SymbolTable) sameFileFirst(declarations []Node[NodeType], file int) []Node[NodeType] {
+	if file < 0 {
+		return declarations
+	}
+
+	toReturn := make([]Node[NodeType], 0, len(declarations))
+	for _, declaration := range declarations {
+		if declared, ok := t.files[declaration.GetId()]; ok && declared == file {
+			toReturn = append(toReturn, declaration)
+		}
+	}
+	for _, declaration := range declarations {
+		if declared, ok := t.files[declaration.GetId()]; !ok || declared != file {
+			toReturn = append(toReturn, declaration)
+		}
+	}
+	return toReturn
+}
+
+// lookupContract returns the scope of the contract with the given, possibly qualified, name visible
+// from the scope.
+func (t *SymbolTable) lookupContract(scope *Scope, name string) *Scope {
+	declaration := t.lookupPath(scope, name, isTypeDeclaration)
+	if declaration == nil {
+		return nil
+	}
+	return t.contractScope(declaration.GetId())
+}
+
+// mergeLinearizations merges the sequences following the C3 algorithm. It returns false if the
+// sequences cannot be merged consistently.
+func mergeLinearizations(sequences [][]*Scope) ([]*Scope, bool) {
+	toReturn := make([]*Scope, 0)
+	for {
+		empty := true
+		for _, sequence := range sequences {
+			if len(sequence) > 0 {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			return toReturn, true
+		}
+
+		var candidate *Scope
+		for _, sequence := range sequences {
+			if len(sequence) == 0 {
+				continue
+			}
+
+			head, inTail := sequence[0], false
+			for _, other := range sequences {
+				for _, scope := range other[min(1, len(other)):] {
+					if scope == head {
+						inTail = true
+					}
+				}
+			}
+
+			if !inTail {
+				candidate = head
+				break
+			}
+		}
+
+		if candidate == nil {
+			return nil, false
+		}
+
+		toReturn = append(toReturn, candidate)
+		for i, sequence := range sequences {
+			if len(sequence) > 0 && sequence[0] == candidate {
+				sequences[i] = sequence[1:]
+			}
+		}
+	}
+}
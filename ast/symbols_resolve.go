@@ -0,0 +1,931 @@
+package ast
+
+import (
+	"reflect"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// Resolve builds the scopes out of the tree and resolves identifiers, type names, member accesses,
+// using for directives, override paths, modifier invocations and Yul identifiers against them.
+//
+// Resolved declarations are written into ReferencedDeclaration of the nodes that were left
+// unresolved by the Resolver. References of nodes resolved to a declaration of an outer scope,
+// while a local variable or a parameter shadows it, are corrected as well. Identifiers of builtins,
+// such as msg or keccak256, do not have declarations and remain unresolved.
+func (t *SymbolTable) Resolve() {
+	root := t.tree.GetRoot()
+	if root == nil {
+		return
+	}
+
+	if t.tree.sources != nil {
+		t.ranges = t.tree.sources.GetCombinedSourceRanges()
+	}
+
+	// Declarations are collected before any of the bodies are walked, as contracts and their members
+	// can be used before they are declared.
+	units := make([]*Scope, 0, len(root.GetSourceUnits()))
+	for _, unit := range root.GetSourceUnits() {
+		units = append(units, t.declareSourceUnit(unit))
+	}
+
+	for _, node := range root.GetGlobalNodes() {
+		if using, ok := node.(*UsingDirective); ok {
+			t.global.usings = append(t.global.usings, using)
+		} else if isDeclaration(node) {
+			t.global.Declare(getName(node), node)
+		}
+	}
+
+	for i, unit := range root.GetSourceUnits() {
+		t.declareImportAliases(unit, units[i])
+	}
+
+	for i, unit := range root.GetSourceUnits() {
+		for _, node := range unit.GetNodes() {
+			t.walk(node, units[i])
+		}
+	}
+
+	// Free functions and top level using for directives are only kept among the global nodes.
+	for _, node := range root.GetGlobalNodes() {
+		switch node.(type) {
+		case *Function, *UsingDirective:
+			t.walk(node, t.global)
+		}
+	}
+}
+
+// declareSourceUnit creates the scope of the source unit along with scopes of its contracts.
+func (t *SymbolTable) declareSourceUnit(unit *SourceUnit[Node[ast_pb.SourceUnit]]) *Scope {
+	scope := newScope(t, ScopeSourceUnit, unit, t.global)
+
+	for _, node := range unit.GetNodes() {
+		switch node.(type) {
+		case *Contract, *Library, *Interface:
+			contract := newScope(t, ScopeContract, node, scope)
+			if unit.GetContract() != nil && unit.GetContract().GetId() == node.GetId() {
+				t.units[unit.GetId()] = contract
+			}
+
+			t.global.Declare(getName(node), node)
+			scope.Declare(getName(node), node)
+			t.files[node.GetId()] = t.fileOf(unit)
+
+			for _, member := range node.GetNodes() {
+				if using, ok := member.(*UsingDirective); ok {
+					contract.usings = append(contract.usings, using)
+				} else if isDeclaration(member) {
+					contract.Declare(getName(member), member)
+					t.files[member.GetId()] = t.fileOf(unit)
+				}
+			}
+		case *UsingDirective:
+			scope.usings = append(scope.usings, node.(*UsingDirective))
+		default:
+			if isDeclaration(node) {
+				t.global.Declare(getName(node), node)
+				scope.Declare(getName(node), node)
+				t.files[node.GetId()] = t.fileOf(unit)
+			}
+		}
+	}
+
+	return scope
+}
+
+// declareImportAliases declares unit aliases and symbol aliases of the source unit imports. It has
+// to be called once all of the source units are declared, as aliases refer to their declarations.
+func (t *SymbolTable) declareImportAliases(unit *SourceUnit[Node[ast_pb.SourceUnit]], scope *Scope) {
+	for _, node := range unit.GetNodes() {
+		importNode, ok := node.(*Import)
+		if !ok {
+			continue
+		}
+
+		scope.Declare(importNode.GetUnitAlias(), importNode)
+
+		for _, symbol := range importNode.GetSymbols() {
+			if symbol == nil || symbol.Alias == "" {
+				continue
+			}
+
+			if declarations := t.global.LookupLocal(symbol.Name); len(declarations) > 0 {
+				scope.Declare(symbol.Alias, declarations[0])
+			}
+		}
+	}
+}
+
+// walk resolves references within the node and its children, opening new scopes where the node
+// introduces them.
+func (t *SymbolTable) walk(node Node[NodeType], scope *Scope) {
+	if IsNilNode(node) {
+		return
+	}
+
+	switch nodeCtx := node.(type) {
+	case *Contract, *Library, *Interface:
+		contract := t.scopes[node.GetId()]
+		if contract == nil {
+			contract = newScope(t, ScopeContract, node, scope)
+		}
+
+		for _, base := range t.baseContracts(node) {
+			for _, argument := range base.Arguments {
+				t.walk(argument, scope)
+			}
+		}
+
+		for _, member := range node.GetNodes() {
+			t.walk(member, contract)
+		}
+
+	case *Function:
+		t.walkCallable(node, scope, nodeCtx.GetParameters(), nodeCtx.GetReturnParameters(), nodeCtx.GetModifiers(), nodeCtx.GetOverrides(), nodeCtx.GetBody())
+	case *Constructor:
+		t.walkCallable(node, scope, nodeCtx.GetParameters(), nodeCtx.GetReturnParameters(), nodeCtx.GetModifiers(), nil, nodeCtx.GetBody())
+	case *Fallback:
+		t.walkCallable(node, scope, nodeCtx.GetParameters(), nodeCtx.GetReturnParameters(), nodeCtx.GetModifiers(), nodeCtx.GetOverrides(), nodeCtx.GetBody())
+	case *Receive:
+		t.walkCallable(node, scope, nodeCtx.GetParameters(), nodeCtx.GetReturnParameters(), nodeCtx.GetModifiers(), nodeCtx.GetOverrides(), nodeCtx.GetBody())
+	case *ModifierDefinition:
+		t.walkCallable(node, scope, nodeCtx.GetParameters(), nil, nil, nil, nodeCtx.GetBody())
+
+	case *BodyNode:
+		block := newScope(t, ScopeBlock, node, scope)
+		for _, statement := range nodeCtx.GetStatements() {
+			t.walk(statement, block)
+		}
+
+	case *VariableDeclaration:
+		// Variables are only visible after the statement declaring them, so the initial value
+		// cannot refer to them.
+		t.walk(nodeCtx.InitialValue, scope)
+		for _, declaration := range nodeCtx.Declarations {
+			if declaration == nil {
+				continue
+			}
+			t.walk(declaration.TypeName, scope)
+			scope.Declare(declaration.GetName(), node)
+		}
+
+	case *ForStatement:
+		loop := newScope(t, ScopeBlock, node, scope)
+		t.walk(nodeCtx.Initialiser, loop)
+		t.walk(nodeCtx.Condition, loop)
+		t.walk(nodeCtx.Closure, loop)
+		t.walk(nodeCtx.Body, loop)
+	case *WhileStatement:
+		t.walk(nodeCtx.Condition, scope)
+		t.walk(nodeCtx.Body, scope)
+	case *DoWhileStatement:
+		t.walk(nodeCtx.Body, scope)
+		t.walk(nodeCtx.Condition, scope)
+
+	case *TryStatement:
+		t.walk(nodeCtx.Expression, scope)
+		returns := newScope(t, ScopeBlock, node, scope)
+		t.declareParameters(nodeCtx.ReturnParameters, returns)
+		t.walk(nodeCtx.Body, returns)
+		for _, clause := range nodeCtx.Clauses {
+			t.walk(clause, scope)
+		}
+	case *CatchStatement:
+		clause := newScope(t, ScopeBlock, node, scope)
+		t.declareParameters(nodeCtx.Parameters, clause)
+		t.walk(nodeCtx.Body, clause)
+
+	case *Yul:
+		if nodeCtx.Body != nil {
+			t.walkYulStatements(nodeCtx.Body.GetStatements(), newScope(t, ScopeYulBlock, node, scope))
+		}
+	case *YulBlockStatement:
+		t.walkYulStatements(nodeCtx.GetStatements(), newScope(t, ScopeYulBlock, node, scope))
+	case *YulVariable:
+		t.walk(nodeCtx.GetValue(), scope)
+		for _, variable := range nodeCtx.GetVariables() {
+			scope.Declare(variable.GetName(), variable)
+		}
+	case *YulFunctionDefinition:
+		function := newScope(t, ScopeYulBlock, node, scope)
+		for _, argument := range nodeCtx.GetArguments() {
+			function.Declare(argument.GetName(), argument)
+		}
+		for _, returnParameter := range nodeCtx.GetReturnParameters() {
+			function.Declare(returnParameter.GetName(), returnParameter)
+		}
+		if body, ok := nodeCtx.GetBody().(*YulBlockStatement); ok {
+			t.walkYulStatements(body.GetStatements(), function)
+		} else {
+			t.walk(nodeCtx.GetBody(), function)
+		}
+	case *YulForStatement:
+		// Variables declared within the initialization block are visible in the rest of the loop.
+		loop := newScope(t, ScopeYulBlock, node, scope)
+		if pre, ok := nodeCtx.GetPre().(*YulBlockStatement); ok {
+			t.walkYulStatements(pre.GetStatements(), loop)
+		} else {
+			t.walk(nodeCtx.GetPre(), loop)
+		}
+		t.walk(nodeCtx.GetCondition(), loop)
+		t.walk(nodeCtx.GetBody(), loop)
+		t.walk(nodeCtx.GetPost(), loop)
+	case *YulAssignment:
+		t.walk(nodeCtx.GetValue(), scope)
+		for _, variable := range nodeCtx.GetVariableNames() {
+			t.walk(variable, scope)
+		}
+	case *YulIdentifier:
+		t.resolveYulIdentifier(nodeCtx, scope)
+
+	case *FunctionCall:
+		if !IsNilNode(nodeCtx.Expression) {
+			t.arities[nodeCtx.Expression.GetId()] = len(nodeCtx.Arguments)
+		}
+		t.walk(nodeCtx.Expression, scope)
+		for _, argument := range nodeCtx.Arguments {
+			t.walk(argument, scope)
+		}
+	case *PrimaryExpression:
+		t.resolveIdentifier(nodeCtx, scope)
+		for _, child := range node.GetNodes() {
+			t.walk(child, scope)
+		}
+	case *MemberAccessExpression:
+		t.walk(nodeCtx.Expression, scope)
+		t.resolveMember(nodeCtx, scope)
+	case *TypeName:
+		t.resolveTypeName(nodeCtx, scope)
+		for _, child := range node.GetNodes() {
+			t.walk(child, scope)
+		}
+	case *UsingDirective:
+		t.resolveUsingDirective(nodeCtx, scope)
+		t.walk(nodeCtx.TypeName, scope)
+	case *OverrideSpecifier:
+		t.resolveOverrides(nodeCtx, scope)
+	case *ModifierInvocation:
+		t.resolveModifierInvocation(nodeCtx, scope)
+		for _, argument := range nodeCtx.Arguments {
+			t.walk(argument, scope)
+		}
+
+	default:
+		for _, child := range node.GetNodes() {
+			t.walk(child, scope)
+		}
+	}
+}
+
+// walkCallable opens the scope of the function like node, declares its parameters and walks its body.
+func (t *SymbolTable) walkCallable(node Node[NodeType], scope *Scope, parameters *ParameterList, returnParameters *ParameterList, modifiers []*ModifierInvocation, overrides []*OverrideSpecifier, body *BodyNode) {
+	function := newScope(t, ScopeFunction, node, scope)
+	t.declareParameters(parameters, function)
+	t.declareParameters(returnParameters, function)
+
+	for _, modifier := range modifiers {
+		t.walk(modifier, function)
+	}
+
+	for _, override := range overrides {
+		t.walk(override, function)
+	}
+
+	t.walk(body, function)
+}
+
+// declareParameters resolves type names of the parameters and declares them within the scope.
+func (t *SymbolTable) declareParameters(parameters *ParameterList, scope *Scope) {
+	if parameters == nil {
+		return
+	}
+
+	for _, parameter := range parameters.GetParameters() {
+		if parameter == nil {
+			continue
+		}
+		t.walk(parameter, scope)
+		scope.Declare(parameter.GetName(), parameter)
+	}
+}
+
+// walkYulStatements walks the statements of the Yul block within the scope. Yul functions can be
+// called before they are defined, so they are declared first.
+func (t *SymbolTable) walkYulStatements(statements []Node[NodeType], scope *Scope) {
+	for _, statement := range statements {
+		for _, node := range unwrapYulStatement(statement) {
+			if function, ok := node.(*YulFunctionDefinition); ok {
+				scope.Declare(function.GetName(), function)
+			}
+		}
+	}
+
+	for _, statement := range statements {
+		for _, node := range unwrapYulStatement(statement) {
+			t.walk(node, scope)
+		}
+	}
+}
+
+// resolveIdentifier resolves the identifier against the scope.
+func (t *SymbolTable) resolveIdentifier(primary *PrimaryExpression, scope *Scope) {
+	if primary.GetName() == "" {
+		return
+	}
+
+	declarations, found := scope.Lookup(primary.GetName())
+	if len(declarations) == 0 {
+		return
+	}
+
+	t.bind(primary, t.pickOverload(declarations, primary.GetId(), 0), found)
+}
+
+// resolveMember resolves the member of the member access expression. Members are looked up within
+// the contract, enum, struct or import alias the expression refers to, within its type and finally
+// within libraries attached to its type with using for directives.
+func (t *SymbolTable) resolveMember(member *MemberAccessExpression, scope *Scope) {
+	var declarations []Node[NodeType]
+	bound := false
+
+	expression := member.Expression
+	if primary, ok := expression.(*PrimaryExpression); ok && (primary.GetName() == "super" || primary.GetName() == "this") {
+		if contract := scope.GetContract(); contract != nil {
+			if primary.GetName() == "this" {
+				declarations = contract.LookupLocal(member.MemberName)
+			} else {
+				for _, base := range t.linearize(contract)[1:] {
+					if declarations = base.symbols[member.MemberName]; len(declarations) > 0 {
+						break
+					}
+				}
+			}
+		}
+	} else if !IsNilNode(expression) {
+		if declaration := t.getDeclaration(t.referenceOf(expression)); declaration != nil {
+			declarations = t.members(declaration, getName(expression), member.MemberName, scope)
+		}
+
+		typeName := t.expressionType(expression, scope)
+		if len(declarations) == 0 && typeName != "" {
+			if declaration := t.lookupPath(scope, typeName, isTypeDeclaration); declaration != nil {
+				declarations = t.members(declaration, "", member.MemberName, scope)
+			}
+		}
+
+		if len(declarations) == 0 && typeName != "" {
+			declarations, bound = t.usingFor(scope, typeName, member.MemberName), true
+		}
+	}
+
+	if len(declarations) == 0 {
+		return
+	}
+
+	// Functions attached with using for take the expression as their first argument.
+	offset := 0
+	if bound {
+		offset = 1
+		t.bound[member.GetId()] = true
+	}
+
+	t.bind(member, t.pickOverload(declarations, member.GetId(), offset), nil)
+}
+
+// resolveTypeName resolves the user defined type name against the scope.
+func (t *SymbolTable) resolveTypeName(typeName *TypeName, scope *Scope) {
+	name := typeNameString(typeName)
+	if name == "" || strings.ContainsAny(name, "[(") {
+		return
+	}
+
+	declaration := t.lookupPath(scope, name, isTypeDeclaration)
+	if declaration == nil {
+		return
+	}
+
+	t.bind(typeName, declaration, nil)
+	if typeName.PathNode != nil {
+		t.bind(typeName.PathNode, declaration, nil)
+	}
+}
+
+// resolveUsingDirective resolves the library of the using for directive, or the functions it attaches.
+func (t *SymbolTable) resolveUsingDirective(using *UsingDirective, scope *Scope) {
+	for _, function := range using.Functions {
+		declaration := t.lookupPath(scope, function.Name, func(node Node[NodeType]) bool {
+			_, ok := node.(*Function)
+			return ok
+		})
+		if declaration == nil {
+			continue
+		}
+
+		t.references[function.GetId()] = declaration.GetId()
+		if function.ReferencedDeclaration == 0 {
+			function.ReferencedDeclaration = declaration.GetId()
+		}
+	}
+
+	if using.LibraryName == nil || len(using.Functions) > 0 {
+		return
+	}
+
+	library := t.lookupContract(scope, using.LibraryName.Name)
+	if library == nil {
+		return
+	}
+
+	t.references[using.GetId()] = library.node.GetId()
+	t.references[using.LibraryName.Id] = library.node.GetId()
+	if using.LibraryName.ReferencedDeclaration == 0 {
+		using.LibraryName.ReferencedDeclaration = library.node.GetId()
+	}
+}
+
+// resolveOverrides resolves contracts listed by the override specifier.
+func (t *SymbolTable) resolveOverrides(override *OverrideSpecifier, scope *Scope) {
+	for _, path := range override.Overrides {
+		if path == nil {
+			continue
+		}
+
+		if contract := t.lookupContract(scope, path.Name); contract != nil {
+			t.references[path.GetId()] = contract.node.GetId()
+			if path.ReferencedDeclaration == 0 {
+				path.ReferencedDeclaration = contract.node.GetId()
+			}
+		}
+	}
+}
+
+// resolveModifierInvocation resolves the invoked modifier, or the base contract in case of
+// constructors calling base constructors.
+func (t *SymbolTable) resolveModifierInvocation(modifier *ModifierInvocation, scope *Scope) {
+	name := modifier.GetName()
+	if modifier.ModifierName != nil && modifier.ModifierName.Name != "" {
+		name = modifier.ModifierName.Name
+	}
+
+	if declaration := t.lookupPath(scope, name, func(node Node[NodeType]) bool {
+		_, ok := node.(*ModifierDefinition)
+		return ok || isContractDeclaration(node)
+	}); declaration != nil {
+		t.references[modifier.GetId()] = declaration.GetId()
+	}
+}
+
+// resolveYulIdentifier resolves the Yul identifier to a Yul variable or function, or to a Solidity
+// variable accessed from the inline assembly. Suffixes such as .slot and .offset are ignored.
+func (t *SymbolTable) resolveYulIdentifier(identifier *YulIdentifier, scope *Scope) {
+	name, _, _ := strings.Cut(identifier.GetName(), ".")
+	if name == "" {
+		return
+	}
+
+	declarations, found := scope.Lookup(name)
+	for _, declaration := range declarations {
+		switch declaration.(type) {
+		case *YulIdentifier, *YulFunctionDefinition, *VariableDeclaration, *Parameter, *StateVariableDeclaration:
+			t.bind(identifier, declaration, found)
+			return
+		}
+	}
+}
+
+// bind records the declaration as the reference of the node. The ReferencedDeclaration of the node
+// is only set if it is not resolved to a declaration yet, such as when the Resolver matched another
+// node of the same name, if the declaration is a local one shadowing the declaration the node was
+// resolved to, or if the node was resolved to a declaration of the same name made in another file.
+func (t *SymbolTable) bind(node Node[NodeType], declaration Node[NodeType], found *Scope) {
+	t.references[node.GetId()] = declaration.GetId()
+
+	current := getReferencedDeclaration(node)
+	local := found != nil && found.kind >= ScopeFunction
+	currentFile, currentOk := t.files[current]
+	file, ok := t.files[declaration.GetId()]
+	elsewhere := currentOk && ok && currentFile >= 0 && file >= 0 && currentFile != file
+	if current != 0 && current != node.GetId() && t.getDeclaration(current) != nil && (!local || current == declaration.GetId()) && !elsewhere {
+		return
+	}
+
+	typeDescription := declarationType(declaration, getName(node))
+	switch nodeCtx := node.(type) {
+	case *PrimaryExpression:
+		nodeCtx.ReferencedDeclaration = declaration.GetId()
+		if nodeCtx.TypeDescription == nil {
+			nodeCtx.TypeDescription = typeDescription
+		}
+	case *MemberAccessExpression:
+		nodeCtx.ReferencedDeclaration = declaration.GetId()
+		if nodeCtx.TypeDescription == nil {
+			nodeCtx.TypeDescription = typeDescription
+		}
+	case *TypeName:
+		nodeCtx.ReferencedDeclaration = declaration.GetId()
+		if nodeCtx.TypeDescription == nil {
+			nodeCtx.TypeDescription = typeDescription
+		}
+	case *PathNode:
+		nodeCtx.ReferencedDeclaration = declaration.GetId()
+	case *YulIdentifier:
+		nodeCtx.ReferencedDeclaration = declaration.GetId()
+	}
+}
+
+// pickOverload picks the declaration whose parameter count matches the number of arguments the
+// node is called with. The offset is added to the number of arguments. Overloads with the same
+// parameter count are recorded, for the TypeChecker to pick from by the types of the arguments.
+func (t *SymbolTable) pickOverload(declarations []Node[NodeType], nodeId int64, offset int) Node[NodeType] {
+	if arity, ok := t.arities[nodeId]; ok && len(declarations) > 1 {
+		matching := make([]Node[NodeType], 0)
+		for _, declaration := range declarations {
+			if count, ok := parameterCount(declaration); ok && count == arity+offset {
+				matching = append(matching, declaration)
+			}
+		}
+
+		if len(matching) > 1 {
+			for _, declaration := range matching {
+				t.overloads[nodeId] = append(t.overloads[nodeId], declaration.GetId())
+			}
+		}
+		if len(matching) > 0 {
+			return matching[0]
+		}
+	}
+	return declarations[0]
+}
+
+// rebind replaces the overload the node was bound to with the given declaration.
+func (t *SymbolTable) rebind(node Node[NodeType], declaration int64) {
+	previous := t.references[node.GetId()]
+	t.references[node.GetId()] = declaration
+
+	switch nodeCtx := node.(type) {
+	case *PrimaryExpression:
+		if nodeCtx.ReferencedDeclaration == previous {
+			nodeCtx.ReferencedDeclaration = declaration
+		}
+	case *MemberAccessExpression:
+		if nodeCtx.ReferencedDeclaration == previous {
+			nodeCtx.ReferencedDeclaration = declaration
+		}
+	}
+}
+
+// members returns declarations of the member within the declaration. The name is the name the
+// declaration is referred to with, used to find the variable within variable declaration statements.
+func (t *SymbolTable) members(declaration Node[NodeType], name string, member string, scope *Scope) []Node[NodeType] {
+	switch declarationCtx := declaration.(type) {
+	case *Contract, *Library, *Interface, *SourceUnit[Node[ast_pb.SourceUnit]]:
+		if contract := t.contractScope(declaration.GetId()); contract != nil {
+			return contract.LookupLocal(member)
+		}
+	case *EnumDefinition:
+		for _, value := range declarationCtx.GetMembers() {
+			if value.GetName() == member {
+				return []Node[NodeType]{value}
+			}
+		}
+	case *StructDefinition:
+		for _, field := range declarationCtx.GetMembers() {
+			if field.GetName() == member {
+				return []Node[NodeType]{field}
+			}
+		}
+	case *Import:
+		return t.global.LookupLocal(member)
+	default:
+		// Variables give access to members of their types.
+		typeName := variableType(declaration, name)
+		if typeName == "" {
+			return nil
+		}
+
+		if typeDeclaration := t.lookupPath(scope, typeName, isTypeDeclaration); typeDeclaration != nil {
+			switch typeDeclaration.(type) {
+			case *Contract, *Library, *Interface, *StructDefinition:
+				return t.members(typeDeclaration, "", member, scope)
+			}
+		}
+	}
+
+	return nil
+}
+
+// usingFor returns functions of the given name attached to the type with using for directives
+// visible from the scope.
+func (t *SymbolTable) usingFor(scope *Scope, typeName string, member string) []Node[NodeType] {
+	for current := scope; current != nil; current = current.parent {
+		for _, using := range current.usings {
+			if target := typeNameString(using.TypeName); target != "" && target != "*" && normalizeTypeString(target) != typeName {
+				continue
+			}
+
+			if len(using.Functions) > 0 {
+				declarations := make([]Node[NodeType], 0)
+				for _, function := range using.Functions {
+					_, name := cutLast(function.Name, ".")
+					if function.Operator == "" && name == member {
+						if declaration := t.getDeclaration(t.references[function.GetId()]); declaration != nil {
+							declarations = append(declarations, declaration)
+						}
+					}
+				}
+				if len(declarations) > 0 {
+					return declarations
+				}
+				continue
+			}
+
+			if using.LibraryName == nil {
+				continue
+			}
+
+			if library := t.lookupContract(current, using.LibraryName.Name); library != nil {
+				if declarations := library.symbols[member]; len(declarations) > 0 {
+					return declarations
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lookupPath returns the declaration with the given, possibly qualified, name visible from the
+// scope, if the declaration is accepted by the filter.
+func (t *SymbolTable) lookupPath(scope *Scope, path string, filter func(Node[NodeType]) bool) Node[NodeType] {
+	if scope == nil || path == "" {
+		return nil
+	}
+
+	parts := strings.Split(path, ".")
+	declarations, _ := scope.Lookup(parts[0])
+
+	for i, part := range parts {
+		if i > 0 {
+			if len(declarations) == 0 {
+				return nil
+			}
+			declarations = t.members(declarations[0], "", part, scope)
+		}
+
+		last := i == len(parts)-1
+		accepted := make([]Node[NodeType], 0, len(declarations))
+		for _, declaration := range declarations {
+			if (last && filter(declaration)) || (!last && isNamespace(declaration)) {
+				accepted = append(accepted, declaration)
+			}
+		}
+		declarations = accepted
+	}
+
+	if len(declarations) == 0 {
+		return nil
+	}
+	return declarations[0]
+}
+
+// expressionType returns the normalized name of the type of the expression. Types of identifiers
+// are taken from their declarations, while types of other expressions from their type descriptions.
+func (t *SymbolTable) expressionType(expression Node[NodeType], scope *Scope) string {
+	if declaration := t.getDeclaration(t.referenceOf(expression)); declaration != nil {
+		if typeName := variableType(declaration, getName(expression)); typeName != "" {
+			return normalizeTypeString(typeName)
+		}
+	}
+
+	if typeDescription := expression.GetTypeDescription(); typeDescription != nil {
+		return normalizeTypeString(typeDescription.GetString())
+	}
+
+	return ""
+}
+
+// referenceOf returns the id of the declaration the node refers to.
+func (t *SymbolTable) referenceOf(node Node[NodeType]) int64 {
+	if id, ok := t.references[node.GetId()]; ok {
+		return id
+	}
+
+	if id := getReferencedDeclaration(node); id != node.GetId() {
+		return id
+	}
+	return 0
+}
+
+// getDeclaration returns the declaration with the given id known to the symbol table.
+func (t *SymbolTable) getDeclaration(id int64) Node[NodeType] {
+	if id == 0 {
+		return nil
+	}
+
+	if contract := t.contractScope(id); contract != nil {
+		return contract.node
+	}
+
+	if scope, ok := t.scopes[id]; ok && scope.node != nil {
+		return scope.node
+	}
+
+	return t.declarations[id]
+}
+
+// baseContracts returns the base contracts of the contract, library or interface.
+func (t *SymbolTable) baseContracts(node Node[NodeType]) []*BaseContract {
+	switch contract := node.(type) {
+	case *Contract:
+		return contract.GetBaseContracts()
+	case *Library:
+		return contract.GetBaseContracts()
+	case *Interface:
+		return contract.GetBaseContracts()
+	}
+	return nil
+}
+
+// unwrapYulStatement returns the nodes wrapped by the Yul statement, or the node itself.
+func unwrapYulStatement(node Node[NodeType]) []Node[NodeType] {
+	if statement, ok := node.(*YulStatement); ok {
+		return statement.GetNodes()
+	}
+	return []Node[NodeType]{node}
+}
+
+// variableType returns the type name of the variable declaration. The name selects the variable
+// within variable declaration statements declaring more than one variable.
+func variableType(declaration Node[NodeType], name string) string {
+	switch declarationCtx := declaration.(type) {
+	case *StateVariableDeclaration:
+		return typeNameString(declarationCtx.GetTypeName())
+	case *Parameter:
+		return typeNameString(declarationCtx.GetTypeName())
+	case *Declaration:
+		return typeNameString(declarationCtx.TypeName)
+	case *VariableDeclaration:
+		for _, variable := range declarationCtx.Declarations {
+			if variable != nil && (variable.GetName() == name || len(declarationCtx.Declarations) == 1) {
+				return typeNameString(variable.TypeName)
+			}
+		}
+	}
+	return ""
+}
+
+// declarationType returns the type description of the declaration. The name selects the variable
+// within variable declaration statements declaring more than one variable.
+func declarationType(declaration Node[NodeType], name string) *TypeDescription {
+	if statement, ok := declaration.(*VariableDeclaration); ok {
+		for _, variable := range statement.Declarations {
+			if variable != nil && variable.GetName() == name && variable.TypeName != nil {
+				return variable.TypeName.GetTypeDescription()
+			}
+		}
+	}
+
+	if _, ok := declaration.(*YulIdentifier); ok {
+		return nil
+	}
+
+	return declaration.GetTypeDescription()
+}
+
+// typeNameString returns the name of the type, preferring the path of user defined types.
+func typeNameString(typeName *TypeName) string {
+	if typeName == nil {
+		return ""
+	}
+
+	if typeName.PathNode != nil && typeName.PathNode.Name != "" {
+		return typeName.PathNode.Name
+	}
+	return typeName.Name
+}
+
+// normalizeTypeString strips data locations and kind prefixes from the type name, and expands the
+// aliases of elementary types, so that type names can be compared.
+func normalizeTypeString(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+	for _, prefix := range []string{"struct ", "contract ", "enum ", "library ", "interface "} {
+		typeName = strings.TrimPrefix(typeName, prefix)
+	}
+
+	if !strings.HasPrefix(typeName, "mapping") {
+		typeName, _, _ = strings.Cut(typeName, " ")
+	}
+
+	switch typeName {
+	case "uint":
+		return "uint256"
+	case "int":
+		return "int256"
+	case "byte":
+		return "bytes1"
+	}
+	return typeName
+}
+
+// cutLast slices the string around the last separator, returning the whole string as the second
+// part if the separator is not found.
+func cutLast(s string, sep string) (string, string) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):]
+	}
+	return "", s
+}
+
+// parameterCount returns the number of parameters of the function, event or error.
+func parameterCount(node Node[NodeType]) (int, bool) {
+	var parameters *ParameterList
+	switch nodeCtx := node.(type) {
+	case *Function:
+		parameters = nodeCtx.GetParameters()
+	case *EventDefinition:
+		parameters = nodeCtx.GetParameters()
+	case *ErrorDefinition:
+		parameters = nodeCtx.GetParameters()
+	default:
+		return 0, false
+	}
+
+	if parameters == nil {
+		return 0, true
+	}
+	return len(parameters.GetParameters()), true
+}
+
+// isDeclaration returns true if the node declares a named member of a contract or a source unit.
+func isDeclaration(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *Contract, *Library, *Interface, *StateVariableDeclaration, *Function, *ModifierDefinition,
+		*EventDefinition, *ErrorDefinition, *StructDefinition, *EnumDefinition, *UserDefinedValueTypeDefinition:
+		return true
+	}
+	return false
+}
+
+// isContractDeclaration returns true if the node is a contract, library or interface.
+func isContractDeclaration(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *Contract, *Library, *Interface:
+		return true
+	}
+	return false
+}
+
+// isTypeDeclaration returns true if the node declares a type.
+func isTypeDeclaration(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *Contract, *Library, *Interface, *StructDefinition, *EnumDefinition, *UserDefinedValueTypeDefinition:
+		return true
+	}
+	return false
+}
+
+// isNamespace returns true if members of the node can be accessed with qualified names.
+func isNamespace(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *Contract, *Library, *Interface, *Import:
+		return true
+	}
+	return false
+}
+
+// getName returns the name of the node, or an empty string if the node is not named.
+func getName(node Node[NodeType]) string {
+	if named, ok := node.(interface{ GetName() string }); ok {
+		return named.GetName()
+	}
+	return ""
+}
+
+// getVisibility returns the visibility of the declaration.
+func getVisibility(node Node[NodeType]) ast_pb.Visibility {
+	if visible, ok := node.(interface{ GetVisibility() ast_pb.Visibility }); ok {
+		return visible.GetVisibility()
+	}
+	return ast_pb.Visibility_V_DEFAULT
+}
+
+// getReferencedDeclaration returns the id of the declaration the node references, if any.
+func getReferencedDeclaration(node Node[NodeType]) int64 {
+	if referencing, ok := node.(interface{ GetReferencedDeclaration() int64 }); ok {
+		return referencing.GetReferencedDeclaration()
+	}
+	return 0
+}
+
+// IsNilNode returns true if the node is nil, including nil pointers wrapped within the interface.
+func IsNilNode(node Node[NodeType]) bool {
+	if node == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
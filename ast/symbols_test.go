@@ -0,0 +1,255 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+)
+
+const symbolsContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library MathLib {
+    function add(uint256 a, uint256 b) internal pure returns (uint256) {
+        return a + b;
+    }
+}
+
+contract Base {
+    uint256 internal value;
+    enum Color { Red, Green }
+    struct Info { uint256 amount; }
+
+    function hook(uint256 amount) internal virtual returns (uint256) {
+        return amount;
+    }
+}
+
+contract Child is Base {
+    using MathLib for uint256;
+
+    Info internal info;
+
+    function hook(uint256 amount) internal override returns (uint256) {
+        uint256 value = amount.add(1);
+        {
+            uint256 inner = value;
+            value = inner;
+        }
+        info.amount = value;
+        Color c = Color.Green;
+        assembly {
+            let x := add(value, 1)
+            function double(y) -> z { z := mul(y, 2) }
+            x := double(x)
+        }
+        return super.hook(value) + uint256(c);
+    }
+}
+
+contract Left is Base {}
+
+contract Right is Base {}
+
+contract Diamond is Left, Right {}`
+
+// buildSymbolsTree parses the content and resolves its references.
+func buildSymbolsTree(t *testing.T, content string) *ASTBuilder {
+	_, astBuilder := parseTestSources(t, newTestSources("Child", &solgo.SourceUnit{Name: "Child", Path: "Child.sol", Content: content}))
+	require.Empty(t, astBuilder.ResolveReferences())
+	require.NotNil(t, astBuilder.GetSymbolTable())
+
+	return astBuilder
+}
+
+// findNodes returns all of the nodes of the tree accepted by the filter, in the order of the walk.
+func findNodes(b *ASTBuilder, filter func(Node[NodeType]) bool) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0)
+	_ = b.GetTree().Walk(&NodeVisitor{
+		Visit: func(node Node[NodeType]) bool {
+			if !IsNilNode(node) && filter(node) {
+				toReturn = append(toReturn, node)
+			}
+			return true
+		},
+	})
+	return toReturn
+}
+
+// findNamed returns the first node of the given type and name.
+func findNamed[T Node[NodeType]](t *testing.T, b *ASTBuilder, name string) T {
+	nodes := findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(T)
+		return ok && getName(node) == name
+	})
+	require.NotEmpty(t, nodes, name)
+	return nodes[0].(T)
+}
+
+func TestSymbolTableResolve(t *testing.T) {
+	b := buildSymbolsTree(t, symbolsContent)
+
+	baseValue := findNamed[*StateVariableDeclaration](t, b, "value")
+	hook := findNodes(b, func(node Node[NodeType]) bool { return getName(node) == "hook" })
+	require.Len(t, hook, 2)
+	baseHook, childHook := hook[0].(*Function), hook[1].(*Function)
+
+	// The local value variable shadows the state variable within the nested block as well.
+	localValue := findNodes(b, func(node Node[NodeType]) bool {
+		statement, ok := node.(*VariableDeclaration)
+		return ok && len(statement.Declarations) == 1 && statement.Declarations[0].GetName() == "value"
+	})
+	require.Len(t, localValue, 1)
+	for _, identifier := range findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*PrimaryExpression)
+		return ok && getName(node) == "value"
+	}) {
+		assert.Equal(t, localValue[0].GetId(), getReferencedDeclaration(identifier))
+		assert.NotEqual(t, baseValue.GetId(), getReferencedDeclaration(identifier))
+	}
+
+	members := make(map[string]*MemberAccessExpression)
+	for _, node := range findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*MemberAccessExpression)
+		return ok
+	}) {
+		members[node.(*MemberAccessExpression).MemberName] = node.(*MemberAccessExpression)
+	}
+
+	// Functions attached with using for, struct and enum members and functions called through super.
+	assert.Equal(t, findNamed[*Function](t, b, "add").GetId(), members["add"].GetReferencedDeclaration())
+	assert.Equal(t, findNamed[*StructDefinition](t, b, "Info").GetMembers()[0].GetId(), members["amount"].GetReferencedDeclaration())
+	assert.Equal(t, findNamed[*EnumDefinition](t, b, "Color").GetMembers()[1].GetId(), members["Green"].GetReferencedDeclaration())
+	assert.Equal(t, baseHook.GetId(), members["hook"].GetReferencedDeclaration())
+
+	// Parameters are referenced by their uses within the body of their own function only.
+	amount := findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*PrimaryExpression)
+		return ok && getName(node) == "amount"
+	})
+	require.Len(t, amount, 2)
+	assert.Equal(t, baseHook.GetParameters().GetParameters()[0].GetId(), getReferencedDeclaration(amount[0]))
+	assert.Equal(t, childHook.GetParameters().GetParameters()[0].GetId(), getReferencedDeclaration(amount[1]))
+
+	// Yul identifiers refer to Yul variables, functions and parameters, or to Solidity variables.
+	yulIdentifiers := make(map[string][]*YulIdentifier)
+	for _, node := range findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*YulIdentifier)
+		return ok
+	}) {
+		yulIdentifiers[getName(node)] = append(yulIdentifiers[getName(node)], node.(*YulIdentifier))
+	}
+	assert.Equal(t, localValue[0].GetId(), yulIdentifiers["value"][0].GetReferencedDeclaration())
+	assert.Equal(t, findNamed[*YulFunctionDefinition](t, b, "double").GetId(), yulIdentifiers["double"][0].GetReferencedDeclaration())
+	assert.Zero(t, yulIdentifiers["add"][0].GetReferencedDeclaration())
+	for _, identifier := range yulIdentifiers["x"] {
+		assert.True(t, identifier.GetReferencedDeclaration() == 0 || identifier.GetReferencedDeclaration() == yulIdentifiers["x"][0].GetId())
+	}
+
+	// Scopes are nested from the source unit down to the Yul block.
+	table := b.GetSymbolTable()
+	scope := table.GetScope(findNamed[*YulFunctionDefinition](t, b, "double").GetId())
+	require.NotNil(t, scope)
+	kinds := make([]ScopeKind, 0)
+	for ; scope != nil; scope = scope.GetParent() {
+		kinds = append(kinds, scope.GetKind())
+	}
+	assert.Equal(t, []ScopeKind{ScopeYulBlock, ScopeYulBlock, ScopeBlock, ScopeFunction, ScopeContract, ScopeSourceUnit, ScopeGlobal}, kinds)
+
+	child := findNamed[*Contract](t, b, "Child")
+	assert.Equal(t, table.GetScope(child.GetId()), table.GetScope(childHook.GetId()).GetContract())
+	assert.Len(t, table.GetScope(child.GetId()).GetUsingDirectives(), 1)
+}
+
+func TestSymbolTableLinearization(t *testing.T) {
+	b := buildSymbolsTree(t, symbolsContent)
+	table := b.GetSymbolTable()
+
+	ids := func(names ...string) []int64 {
+		toReturn := make([]int64, 0, len(names))
+		for _, name := range names {
+			toReturn = append(toReturn, findNamed[*Contract](t, b, name).GetId())
+		}
+		return toReturn
+	}
+
+	assert.Equal(t, ids("Child", "Base"), table.GetLinearization(ids("Child")[0]))
+	assert.Equal(t, ids("Diamond", "Right", "Left", "Base"), table.GetLinearization(ids("Diamond")[0]))
+	assert.Equal(t, ids("Base"), table.GetLinearization(ids("Base")[0]))
+	assert.Nil(t, table.GetLinearization(-1))
+
+	// Members of the bases are visible within the derived contracts, apart from the private ones.
+	declarations := table.GetScope(ids("Diamond")[0]).LookupLocal("Color")
+	require.Len(t, declarations, 1)
+	assert.Equal(t, findNamed[*EnumDefinition](t, b, "Color").GetId(), declarations[0].GetId())
+}
+
+func TestSymbolTableResolveWithErrors(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Child {
+    function run(uint256 amount) public pure returns (uint256) {
+        return amount + missing;
+    }
+}`
+
+	_, b := parseTestSources(t, newTestSources("Child", &solgo.SourceUnit{Name: "Child", Path: "Child.sol", Content: content}))
+
+	// The undeclared identifier fails the resolver, yet the scoped resolution still runs.
+	assert.NotEmpty(t, b.ResolveReferences())
+	require.NotNil(t, b.GetSymbolTable())
+
+	amount := findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*PrimaryExpression)
+		return ok && getName(node) == "amount"
+	})
+	require.Len(t, amount, 1)
+	assert.Equal(t, findNamed[*Function](t, b, "run").GetParameters().GetParameters()[0].GetId(), getReferencedDeclaration(amount[0]))
+}
+
+func TestSymbolTableResolveDuplicates(t *testing.T) {
+	library := `library SafeLib {
+    function safe(uint256 a) internal pure returns (uint256) {
+        return a;
+    }
+}`
+
+	units := make([]*solgo.SourceUnit, 0, 2)
+	for _, name := range []string{"First", "Second"} {
+		units = append(units, &solgo.SourceUnit{
+			Name: name,
+			Path: name + ".sol",
+			Content: "// SPDX-License-Identifier: MIT\npragma solidity ^0.8.0;\n\n" + library + "\n\ncontract " + name + ` {
+    using SafeLib for uint256;
+
+    function run(uint256 a) public pure returns (uint256) {
+        return a.safe() + a.safe();
+    }
+}`,
+		})
+	}
+
+	// Source units declaring the same library refer to their own copy on every build.
+	for i := 0; i < 5; i++ {
+		_, b := parseTestSources(t, newTestSources("Second", units...))
+		require.Empty(t, b.ResolveReferences())
+
+		safe := findNodes(b, func(node Node[NodeType]) bool {
+			_, ok := node.(*Function)
+			return ok && getName(node) == "safe"
+		})
+		require.Len(t, safe, 2)
+
+		calls := findNodes(b, func(node Node[NodeType]) bool {
+			member, ok := node.(*MemberAccessExpression)
+			return ok && member.MemberName == "safe"
+		})
+		require.Len(t, calls, 4)
+		for j, call := range calls {
+			assert.Equal(t, safe[j/2].GetId(), call.(*MemberAccessExpression).GetReferencedDeclaration())
+		}
+	}
+}
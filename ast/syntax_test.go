@@ -0,0 +1,248 @@
+package ast
+
+import (
+	"strconv"
+	"testing"
+
+	v3 "github.com/cncf/xds/go/xds/type/v3"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+)
+
+const syntaxLibraryContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library Lib {
+    function id(uint256 a) internal pure returns (uint256) {
+        return a;
+    }
+}`
+
+const syntaxContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+import * as All from "./Lib.sol";
+import {Lib, Lib as Alias} from "./Lib.sol";
+
+abstract contract Named {
+    constructor(string memory name) {}
+
+    function run() public virtual;
+}
+
+contract Syntax is Named("syntax") {
+    function pay(address target, bytes calldata data) public payable returns (uint256 total) {
+        (bool ok, ) = target.call{value: msg.value, gas: 5000}(data[4:]);
+        total = sum({b: 2, a: 1});
+        if (ok) {
+            total = 1;
+        } else if (total > 1) {
+            total = 2;
+        } else total = 3;
+        unchecked {
+            total++;
+        }
+        total += data[:4].length;
+    }
+
+    function sum(uint256 a, uint256 b) public pure returns (uint256) {
+        return a + b;
+    }
+
+    function run() public override {}
+}`
+
+// buildSyntaxTree parses the syntax sources.
+func buildSyntaxTree(t *testing.T) *RootNode {
+	_, astBuilder := parseTestSources(t, newTestSources("Syntax",
+		&solgo.SourceUnit{Name: "Lib", Path: "Lib.sol", Content: syntaxLibraryContent},
+		&solgo.SourceUnit{Name: "Syntax", Path: "Syntax.sol", Content: syntaxContent},
+	))
+	return astBuilder.GetRoot()
+}
+
+// collectSyntaxNodes returns the nodes of the subtree matching the filter, in depth first order.
+// Nodes reachable through several parents are returned once.
+func collectSyntaxNodes(node Node[NodeType], filter func(Node[NodeType]) bool) []Node[NodeType] {
+	toReturn := make([]Node[NodeType], 0)
+	seen := make(map[int64]bool)
+
+	var walk func(node Node[NodeType])
+	walk = func(node Node[NodeType]) {
+		if node == nil || seen[node.GetId()] {
+			return
+		}
+		seen[node.GetId()] = true
+		if filter(node) {
+			toReturn = append(toReturn, node)
+		}
+		for _, child := range node.GetNodes() {
+			walk(child)
+		}
+	}
+
+	walk(node)
+	return toReturn
+}
+
+// syntaxUnit returns the source unit with the given name.
+func syntaxUnit(t *testing.T, root *RootNode, name string) *SourceUnit[Node[ast_pb.SourceUnit]] {
+	for _, unit := range root.GetSourceUnits() {
+		if unit.GetName() == name {
+			return unit
+		}
+	}
+	require.FailNowf(t, "source unit not found", "source unit %s", name)
+	return nil
+}
+
+// syntaxFunction returns the function of the Syntax contract with the given name.
+func syntaxFunction(t *testing.T, root *RootNode, name string) *Function {
+	for _, node := range syntaxUnit(t, root, "Syntax").GetContract().GetNodes() {
+		if function, ok := node.(*Function); ok && function.GetName() == name {
+			return function
+		}
+	}
+	require.FailNowf(t, "function not found", "function %s", name)
+	return nil
+}
+
+// roundTrip marshals the node to JSON and unmarshals it into target.
+func roundTrip(t *testing.T, node any, target any) {
+	data, err := json.Marshal(node)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, target))
+}
+
+func TestAstSyntaxDeclarations(t *testing.T) {
+	root := buildSyntaxTree(t)
+
+	imports := syntaxUnit(t, root, "Syntax").GetImports()
+	require.Len(t, imports, 2)
+	assert.True(t, imports[0].IsWildcard())
+	assert.Equal(t, "All", imports[0].GetUnitAlias())
+	assert.Empty(t, imports[0].GetSymbols())
+	assert.False(t, imports[1].IsWildcard())
+	assert.Equal(t, []*ImportSymbol{{Name: "Lib"}, {Name: "Lib", Alias: "Alias"}}, imports[1].GetSymbols())
+
+	var importNode Import
+	roundTrip(t, imports[1], &importNode)
+	assert.Equal(t, imports[1].GetSymbols(), importNode.GetSymbols())
+
+	named, ok := syntaxUnit(t, root, "Named").GetContract().(*Contract)
+	require.True(t, ok)
+	assert.True(t, named.IsAbstract())
+
+	contract, ok := syntaxUnit(t, root, "Syntax").GetContract().(*Contract)
+	require.True(t, ok)
+	assert.False(t, contract.IsAbstract())
+
+	bases := contract.GetBaseContracts()
+	require.Len(t, bases, 1)
+	require.Len(t, bases[0].GetArguments(), 1)
+	assert.Equal(t, ast_pb.NodeType_LITERAL, bases[0].GetArguments()[0].GetType())
+
+	var base BaseContract
+	roundTrip(t, bases[0], &base)
+	require.Len(t, base.GetArguments(), 1)
+	assert.Equal(t, bases[0].GetArguments()[0].GetId(), base.GetArguments()[0].GetId())
+}
+
+func TestAstSyntaxExpressions(t *testing.T) {
+	root := buildSyntaxTree(t)
+	pay := syntaxFunction(t, root, "pay")
+
+	options := collectSyntaxNodes(pay.GetBody(), func(node Node[NodeType]) bool {
+		_, ok := node.(*FunctionCallOption)
+		return ok
+	})
+	require.Len(t, options, 1)
+	option := options[0].(*FunctionCallOption)
+	assert.Equal(t, []string{"value", "gas"}, option.GetNames())
+	require.Len(t, option.GetOptions(), 2)
+	assert.Contains(t, option.GetNodes(), option.GetOptions()[1])
+
+	var optionNode FunctionCallOption
+	roundTrip(t, option, &optionNode)
+	assert.Equal(t, option.GetNames(), optionNode.GetNames())
+	assert.Len(t, optionNode.GetOptions(), 2)
+
+	calls := collectSyntaxNodes(pay.GetBody(), func(node Node[NodeType]) bool {
+		call, ok := node.(*FunctionCall)
+		return ok && len(call.GetNames()) > 0
+	})
+	require.Len(t, calls, 1)
+	call := calls[0].(*FunctionCall)
+	assert.Equal(t, []string{"b", "a"}, call.GetNames())
+	assert.Len(t, call.GetArguments(), 2)
+
+	var callNode FunctionCall
+	roundTrip(t, call, &callNode)
+	assert.Equal(t, call.GetNames(), callNode.GetNames())
+
+	ranges := collectSyntaxNodes(pay.GetBody(), func(node Node[NodeType]) bool {
+		_, ok := node.(*IndexRange)
+		return ok
+	})
+	require.Len(t, ranges, 2)
+	from, to := ranges[0].(*IndexRange), ranges[1].(*IndexRange)
+	assert.NotNil(t, from.GetStartExpression())
+	assert.Nil(t, from.GetEndExpression())
+	assert.Nil(t, to.GetStartExpression())
+	assert.NotNil(t, to.GetEndExpression())
+
+	var rangeNode IndexRange
+	roundTrip(t, from, &rangeNode)
+	require.NotNil(t, rangeNode.GetStartExpression())
+	assert.Equal(t, from.GetStartExpression().GetId(), rangeNode.GetStartExpression().GetId())
+	assert.Nil(t, rangeNode.GetEndExpression())
+}
+
+func TestAstSyntaxStatements(t *testing.T) {
+	root := buildSyntaxTree(t)
+	statements := syntaxFunction(t, root, "pay").GetBody().GetStatements()
+
+	// Unchecked blocks keep their place among the statements of the block.
+	types := make([]ast_pb.NodeType, 0, len(statements))
+	for _, statement := range statements {
+		types = append(types, statement.GetType())
+	}
+	assert.Equal(t, []ast_pb.NodeType{
+		ast_pb.NodeType_VARIABLE_DECLARATION,
+		ast_pb.NodeType_ASSIGNMENT,
+		ast_pb.NodeType_IF_STATEMENT,
+		ast_pb.NodeType_UNCHECKED_BLOCK,
+		ast_pb.NodeType_ASSIGNMENT,
+	}, types)
+
+	ifStatement, ok := statements[2].(*IfStatement)
+	require.True(t, ok)
+	elseIf, ok := ifStatement.GetElse().(*IfStatement)
+	require.True(t, ok)
+	elseBody, ok := elseIf.GetElse().(*BodyNode)
+	require.True(t, ok)
+	assert.Len(t, elseBody.GetStatements(), 1)
+
+	var ifNode IfStatement
+	roundTrip(t, ifStatement, &ifNode)
+	nested, ok := ifNode.GetElse().(*IfStatement)
+	require.True(t, ok)
+	assert.NotNil(t, nested.GetElse())
+
+	// Protocol buffer messages of if statements have no else field, so the else branch is added to the
+	// value of the typed struct, holding the chained if statement and its final body.
+	proto, ok := ifStatement.ToProto().(*v3.TypedStruct)
+	require.True(t, ok)
+	assert.Contains(t, proto.GetValue().GetFields(), "condition")
+	assert.Contains(t, proto.GetValue().GetFields(), "body")
+	require.Contains(t, proto.GetValue().GetFields(), "else")
+
+	chained := proto.GetValue().GetFields()["else"].GetStructValue().GetFields()
+	assert.Equal(t, "github.com/unpackdev/protos/unpack.v1.ast.If", chained["typeUrl"].GetStringValue())
+	final := chained["value"].GetStructValue().GetFields()["else"].GetStructValue().GetFields()
+	assert.Equal(t, strconv.FormatInt(elseBody.GetId(), 10), final["id"].GetStringValue())
+	assert.Len(t, final["statements"].GetListValue().GetValues(), 1)
+}
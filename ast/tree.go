@@ -12,6 +12,9 @@ type Tree struct {
 
 	// astRoot is the root node of the Abstract Syntax Tree.
 	astRoot *RootNode
+
+	// rewritten holds the ids of nodes attached to the tree by rewrites.
+	rewritten map[int64]bool
 }
 
 // NewTree creates a new Tree with the provided ASTBuilder.
@@ -41,7 +44,10 @@ func (t *Tree) GetRoot() *RootNode {
 }
 
 // GetById attempts to find a node in the AST by its ID.
-// It performs a recursive search through all nodes in the AST.
+// It performs a recursive search through all nodes in the AST, as exposed through GetNodes.
+// Nodes attached by rewrites are additionally searched for through the fields of all nodes,
+// as their parents may not expose them through GetNodes. Other nodes not exposed through
+// GetNodes are not found.
 // Returns the found Node or nil if the node cannot be found.
 func (t *Tree) GetById(id int64) Node[NodeType] {
 	for _, node := range t.astRoot.GetNodes() {
@@ -53,6 +59,11 @@ func (t *Tree) GetById(id int64) Node[NodeType] {
 			return n
 		}
 	}
+
+	if t.rewritten[id] {
+		return t.byReflectedId(id)
+	}
+
 	return nil
 }
 
@@ -265,14 +265,6 @@ func (t *TryStatement) Parse(
 		bodyNode.ParseBlock(unit, contractNode, t, ctx.Block())
 		t.Body = bodyNode
 
-		if ctx.Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(t.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, t, uncheckedCtx)
-				t.Body.Statements = append(t.Body.Statements, bodyNode)
-			}
-		}
-
 		// Very naive implementation check but it works for now until someone starts to complain.
 		if len(bodyNode.GetNodes()) > 0 {
 			t.Implemented = true
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/goccy/go-json"
 	"strings"
 
@@ -18,6 +19,7 @@ type TupleExpression struct {
 	Constant              bool             `json:"isConstant"`                      // Whether the tuple expression is constant
 	Pure                  bool             `json:"isPure"`                          // Whether the tuple expression is pure
 	Components            []Node[NodeType] `json:"components"`                      // Components of the tuple expression
+	Omitted               []int            `json:"omitted,omitempty"`               // Positions of components left out, such as the first one of (, x)
 	ReferencedDeclaration int64            `json:"referencedDeclaration,omitempty"` // Referenced declaration of the tuple expression
 	TypeDescription       *TypeDescription `json:"typeDescription"`                 // Type description of the tuple expression
 }
@@ -58,6 +60,12 @@ func (t *TupleExpression) GetComponents() []Node[NodeType] {
 	return t.Components
 }
 
+// GetOmitted returns the positions of the components left out of the tuple expression, such as 0
+// for (, x). The components themselves only hold the expressions written out.
+func (t *TupleExpression) GetOmitted() []int {
+	return t.Omitted
+}
+
 // GetNodes returns the components of the tuple expression.
 func (t *TupleExpression) GetNodes() []Node[NodeType] {
 	return t.Components
@@ -132,6 +140,12 @@ func (t *TupleExpression) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if omitted, ok := tempMap["omitted"]; ok {
+		if err := json.Unmarshal(omitted, &t.Omitted); err != nil {
+			return err
+		}
+	}
+
 	if components, ok := tempMap["components"]; ok {
 		var nodes []json.RawMessage
 		if err := json.Unmarshal(components, &nodes); err != nil {
@@ -225,6 +239,24 @@ func (t *TupleExpression) Parse(
 		}
 	}
 
+	// Positions without an expression between the commas are left out, as in (, x) = f().
+	position, written := 0, false
+	for _, child := range ctx.TupleExpression().GetChildren() {
+		if terminal, ok := child.(antlr.TerminalNode); ok {
+			if terminal.GetText() == "," {
+				if !written {
+					t.Omitted = append(t.Omitted, position)
+				}
+				position, written = position+1, false
+			}
+			continue
+		}
+		written = true
+	}
+	if position > 0 && !written {
+		t.Omitted = append(t.Omitted, position)
+	}
+
 	t.TypeDescription = t.buildTypeDescription()
 	return t
 }
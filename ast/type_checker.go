@@ -0,0 +1,1935 @@
+package ast
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// versionPattern matches the first version of a solidity pragma, capturing its minor version.
+var versionPattern = regexp.MustCompile(`0\.([0-9]+)`)
+
+// TypeError is a type error found by the TypeChecker, such as an operator applied to types it is
+// not defined for, or a value which is not implicitly convertible to the type it is used as.
+type TypeError struct {
+	Node    Node[NodeType] `json:"-"`       // Node the error was found at.
+	Src     SrcNode        `json:"src"`     // Source location of the node.
+	Message string         `json:"message"` // Message describing the error, worded as the compiler words it.
+}
+
+// Error returns the message of the type error.
+func (e *TypeError) Error() string {
+	return e.Message
+}
+
+// TypeChecker determines types of the expressions of the tree following the typing rules of the
+// Solidity compiler, and reports type errors it comes across.
+//
+// Types are written as exact type descriptions into the expression nodes, replacing the ones the
+// builder approximated while parsing. Declarations keep their type descriptions. The checker relies
+// on references resolved by the SymbolTable, so it has to run after the symbols are resolved. Types
+// which cannot be determined are left as they are, and no errors are reported for them.
+type TypeChecker struct {
+	tree      *Tree
+	symbols   *SymbolTable
+	nodes     map[int64]Node[NodeType]       // Nodes of the tree by id.
+	parents   map[int64]Node[NodeType]       // Contracts of their member declarations by member id.
+	enums     map[int64]*EnumDefinition      // Enums of their values by value id.
+	types     map[int64]*Type                // Types of the checked expressions by node id.
+	calls     map[int64][]*Type              // Argument types of calls by id of the called expression.
+	operators map[int64]int64                // Functions implementing user defined operators by node id.
+	errors    []*TypeError                   // Type errors found so far.
+	seen      map[string]Node[NodeType]      // Declarations by kind and location, to unify duplicated globals.
+	contract  Node[NodeType]                 // Contract whose members are checked.
+	returns   []*Type                        // Return types of the function whose body is checked.
+	cache     map[*TypeName]map[*Scope]*Type // Types of type names, by scope they were resolved in.
+	minor     int                            // Lowest minor compiler version the checked source unit allows.
+}
+
+// NewTypeChecker creates a new TypeChecker for the tree with resolved symbols.
+func NewTypeChecker(tree *Tree, symbols *SymbolTable) *TypeChecker {
+	return &TypeChecker{
+		tree:      tree,
+		symbols:   symbols,
+		nodes:     make(map[int64]Node[NodeType]),
+		parents:   make(map[int64]Node[NodeType]),
+		enums:     make(map[int64]*EnumDefinition),
+		types:     make(map[int64]*Type),
+		calls:     make(map[int64][]*Type),
+		operators: make(map[int64]int64),
+		errors:    make([]*TypeError, 0),
+		seen:      make(map[string]Node[NodeType]),
+		cache:     make(map[*TypeName]map[*Scope]*Type),
+		minor:     8,
+	}
+}
+
+// Check determines types of all of the expressions of the tree and returns the type errors found.
+func (c *TypeChecker) Check() []*TypeError {
+	root := c.tree.GetRoot()
+	if root == nil || c.symbols == nil {
+		return c.errors
+	}
+
+	for _, unit := range root.GetSourceUnits() {
+		for _, node := range unit.GetNodes() {
+			c.index(node, nil)
+		}
+	}
+	for _, node := range root.GetGlobalNodes() {
+		c.index(node, nil)
+	}
+
+	for _, unit := range root.GetSourceUnits() {
+		scope := c.symbols.GetScope(unit.GetId())
+		c.minor = pragmaMinor(unit)
+		for _, node := range unit.GetNodes() {
+			c.check(node, scope)
+		}
+	}
+	c.minor = 8
+
+	for _, node := range root.GetGlobalNodes() {
+		if _, ok := node.(*Function); ok {
+			c.check(node, c.symbols.GetGlobalScope())
+		}
+	}
+
+	return c.errors
+}
+
+// GetErrors returns the type errors found by the last check.
+func (c *TypeChecker) GetErrors() []*TypeError {
+	return c.errors
+}
+
+// GetType returns the type of the expression with the given id, or nil if the expression was not
+// checked.
+func (c *TypeChecker) GetType(nodeId int64) *Type {
+	return c.types[nodeId]
+}
+
+// GetOperatorFunction returns the id of the function implementing the user defined operator of the
+// operation with the given id, or 0 if the operation uses a built-in operator.
+func (c *TypeChecker) GetOperatorFunction(nodeId int64) int64 {
+	return c.operators[nodeId]
+}
+
+// index records the node and its children by id, along with contracts of their members and enums
+// of their values. Global definitions duplicating definitions of contracts are mapped to the
+// definitions of the contracts.
+func (c *TypeChecker) index(node Node[NodeType], parent Node[NodeType]) {
+	if IsNilNode(node) {
+		return
+	}
+
+	switch node.(type) {
+	case *StructDefinition, *EnumDefinition, *UserDefinedValueTypeDefinition, *EventDefinition, *ErrorDefinition:
+		key := fmt.Sprintf("%s:%d:%d", reflect.TypeOf(node).String(), node.GetSrc().Start, node.GetSrc().End)
+		if seen, ok := c.seen[key]; ok && seen.GetId() != node.GetId() {
+			c.nodes[node.GetId()] = seen
+			if enum, ok := node.(*EnumDefinition); ok {
+				for _, member := range enum.GetMembers() {
+					c.enums[member.GetId()] = seen.(*EnumDefinition)
+				}
+			}
+			return
+		}
+		c.seen[key] = node
+	}
+
+	if _, ok := c.nodes[node.GetId()]; !ok {
+		c.nodes[node.GetId()] = node
+	}
+	if parent != nil {
+		c.parents[node.GetId()] = parent
+	}
+
+	switch nodeCtx := node.(type) {
+	case *Contract, *Library, *Interface:
+		for _, member := range node.GetNodes() {
+			c.index(member, node)
+		}
+		return
+	case *EnumDefinition:
+		for _, member := range nodeCtx.GetMembers() {
+			c.enums[member.GetId()] = nodeCtx
+			c.nodes[member.GetId()] = member
+		}
+		return
+	}
+
+	for _, child := range node.GetNodes() {
+		c.index(child, nil)
+	}
+}
+
+// check checks the statements and declarations of the node and its children.
+func (c *TypeChecker) check(node Node[NodeType], scope *Scope) {
+	if IsNilNode(node) {
+		return
+	}
+
+	if opened := c.symbols.GetScope(node.GetId()); opened != nil {
+		scope = opened
+	}
+
+	switch nodeCtx := node.(type) {
+	case *Contract, *Library, *Interface:
+		previous := c.contract
+		c.contract = node
+		for _, base := range c.symbols.baseContracts(node) {
+			for _, argument := range base.Arguments {
+				c.expression(argument, scope)
+			}
+		}
+		for _, member := range node.GetNodes() {
+			c.check(member, scope)
+		}
+		c.contract = previous
+
+	case *Function:
+		c.checkCallable(nodeCtx.GetReturnParameters(), nodeCtx.GetModifiers(), nodeCtx.GetBody(), scope)
+	case *Constructor:
+		c.checkCallable(nil, nodeCtx.GetModifiers(), nodeCtx.GetBody(), scope)
+	case *Fallback:
+		c.checkCallable(nil, nodeCtx.GetModifiers(), nodeCtx.GetBody(), scope)
+	case *Receive:
+		c.checkCallable(nil, nodeCtx.GetModifiers(), nodeCtx.GetBody(), scope)
+	case *ModifierDefinition:
+		c.checkCallable(nil, nil, nodeCtx.GetBody(), scope)
+
+	case *StateVariableDeclaration:
+		if !IsNilNode(nodeCtx.InitialValue) {
+			c.expect(nodeCtx.InitialValue, c.expression(nodeCtx.InitialValue, scope), c.declarationType(node, "", scope))
+		}
+	case *VariableDeclaration:
+		c.checkVariables(nodeCtx, scope)
+	case *ReturnStatement:
+		c.checkReturn(nodeCtx, scope)
+
+	case *IfStatement:
+		c.condition(nodeCtx.Condition, scope)
+		c.check(nodeCtx.Body, scope)
+		c.check(nodeCtx.Else, scope)
+	case *WhileStatement:
+		c.condition(nodeCtx.Condition, scope)
+		c.check(nodeCtx.Body, scope)
+	case *DoWhileStatement:
+		c.check(nodeCtx.Body, scope)
+		c.condition(nodeCtx.Condition, scope)
+	case *ForStatement:
+		c.check(nodeCtx.Initialiser, scope)
+		c.condition(nodeCtx.Condition, scope)
+		c.check(nodeCtx.Closure, scope)
+		c.check(nodeCtx.Body, scope)
+
+	case *Emit:
+		c.call(node, c.expression(nodeCtx.Expression, scope), nodeCtx.Arguments, c.arguments(nodeCtx.Arguments, scope), nodeCtx.Names)
+	case *RevertStatement:
+		c.call(node, c.expression(nodeCtx.Expression, scope), nodeCtx.Arguments, c.arguments(nodeCtx.Arguments, scope), nodeCtx.Names)
+	case *ModifierInvocation:
+		c.arguments(nodeCtx.Arguments, scope)
+
+	case *TypeName, *Parameter, *StructDefinition, *EnumDefinition, *EventDefinition,
+		*ErrorDefinition, *UserDefinedValueTypeDefinition, *UsingDirective, *Import, *Pragma, *Yul:
+		// Declarations take their types from their type names, while inline assembly is untyped.
+
+	default:
+		if isExpression(node) {
+			c.expression(node, scope)
+			return
+		}
+
+		for _, child := range node.GetNodes() {
+			c.check(child, scope)
+		}
+	}
+}
+
+// checkCallable checks the modifier invocations and the body of the function like node.
+func (c *TypeChecker) checkCallable(returnParameters *ParameterList, modifiers []*ModifierInvocation, body *BodyNode, scope *Scope) {
+	previous := c.returns
+	c.returns = c.parameterTypes(returnParameters, scope)
+
+	for _, modifier := range modifiers {
+		c.check(modifier, scope)
+	}
+	c.check(body, scope)
+
+	c.returns = previous
+}
+
+// checkVariables checks that the initial value of the variable declaration statement is implicitly
+// convertible to the types of the declared variables.
+func (c *TypeChecker) checkVariables(statement *VariableDeclaration, scope *Scope) {
+	if IsNilNode(statement.InitialValue) {
+		return
+	}
+
+	value := c.expression(statement.InitialValue, scope)
+	if len(statement.Declarations) == 1 {
+		// Components skipped by the declaration, as in (bool success, ) = ..., are not kept, so
+		// tuples cannot be matched against the declared variable.
+		if statement.Declarations[0] != nil && value.Kind != TypeTuple {
+			c.expect(statement.InitialValue, value, c.variableType(statement.Declarations[0], scope))
+		}
+		return
+	}
+
+	if value.Kind != TypeTuple || len(value.Components) != len(statement.Declarations) {
+		return
+	}
+
+	for i, declaration := range statement.Declarations {
+		if declaration != nil && value.Components[i] != nil {
+			c.expect(statement.InitialValue, value.Components[i], c.variableType(declaration, scope))
+		}
+	}
+}
+
+// checkReturn checks that the returned value is implicitly convertible to the return types and,
+// for functions with several return variables, that the number of returned values matches.
+func (c *TypeChecker) checkReturn(statement *ReturnStatement, scope *Scope) {
+	if IsNilNode(statement.Expression) {
+		return
+	}
+
+	value := c.expression(statement.Expression, scope)
+
+	switch len(c.returns) {
+	case 0:
+		return
+	case 1:
+		if !c.convertible(value, c.returns[0]) {
+			c.report(statement.Expression, "Return argument type %s is not implicitly convertible to expected type (type of first return variable) %s.", value, c.returns[0])
+		}
+		return
+	}
+
+	if !value.IsKnown() {
+		return
+	}
+
+	if value.Kind != TypeTuple || len(value.Components) != len(c.returns) {
+		c.report(statement.Expression, "Different number of arguments in return statement than in returns declaration.")
+		return
+	}
+
+	if expected := NewTupleType(c.returns...); !c.convertible(value, expected) {
+		c.report(statement.Expression, "Return argument type %s is not implicitly convertible to expected type %s.", value, expected)
+	}
+}
+
+// condition checks that the condition is a boolean expression.
+func (c *TypeChecker) condition(node Node[NodeType], scope *Scope) {
+	if IsNilNode(node) {
+		return
+	}
+	c.expect(node, c.expression(node, scope), boolType)
+}
+
+// expect reports an error if the type of the node is not implicitly convertible to the expected type.
+func (c *TypeChecker) expect(node Node[NodeType], value *Type, expected *Type) {
+	if !c.convertible(value, expected) {
+		c.report(node, "Type %s is not implicitly convertible to expected type %s.", value, expected)
+	}
+}
+
+// report records the type error found at the node.
+func (c *TypeChecker) report(node Node[NodeType], format string, types ...*Type) {
+	arguments := make([]any, 0, len(types))
+	for _, t := range types {
+		arguments = append(arguments, t.String())
+	}
+
+	c.errors = append(c.errors, &TypeError{
+		Node:    node,
+		Src:     node.GetSrc(),
+		Message: fmt.Sprintf(format, arguments...),
+	})
+}
+
+// convertible returns true if values of the type are implicitly convertible to the expected type,
+// including conversions of contracts to their base contracts.
+func (c *TypeChecker) convertible(value *Type, expected *Type) bool {
+	if !value.IsKnown() || !expected.IsKnown() {
+		return true
+	}
+
+	switch {
+	case value.Kind == TypeTuple && expected.Kind == TypeTuple:
+		if len(value.Components) != len(expected.Components) {
+			return false
+		}
+		for i, component := range value.Components {
+			if component != nil && expected.Components[i] != nil && !c.convertible(component, expected.Components[i]) {
+				return false
+			}
+		}
+		return true
+	case value.Kind == TypeContract && expected.Kind == TypeContract && !value.Super:
+		for _, id := range c.symbols.GetLinearization(value.Declaration) {
+			if id == expected.Declaration {
+				return true
+			}
+		}
+		return value.Declaration == expected.Declaration
+	}
+
+	return value.IsImplicitlyConvertibleTo(expected)
+}
+
+// arguments determines types of the arguments.
+func (c *TypeChecker) arguments(arguments []Node[NodeType], scope *Scope) []*Type {
+	toReturn := make([]*Type, 0, len(arguments))
+	for _, argument := range arguments {
+		toReturn = append(toReturn, c.expression(argument, scope))
+	}
+	return toReturn
+}
+
+// expression determines the type of the expression, records it and writes its description into the
+// expression node.
+func (c *TypeChecker) expression(node Node[NodeType], scope *Scope) *Type {
+	if IsNilNode(node) {
+		return unknownType
+	}
+
+	var toReturn *Type
+	switch nodeCtx := node.(type) {
+	case *PrimaryExpression:
+		toReturn = c.primary(nodeCtx, scope)
+	case *MemberAccessExpression:
+		toReturn = c.memberAccess(nodeCtx, scope)
+	case *IndexAccess:
+		toReturn = c.indexAccess(nodeCtx, scope)
+	case *IndexRange:
+		toReturn = c.indexRange(nodeCtx, scope)
+	case *FunctionCall:
+		// Arguments are typed first, so that overloads of the callee can be picked by them.
+		arguments := c.arguments(nodeCtx.Arguments, scope)
+		if !IsNilNode(nodeCtx.Expression) && len(nodeCtx.Names) == 0 {
+			c.calls[nodeCtx.Expression.GetId()] = arguments
+		}
+		callee := c.expression(nodeCtx.Expression, scope)
+		toReturn = c.call(node, callee, nodeCtx.Arguments, arguments, nodeCtx.Names)
+	case *FunctionCallOption:
+		c.arguments(nodeCtx.Options, scope)
+		toReturn = c.expression(nodeCtx.Expression, scope)
+	case *NewExpr:
+		toReturn = c.newExpression(nodeCtx, scope)
+	case *PayableConversion:
+		c.arguments(nodeCtx.Arguments, scope)
+		toReturn = &Type{Kind: TypeAddress, Payable: true}
+	case *MetaType:
+		toReturn = &Type{Kind: TypeMagic, Name: "meta", Actual: c.typeName(nodeCtx.TypeName, scope)}
+	case *TupleExpression:
+		toReturn = c.tuple(nodeCtx, scope)
+	case *InlineArray:
+		toReturn = c.inlineArray(nodeCtx, scope)
+	case *Conditional:
+		toReturn = c.conditional(nodeCtx, scope)
+	case *Assignment:
+		toReturn = c.assignment(nodeCtx, scope)
+	case *UnaryPrefix:
+		toReturn = c.unary(node, nodeCtx.Operator, nodeCtx.Expression, scope)
+	case *UnarySuffix:
+		toReturn = c.unary(node, nodeCtx.Operator, nodeCtx.Expression, scope)
+	case *BinaryOperation:
+		toReturn = c.binary(node, binaryOperator(nodeCtx.Operator), nodeCtx.LeftExpression, nodeCtx.RightExpression, scope)
+	case *AndOperation:
+		toReturn = c.binaryList(node, "&&", nodeCtx.Expressions, scope)
+	case *BitAndOperation:
+		toReturn = c.binaryList(node, "&", nodeCtx.Expressions, scope)
+	case *BitOrOperation:
+		toReturn = c.binaryList(node, "|", nodeCtx.Expressions, scope)
+	case *BitXorOperation:
+		toReturn = c.binaryList(node, "^", nodeCtx.Expressions, scope)
+	case *ShiftOperation:
+		operator := "<<"
+		if nodeCtx.Operator == ast_pb.NodeType_SHIFT_RIGHT_OPERATION {
+			operator = ">>"
+		}
+		toReturn = c.binaryList(node, operator, nodeCtx.Expressions, scope)
+	case *ExprOperation:
+		toReturn = c.binary(node, "**", nodeCtx.LeftExpression, nodeCtx.RightExpression, scope)
+	default:
+		for _, child := range node.GetNodes() {
+			c.check(child, scope)
+		}
+	}
+
+	if toReturn == nil {
+		toReturn = unknownType
+	}
+
+	c.types[node.GetId()] = toReturn
+	c.describe(node, toReturn)
+	return toReturn
+}
+
+// describe writes the description of the type into the expression node. Unknown types are not
+// written, so that the descriptions approximated by the builder are kept for them.
+func (c *TypeChecker) describe(node Node[NodeType], t *Type) {
+	description := t.ToTypeDescription()
+	if description == nil {
+		return
+	}
+
+	switch nodeCtx := node.(type) {
+	case *PrimaryExpression:
+		nodeCtx.TypeDescription = description
+	case *MemberAccessExpression:
+		nodeCtx.TypeDescription = description
+	case *IndexAccess:
+		nodeCtx.TypeDescription = description
+	case *IndexRange:
+		nodeCtx.TypeDescription = description
+	case *FunctionCall:
+		nodeCtx.TypeDescription = description
+	case *FunctionCallOption:
+		nodeCtx.TypeDescription = description
+	case *NewExpr:
+		nodeCtx.TypeDescription = description
+	case *PayableConversion:
+		nodeCtx.TypeDescription = description
+	case *MetaType:
+		nodeCtx.TypeDescription = description
+	case *TupleExpression:
+		nodeCtx.TypeDescription = description
+	case *InlineArray:
+		nodeCtx.TypeDescription = description
+	case *Conditional:
+		nodeCtx.TypeDescription = description
+	case *Assignment:
+		nodeCtx.TypeDescription = description
+	case *UnaryPrefix:
+		nodeCtx.TypeDescription = description
+	case *UnarySuffix:
+		nodeCtx.TypeDescription = description
+	case *BinaryOperation:
+		nodeCtx.TypeDescription = description
+	case *AndOperation:
+		nodeCtx.TypeDescription = description
+	case *BitAndOperation:
+		nodeCtx.TypeDescription = description
+	case *BitOrOperation:
+		nodeCtx.TypeDescription = description
+	case *BitXorOperation:
+		nodeCtx.TypeDescription = description
+	case *ShiftOperation:
+		nodeCtx.TypeDescription = description
+	case *ExprOperation:
+		nodeCtx.TypeDescription = description
+	}
+}
+
+// primary determines the type of the literal or the identifier.
+func (c *TypeChecker) primary(primary *PrimaryExpression, scope *Scope) *Type {
+	switch primary.GetKind() {
+	case ast_pb.NodeType_NUMBER:
+		return numberLiteralType(primary.GetValue())
+	case ast_pb.NodeType_BOOLEAN:
+		return boolType
+	case ast_pb.NodeType_STRING, ast_pb.NodeType_UNICODE_STRING_LITERAL:
+		return &Type{Kind: TypeStringLiteral, Literal: strings.Trim(primary.GetValue(), "'\"")}
+	case ast_pb.NodeType_HEX_STRING:
+		return &Type{Kind: TypeStringLiteral, Hex: true, Literal: strings.Trim(strings.TrimPrefix(primary.GetValue(), "hex"), "'\"")}
+	}
+
+	if primary.TypeName != nil {
+		return &Type{Kind: TypeMeta, Actual: c.typeName(primary.TypeName, scope)}
+	}
+
+	name := primary.GetName()
+	if declaration := c.declaration(c.overload(primary, nil, scope)); declaration != nil {
+		if t := c.declarationType(declaration, name, scope); t.IsKnown() {
+			return t
+		}
+	}
+
+	switch name {
+	case "this":
+		if contract := c.currentContract(scope); contract != nil {
+			return c.userType(contract)
+		}
+	case "super":
+		if contract := c.currentContract(scope); contract != nil {
+			toReturn := c.userType(contract)
+			toReturn.Super = true
+			return toReturn
+		}
+	case "msg", "block", "tx", "abi":
+		return &Type{Kind: TypeMagic, Name: name}
+	case "now":
+		return uint256Type
+	}
+
+	if builtin := c.builtinFunction(name, c.symbols.arities[primary.GetId()]); builtin != nil {
+		return builtin
+	}
+
+	if elementary := elementaryType(name); elementary != nil {
+		return &Type{Kind: TypeMeta, Actual: elementary}
+	}
+
+	return unknownType
+}
+
+// memberAccess determines the type of the member of the expression.
+func (c *TypeChecker) memberAccess(member *MemberAccessExpression, scope *Scope) *Type {
+	base := c.expression(member.Expression, scope)
+	name := member.MemberName
+	declaration := c.declaration(c.overload(member, base, scope))
+
+	// Functions attached to the type with using for directives take the expression as their first
+	// argument, whatever the type of the expression is.
+	if function, ok := declaration.(*Function); ok && base.IsKnown() && c.symbols.bound[member.GetId()] {
+		return c.attached(function, base, scope)
+	}
+
+	switch base.Kind {
+	case TypeMagic:
+		return c.magicMember(base, name, member)
+	case TypeAddress:
+		return addressMember(base, name)
+	case TypeArray, TypeBytes:
+		switch name {
+		case "length":
+			return uint256Type
+		case "push":
+			elementType := &Type{Kind: TypeFixedBytes, Size: 1}
+			if base.Kind == TypeArray {
+				elementType = base.Base
+			}
+			if c.symbols.arities[member.GetId()] > 0 {
+				return &Type{Kind: TypeFunction, FunctionKind: "arraypush", Parameters: []*Type{elementType}, AttachedTo: base}
+			}
+			return &Type{Kind: TypeFunction, FunctionKind: "arraypush", Returns: []*Type{elementType}, AttachedTo: base}
+		case "pop":
+			return &Type{Kind: TypeFunction, FunctionKind: "arraypop", AttachedTo: base}
+		}
+	case TypeFixedBytes:
+		if name == "length" {
+			return NewIntegerType(8, false)
+		}
+	case TypeStruct:
+		if t := c.structMember(base, name, scope); t.IsKnown() {
+			return t
+		}
+	case TypeContract:
+		if declaration != nil {
+			switch declarationCtx := declaration.(type) {
+			case *Function:
+				if base.Super {
+					return c.functionType(declarationCtx, "internal", scope)
+				}
+				return c.functionType(declarationCtx, "external", scope)
+			case *StateVariableDeclaration:
+				return getterType(c.declarationType(declaration, "", scope))
+			}
+		}
+	case TypeMeta:
+		return c.typeMember(base.Actual, name, declaration, scope)
+	case TypeFunction:
+		switch name {
+		case "selector":
+			return &Type{Kind: TypeFixedBytes, Size: 4}
+		case "address":
+			return addressType
+		}
+	}
+
+	// Functions attached to the type with using for directives.
+	if function, ok := declaration.(*Function); ok && base.IsKnown() {
+		return c.attached(function, base, scope)
+	}
+
+	return unknownType
+}
+
+// attached returns the type of the library function attached to the base type with a using for
+// directive.
+func (c *TypeChecker) attached(function *Function, base *Type, scope *Scope) *Type {
+	toReturn := c.functionType(function, "internal", scope)
+	toReturn.AttachedTo = base
+	return toReturn
+}
+
+// overload returns the declaration the called expression refers to. Among overloads with the same
+// parameter count, the first one whose parameters accept the arguments of the call is picked. The
+// base is the type of the expression functions attached with using for are called on.
+func (c *TypeChecker) overload(node Node[NodeType], base *Type, scope *Scope) int64 {
+	candidates := c.symbols.overloads[node.GetId()]
+	arguments, ok := c.calls[node.GetId()]
+	if len(candidates) == 0 || !ok {
+		return c.symbols.referenceOf(node)
+	}
+
+	if c.symbols.bound[node.GetId()] {
+		arguments = append([]*Type{base}, arguments...)
+	}
+
+	for _, candidate := range candidates {
+		var types []*Type
+		switch declaration := c.declaration(candidate).(type) {
+		case *Function:
+			types = c.functionType(declaration, "internal", scope).Parameters
+		case *EventDefinition:
+			types = c.parameterTypes(declaration.GetParameters(), c.declarationScope(declaration, scope))
+		case *ErrorDefinition:
+			types = c.parameterTypes(declaration.GetParameters(), c.declarationScope(declaration, scope))
+		}
+		if len(types) != len(arguments) {
+			continue
+		}
+
+		// Data locations do not take part in overload resolution.
+		accepted := true
+		for i, argument := range arguments {
+			if !c.convertible(argument.withoutLocation(), types[i].withoutLocation()) {
+				accepted = false
+				break
+			}
+		}
+
+		if accepted {
+			c.symbols.rebind(node, candidate)
+			return candidate
+		}
+	}
+
+	return c.symbols.referenceOf(node)
+}
+
+// getterType returns the type of the getter of the public state variable of the type. Getters take
+// the keys of mappings and the indices of arrays, and return the value they lead to.
+func getterType(t *Type) *Type {
+	getter := &Type{Kind: TypeFunction, FunctionKind: "external", StateMutability: "view"}
+	for t.IsKnown() {
+		if t.Kind == TypeMapping && t.KeyType != nil && t.ValueType != nil {
+			getter.Parameters = append(getter.Parameters, t.KeyType.WithLocation(LocationMemory, false))
+			t = t.ValueType
+		} else if t.Kind == TypeArray && t.Base != nil {
+			getter.Parameters = append(getter.Parameters, uint256Type)
+			t = t.Base
+		} else {
+			break
+		}
+	}
+	getter.Returns = []*Type{t.WithLocation(LocationMemory, false)}
+	return getter
+}
+
+// typeMember determines the type of the member of the type, such as members of libraries, enum
+// values, or wrap and unwrap of user defined value types.
+func (c *TypeChecker) typeMember(t *Type, name string, declaration Node[NodeType], scope *Scope) *Type {
+	if !t.IsKnown() {
+		return unknownType
+	}
+
+	switch t.Kind {
+	case TypeEnum:
+		return &Type{Kind: TypeEnum, Name: t.Name, CanonicalName: t.CanonicalName, Declaration: t.Declaration}
+	case TypeUserDefinedValue:
+		switch name {
+		case "wrap":
+			return &Type{Kind: TypeFunction, FunctionKind: "wrap", StateMutability: "pure", Parameters: []*Type{t.Underlying}, Returns: []*Type{t}}
+		case "unwrap":
+			return &Type{Kind: TypeFunction, FunctionKind: "unwrap", StateMutability: "pure", Parameters: []*Type{t}, Returns: []*Type{t.Underlying}}
+		}
+	case TypeBytes, TypeString:
+		if name == "concat" {
+			kind := "bytesconcat"
+			if t.Kind == TypeString {
+				kind = "stringconcat"
+			}
+			return &Type{Kind: TypeFunction, FunctionKind: kind, StateMutability: "pure", Returns: []*Type{t.WithLocation(LocationMemory, false)}}
+		}
+	}
+
+	if declaration != nil {
+		return c.declarationType(declaration, name, scope)
+	}
+	return unknownType
+}
+
+// magicMember determines the type of the member of msg, block, tx, abi or type(X).
+func (c *TypeChecker) magicMember(magic *Type, name string, member *MemberAccessExpression) *Type {
+	if magic.Actual != nil {
+		switch name {
+		case "name":
+			return (&Type{Kind: TypeString}).WithLocation(LocationMemory, false)
+		case "creationCode", "runtimeCode":
+			return (&Type{Kind: TypeBytes}).WithLocation(LocationMemory, false)
+		case "interfaceId":
+			return &Type{Kind: TypeFixedBytes, Size: 4}
+		case "min", "max":
+			return magic.Actual
+		}
+		return unknownType
+	}
+
+	switch magic.Name {
+	case "msg":
+		switch name {
+		case "sender":
+			// Before 0.8 the sender and the origin are payable addresses.
+			return &Type{Kind: TypeAddress, Payable: c.minor < 8}
+		case "value", "gas":
+			return uint256Type
+		case "data":
+			return (&Type{Kind: TypeBytes}).WithLocation(LocationCalldata, false)
+		case "sig":
+			return &Type{Kind: TypeFixedBytes, Size: 4}
+		}
+	case "block":
+		switch name {
+		case "coinbase":
+			return &Type{Kind: TypeAddress, Payable: true}
+		case "timestamp", "number", "difficulty", "prevrandao", "gaslimit", "chainid", "basefee", "blobbasefee":
+			return uint256Type
+		}
+	case "tx":
+		switch name {
+		case "origin":
+			return &Type{Kind: TypeAddress, Payable: c.minor < 8}
+		case "gasprice":
+			return uint256Type
+		}
+	case "abi":
+		bytesMemory := (&Type{Kind: TypeBytes}).WithLocation(LocationMemory, false)
+		switch name {
+		case "encode", "encodePacked", "encodeWithSelector", "encodeWithSignature", "encodeCall":
+			return &Type{Kind: TypeFunction, FunctionKind: "abi" + strings.ToLower(name), StateMutability: "pure", Returns: []*Type{bytesMemory}}
+		case "decode":
+			return &Type{Kind: TypeFunction, FunctionKind: "abidecode", StateMutability: "pure"}
+		}
+	}
+
+	return unknownType
+}
+
+// addressMember determines the type of the member of the address.
+func addressMember(address *Type, name string) *Type {
+	bytesMemory := (&Type{Kind: TypeBytes}).WithLocation(LocationMemory, false)
+	switch name {
+	case "balance":
+		return uint256Type
+	case "code":
+		return bytesMemory
+	case "codehash":
+		return &Type{Kind: TypeFixedBytes, Size: 32}
+	case "transfer":
+		return &Type{Kind: TypeFunction, FunctionKind: "transfer", Parameters: []*Type{uint256Type}}
+	case "send":
+		return &Type{Kind: TypeFunction, FunctionKind: "send", Parameters: []*Type{uint256Type}, Returns: []*Type{boolType}}
+	case "call", "delegatecall", "staticcall":
+		mutability := "payable"
+		switch name {
+		case "delegatecall":
+			mutability = "nonpayable"
+		case "staticcall":
+			mutability = "view"
+		}
+		return &Type{
+			Kind:            TypeFunction,
+			FunctionKind:    "bare" + name,
+			StateMutability: mutability,
+			Parameters:      []*Type{bytesMemory},
+			Returns:         []*Type{boolType, bytesMemory},
+		}
+	}
+	return unknownType
+}
+
+// builtinFunction returns the type of the global builtin function called with the given number of
+// arguments, or nil if there is no such builtin.
+func (c *TypeChecker) builtinFunction(name string, arity int) *Type {
+	bytes32 := &Type{Kind: TypeFixedBytes, Size: 32}
+	bytesMemory := (&Type{Kind: TypeBytes}).WithLocation(LocationMemory, false)
+	stringMemory := (&Type{Kind: TypeString}).WithLocation(LocationMemory, false)
+
+	switch name {
+	case "keccak256", "sha256":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{bytesMemory}, Returns: []*Type{bytes32}}
+	case "ripemd160":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{bytesMemory}, Returns: []*Type{{Kind: TypeFixedBytes, Size: 20}}}
+	case "ecrecover":
+		return &Type{
+			Kind:            TypeFunction,
+			FunctionKind:    name,
+			StateMutability: "pure",
+			Parameters:      []*Type{bytes32, NewIntegerType(8, false), bytes32, bytes32},
+			Returns:         []*Type{addressType},
+		}
+	case "addmod", "mulmod":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{uint256Type, uint256Type, uint256Type}, Returns: []*Type{uint256Type}}
+	case "gasleft":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "view", Returns: []*Type{uint256Type}}
+	case "blockhash", "blobhash":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "view", Parameters: []*Type{uint256Type}, Returns: []*Type{bytes32}}
+	case "selfdestruct":
+		return &Type{Kind: TypeFunction, FunctionKind: name, Parameters: []*Type{{Kind: TypeAddress, Payable: true}}}
+	case "assert":
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{boolType}}
+	case "require":
+		if arity > 1 {
+			return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{boolType, stringMemory}}
+		}
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{boolType}}
+	case "revert":
+		if arity > 0 {
+			return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure", Parameters: []*Type{stringMemory}}
+		}
+		return &Type{Kind: TypeFunction, FunctionKind: name, StateMutability: "pure"}
+	}
+
+	return nil
+}
+
+// indexAccess determines the type of the element of the array, mapping or bytes.
+func (c *TypeChecker) indexAccess(access *IndexAccess, scope *Scope) *Type {
+	base := c.expression(access.BaseExpression, scope)
+
+	// Array type names, such as uint256[] within new uint256[](1) or abi.decode(data, (uint256[])).
+	if base.Kind == TypeMeta {
+		array := &Type{Kind: TypeArray, Base: base.Actual, Length: -1}
+		if !IsNilNode(access.IndexExpression) {
+			length := c.expression(access.IndexExpression, scope)
+			if length.Kind == TypeRational && length.Rational.IsInt() {
+				array.Length = length.Rational.Num().Int64()
+			}
+		}
+		return &Type{Kind: TypeMeta, Actual: array}
+	}
+
+	index := c.expression(access.IndexExpression, scope)
+
+	switch base.Kind {
+	case TypeArray:
+		c.expect(access.IndexExpression, index, uint256Type)
+		return base.Base
+	case TypeBytes, TypeFixedBytes:
+		c.expect(access.IndexExpression, index, uint256Type)
+		return &Type{Kind: TypeFixedBytes, Size: 1}
+	case TypeMapping:
+		c.expect(access.IndexExpression, index, base.KeyType)
+		return base.ValueType.WithLocation(LocationStorage, false)
+	case TypeString:
+		c.errors = append(c.errors, &TypeError{Node: access, Src: access.GetSrc(), Message: "Index access for string is not possible."})
+	}
+
+	return unknownType
+}
+
+// indexRange determines the type of the slice of the calldata array.
+func (c *TypeChecker) indexRange(indexRange *IndexRange, scope *Scope) *Type {
+	base := c.expression(indexRange.LeftExpression, scope)
+	for _, bound := range []Node[NodeType]{indexRange.StartExpression, indexRange.EndExpression, indexRange.RightExpression} {
+		if !IsNilNode(bound) {
+			if _, ok := c.types[bound.GetId()]; !ok {
+				c.expect(bound, c.expression(bound, scope), uint256Type)
+			}
+		}
+	}
+
+	if base.Kind != TypeArray && base.Kind != TypeBytes {
+		return unknownType
+	}
+
+	toReturn := *base
+	toReturn.Slice = true
+	return &toReturn
+}
+
+// call determines the type of the result of the call, checking the arguments against the parameters
+// of the called function. Calls of type names are conversions or struct constructors.
+func (c *TypeChecker) call(node Node[NodeType], callee *Type, argumentNodes []Node[NodeType], arguments []*Type, names []string) *Type {
+	switch callee.Kind {
+	case TypeMeta:
+		actual := callee.Actual
+		if !actual.IsKnown() {
+			return unknownType
+		}
+
+		if actual.Kind == TypeStruct {
+			return actual.WithLocation(LocationMemory, false)
+		}
+
+		// Conversions keep the data location of the converted value.
+		if actual.IsReference() {
+			location := LocationMemory
+			if len(arguments) == 1 && arguments[0].IsReference() && arguments[0].Location != LocationNone {
+				location = arguments[0].Location
+			}
+			return actual.WithLocation(location, location == LocationStorage)
+		}
+		return actual
+
+	case TypeFunction:
+		switch callee.FunctionKind {
+		case "abidecode":
+			if len(arguments) != 2 {
+				return unknownType
+			}
+			metas := []*Type{arguments[1]}
+			if arguments[1].Kind == TypeTuple {
+				metas = arguments[1].Components
+			}
+			decoded := make([]*Type, 0, len(metas))
+			for _, meta := range metas {
+				if meta == nil || meta.Kind != TypeMeta {
+					return unknownType
+				}
+				decoded = append(decoded, meta.Actual.WithLocation(LocationMemory, false))
+			}
+			return returnType(decoded)
+		case "abiencode", "abiencodepacked", "abiencodewithselector", "abiencodewithsignature", "abiencodecall",
+			"bytesconcat", "stringconcat", "creation", "event", "error":
+			// Variadic builtins, and calls whose parameters are not known.
+			if callee.FunctionKind == "event" || callee.FunctionKind == "error" {
+				c.checkArguments(node, callee.Parameters, argumentNodes, arguments, names)
+			}
+			return returnType(callee.Returns)
+		}
+
+		parameters := callee.Parameters
+		if callee.AttachedTo != nil && callee.FunctionKind != "arraypush" && callee.FunctionKind != "arraypop" && len(parameters) > 0 {
+			parameters = parameters[1:]
+		}
+		c.checkArguments(node, parameters, argumentNodes, arguments, names)
+		return returnType(callee.Returns)
+	}
+
+	return unknownType
+}
+
+// checkArguments checks the arguments of the call against the parameters. Calls with named
+// arguments are not checked, as the parameter names are not part of the types.
+func (c *TypeChecker) checkArguments(node Node[NodeType], parameters []*Type, argumentNodes []Node[NodeType], arguments []*Type, names []string) {
+	if len(names) > 0 {
+		return
+	}
+
+	if len(arguments) != len(parameters) {
+		c.errors = append(c.errors, &TypeError{
+			Node:    node,
+			Src:     node.GetSrc(),
+			Message: fmt.Sprintf("Wrong argument count for function call: %d arguments given but expected %d.", len(arguments), len(parameters)),
+		})
+		return
+	}
+
+	for i, argument := range arguments {
+		if !c.convertible(argument, parameters[i]) {
+			c.report(argumentNodes[i], "Invalid type for argument in function call. Invalid implicit conversion from %s to %s requested.", argument, parameters[i])
+		}
+	}
+}
+
+// newExpression determines the type of the contract creation or the array allocation.
+func (c *TypeChecker) newExpression(expression *NewExpr, scope *Scope) *Type {
+	t := c.typeName(expression.TypeName, scope)
+
+	switch t.Kind {
+	case TypeContract:
+		return &Type{Kind: TypeFunction, FunctionKind: "creation", Returns: []*Type{t}}
+	case TypeArray, TypeBytes, TypeString:
+		return &Type{
+			Kind:            TypeFunction,
+			FunctionKind:    "objectcreation",
+			StateMutability: "pure",
+			Parameters:      []*Type{uint256Type},
+			Returns:         []*Type{t.WithLocation(LocationMemory, false)},
+		}
+	}
+
+	return unknownType
+}
+
+// tuple determines the type of the tuple expression. Parenthesized expressions have the type of the
+// expression they enclose.
+func (c *TypeChecker) tuple(tuple *TupleExpression, scope *Scope) *Type {
+	if len(tuple.Components) == 1 && len(tuple.Omitted) == 0 {
+		return c.expression(tuple.Components[0], scope)
+	}
+
+	components := make([]*Type, 0, len(tuple.Components)+len(tuple.Omitted))
+	for _, component := range tuple.Components {
+		for slices.Contains(tuple.Omitted, len(components)) {
+			components = append(components, nil)
+		}
+		if IsNilNode(component) {
+			components = append(components, nil)
+			continue
+		}
+		components = append(components, c.expression(component, scope))
+	}
+	for slices.Contains(tuple.Omitted, len(components)) {
+		components = append(components, nil)
+	}
+	return NewTupleType(components...)
+}
+
+// inlineArray determines the type of the inline array, which is a memory array of the common type
+// of its elements.
+func (c *TypeChecker) inlineArray(array *InlineArray, scope *Scope) *Type {
+	var base *Type
+	for _, expression := range array.Expressions {
+		element := c.expression(expression, scope).Mobile()
+		if base == nil {
+			base = element
+			continue
+		}
+
+		if common := c.commonType(base, element); common != nil {
+			base = common
+		} else {
+			c.report(expression, "Unable to deduce common type for array elements.")
+			return unknownType
+		}
+	}
+
+	if base == nil || !base.IsKnown() {
+		return unknownType
+	}
+
+	return (&Type{Kind: TypeArray, Base: base, Length: int64(len(array.Expressions))}).WithLocation(LocationMemory, false)
+}
+
+// conditional determines the type of the conditional expression, which is the common type of its
+// true and false expressions.
+func (c *TypeChecker) conditional(conditional *Conditional, scope *Scope) *Type {
+	if len(conditional.Expressions) != 3 {
+		c.arguments(conditional.Expressions, scope)
+		return unknownType
+	}
+
+	c.condition(conditional.Expressions[0], scope)
+	trueType := c.expression(conditional.Expressions[1], scope).Mobile()
+	falseType := c.expression(conditional.Expressions[2], scope).Mobile()
+	if !trueType.IsKnown() || !falseType.IsKnown() {
+		return unknownType
+	}
+
+	common := c.commonType(trueType, falseType)
+	if common == nil {
+		c.report(conditional, "True expression's type %s does not match false expression's type %s.", trueType, falseType)
+		return unknownType
+	}
+	return common
+}
+
+// assignment determines the type of the assignment, which is the type of the assigned expression,
+// and checks that the assigned value is implicitly convertible to it.
+func (c *TypeChecker) assignment(assignment *Assignment, scope *Scope) *Type {
+	if IsNilNode(assignment.LeftExpression) {
+		return c.expression(assignment.Expression, scope)
+	}
+
+	left := c.expression(assignment.LeftExpression, scope)
+	right := c.expression(assignment.RightExpression, scope)
+
+	operator := assignmentOperator(assignment.Operator)
+	if operator == "" {
+		c.expect(assignment.RightExpression, right, left)
+		return left
+	}
+
+	if !left.IsKnown() || !right.IsKnown() {
+		return left
+	}
+
+	result, _, ok := c.binaryType(operator, left, right, scope, false)
+	if !ok {
+		c.report(assignment, "Operator "+operator+"= not compatible with types %s and %s.", left, right)
+		return left
+	}
+
+	c.expect(assignment.RightExpression, result, left)
+	return left
+}
+
+// unary determines the type of the unary operation.
+func (c *TypeChecker) unary(node Node[NodeType], operator ast_pb.Operator, expression Node[NodeType], scope *Scope) *Type {
+	operand := c.expression(expression, scope)
+
+	symbol := ""
+	switch operator {
+	case ast_pb.Operator_O_DEFAULT:
+		return emptyTuple
+	case ast_pb.Operator_NOT:
+		c.expect(expression, operand, boolType)
+		return boolType
+	case ast_pb.Operator_INCREMENT:
+		symbol = "++"
+	case ast_pb.Operator_DECREMENT:
+		symbol = "--"
+	case ast_pb.Operator_BIT_NOT:
+		symbol = "~"
+	case ast_pb.Operator_SUBTRACT:
+		symbol = "-"
+	default:
+		return unknownType
+	}
+
+	if !operand.IsKnown() {
+		return unknownType
+	}
+
+	switch operand.Kind {
+	case TypeRational:
+		if operand.Rational == nil {
+			return unknownType
+		}
+		switch symbol {
+		case "-":
+			return NewRationalType(new(big.Rat).Neg(operand.Rational))
+		case "~":
+			if operand.Rational.IsInt() {
+				return NewRationalType(new(big.Rat).SetInt(new(big.Int).Not(operand.Rational.Num())))
+			}
+		}
+	case TypeInteger:
+		if symbol == "-" && !operand.Signed {
+			c.report(node, "Built-in unary operator - cannot be applied to type %s. Unary negation is only allowed for signed integers.", operand)
+			return unknownType
+		}
+		return operand
+	case TypeFixedBytes:
+		if symbol == "~" {
+			return operand
+		}
+	case TypeUserDefinedValue:
+		if function := c.userOperator(symbol, operand, 1, scope); function != nil {
+			c.operators[node.GetId()] = function.GetId()
+			return returnType(c.functionType(function, "internal", scope).Returns)
+		}
+	}
+
+	c.report(node, "Built-in unary operator "+symbol+" cannot be applied to type %s.", operand)
+	return unknownType
+}
+
+// binaryList determines the type of the binary operation stored as a list of two expressions.
+func (c *TypeChecker) binaryList(node Node[NodeType], operator string, expressions []Node[NodeType], scope *Scope) *Type {
+	if len(expressions) != 2 {
+		c.arguments(expressions, scope)
+		return unknownType
+	}
+	return c.binary(node, operator, expressions[0], expressions[1], scope)
+}
+
+// binary determines the type of the binary operation and reports operators applied to types they
+// are not defined for.
+func (c *TypeChecker) binary(node Node[NodeType], operator string, leftNode Node[NodeType], rightNode Node[NodeType], scope *Scope) *Type {
+	left := c.expression(leftNode, scope)
+	right := c.expression(rightNode, scope)
+
+	if !left.IsKnown() || !right.IsKnown() || operator == "" {
+		if isComparison(operator) || operator == "&&" || operator == "||" {
+			return boolType
+		}
+		return unknownType
+	}
+
+	result, function, ok := c.binaryType(operator, left, right, scope, true)
+	if function != nil {
+		c.operators[node.GetId()] = function.GetId()
+	}
+
+	if !ok {
+		if message, isMessage := result.messageOf(); isMessage {
+			c.errors = append(c.errors, &TypeError{Node: node, Src: node.GetSrc(), Message: message})
+		} else {
+			c.report(node, "Built-in binary operator "+operator+" cannot be applied to types %s and %s.", left, right)
+		}
+
+		if isComparison(operator) || operator == "&&" || operator == "||" {
+			return boolType
+		}
+		return unknownType
+	}
+
+	return result
+}
+
+// binaryType returns the type of the result of the binary operator applied to the types. User
+// defined operators are only looked up if requested, as they cannot be used in compound assignments.
+// The function implementing the user defined operator is returned along with the result.
+func (c *TypeChecker) binaryType(operator string, left *Type, right *Type, scope *Scope, userDefined bool) (*Type, *Function, bool) {
+	comparison := isComparison(operator)
+
+	if left.Kind == TypeUserDefinedValue || right.Kind == TypeUserDefinedValue {
+		if !userDefined || left.Kind != TypeUserDefinedValue || !c.convertible(right, left) {
+			return nil, nil, false
+		}
+
+		function := c.userOperator(operator, left, 2, scope)
+		if function == nil {
+			return nil, nil, false
+		}
+		return returnType(c.functionType(function, "internal", scope).Returns), function, true
+	}
+
+	if operator == "&&" || operator == "||" {
+		return boolType, nil, left.Kind == TypeBool && right.Kind == TypeBool
+	}
+
+	if left.Kind == TypeRational && right.Kind == TypeRational && left.Rational != nil && right.Rational != nil {
+		folded, message := foldRationals(operator, left.Rational, right.Rational)
+		if message != "" {
+			return errorMessage(message), nil, false
+		}
+		if folded != nil {
+			return folded, nil, true
+		}
+	}
+
+	switch operator {
+	case "<<", ">>":
+		if !right.IsInteger() || (right.Kind == TypeInteger && right.Signed) {
+			return nil, nil, false
+		}
+		switch left.Kind {
+		case TypeRational:
+			// Shifts of literals by non-literals use the 256 bit type of the literal.
+			if !left.IsInteger() {
+				return nil, nil, false
+			}
+			return NewIntegerType(256, left.Rational.Sign() < 0), nil, true
+		case TypeInteger, TypeFixedBytes:
+			return left, nil, true
+		}
+		return nil, nil, false
+	case "**":
+		if !right.IsInteger() || (right.Kind == TypeInteger && right.Signed) {
+			return nil, nil, false
+		}
+		switch left.Kind {
+		case TypeRational:
+			// Exponentiation of literals by non-literals uses the 256 bit type of the literal.
+			if !left.IsInteger() {
+				return nil, nil, false
+			}
+			return NewIntegerType(256, left.Rational.Sign() < 0), nil, true
+		case TypeInteger:
+			return left, nil, true
+		}
+		return nil, nil, false
+	}
+
+	common := c.commonType(left, right)
+	if common == nil {
+		return nil, nil, false
+	}
+
+	switch {
+	case operator == "==" || operator == "!=":
+		switch common.Kind {
+		case TypeBool, TypeInteger, TypeAddress, TypeFixedBytes, TypeEnum, TypeContract:
+			return boolType, nil, true
+		case TypeFunction:
+			return boolType, nil, common.FunctionKind == "external" || common.FunctionKind == "internal"
+		}
+		return nil, nil, false
+	case comparison:
+		switch common.Kind {
+		case TypeInteger, TypeAddress, TypeFixedBytes, TypeEnum:
+			return boolType, nil, true
+		}
+		return nil, nil, false
+	case operator == "&" || operator == "|" || operator == "^":
+		return common, nil, common.Kind == TypeInteger || common.Kind == TypeFixedBytes
+	default:
+		return common, nil, common.Kind == TypeInteger
+	}
+}
+
+// commonType returns the type both of the types are implicitly convertible to, or nil if there is
+// no such type. Constants are converted to the smallest type they fit into when both are constant.
+func (c *TypeChecker) commonType(a *Type, b *Type) *Type {
+	if a.Kind == TypeRational && b.Kind == TypeRational {
+		a, b = a.Mobile(), b.Mobile()
+	}
+
+	switch {
+	case c.convertible(a, b):
+		return b
+	case c.convertible(b, a):
+		return a
+	}
+	return nil
+}
+
+// userOperator returns the function implementing the operator for the user defined value type,
+// attached with using for directives visible from the scope.
+func (c *TypeChecker) userOperator(operator string, operand *Type, arity int, scope *Scope) *Function {
+	for current := scope; current != nil; current = current.GetParent() {
+		for _, using := range current.GetUsingDirectives() {
+			target := c.typeName(using.TypeName, current)
+			if target.Kind != TypeUserDefinedValue || target.Declaration != operand.Declaration {
+				continue
+			}
+
+			for _, attached := range using.GetFunctions() {
+				if attached.GetOperator() != operator {
+					continue
+				}
+
+				reference := c.symbols.GetReference(attached.GetId())
+				if reference == 0 {
+					reference = attached.GetReferencedDeclaration()
+				}
+
+				if function, ok := c.declaration(reference).(*Function); ok {
+					if count, _ := parameterCount(function); count == arity {
+						return function
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// declaration returns the node with the given id.
+func (c *TypeChecker) declaration(id int64) Node[NodeType] {
+	if id == 0 {
+		return nil
+	}
+	if node, ok := c.nodes[id]; ok {
+		return node
+	}
+	return c.symbols.getDeclaration(id)
+}
+
+// currentContract returns the contract enclosing the scope.
+func (c *TypeChecker) currentContract(scope *Scope) Node[NodeType] {
+	if scope != nil {
+		if contract := scope.GetContract(); contract != nil {
+			return contract.GetNode()
+		}
+	}
+	return c.contract
+}
+
+// declarationType returns the type of the expression referring to the declaration. The name selects
+// the variable within variable declaration statements declaring more than one variable.
+func (c *TypeChecker) declarationType(declaration Node[NodeType], name string, scope *Scope) *Type {
+	switch declarationCtx := declaration.(type) {
+	case *StateVariableDeclaration:
+		return c.typeName(declarationCtx.TypeName, c.declarationScope(declaration, scope)).WithLocation(LocationStorage, false)
+	case *Parameter:
+		if enum, ok := c.enums[declarationCtx.GetId()]; ok {
+			return c.userType(enum)
+		}
+		return c.parameterType(declarationCtx, scope)
+	case *VariableDeclaration:
+		for _, variable := range declarationCtx.Declarations {
+			if variable != nil && (variable.GetName() == name || len(declarationCtx.Declarations) == 1) {
+				return c.variableType(variable, scope)
+			}
+		}
+	case *Declaration:
+		return c.variableType(declarationCtx, scope)
+	case *Function:
+		return c.functionType(declarationCtx, "internal", scope)
+	case *EventDefinition:
+		return &Type{Kind: TypeFunction, FunctionKind: "event", Parameters: c.parameterTypes(declarationCtx.Parameters, scope)}
+	case *ErrorDefinition:
+		return &Type{Kind: TypeFunction, FunctionKind: "error", StateMutability: "pure", Parameters: c.parameterTypes(declarationCtx.Parameters, scope)}
+	case *Contract, *Library, *Interface, *EnumDefinition, *UserDefinedValueTypeDefinition:
+		return &Type{Kind: TypeMeta, Actual: c.userType(declaration)}
+	case *StructDefinition:
+		return &Type{Kind: TypeMeta, Actual: c.userType(declaration).WithLocation(LocationStorage, true)}
+	}
+
+	return unknownType
+}
+
+// declarationScope returns the scope type names of the contract member are resolved in.
+func (c *TypeChecker) declarationScope(declaration Node[NodeType], scope *Scope) *Scope {
+	if parent, ok := c.parents[declaration.GetId()]; ok {
+		if contract := c.symbols.GetScope(parent.GetId()); contract != nil {
+			return contract
+		}
+	}
+	return scope
+}
+
+// variableType returns the type of the local variable, at its data location.
+func (c *TypeChecker) variableType(variable *Declaration, scope *Scope) *Type {
+	location := locationOf(variable.StorageLocation)
+	return c.typeName(variable.TypeName, scope).WithLocation(location, location == LocationStorage)
+}
+
+// parameterType returns the type of the parameter, at its data location. Reference types without
+// the data location are in memory.
+func (c *TypeChecker) parameterType(parameter *Parameter, scope *Scope) *Type {
+	t := c.typeName(parameter.TypeName, scope)
+	location := locationOf(parameter.StorageLocation)
+	if location == LocationNone {
+		location = LocationMemory
+	}
+	return t.WithLocation(location, location == LocationStorage)
+}
+
+// parameterTypes returns types of the parameters of the list.
+func (c *TypeChecker) parameterTypes(parameters *ParameterList, scope *Scope) []*Type {
+	if parameters == nil {
+		return nil
+	}
+
+	toReturn := make([]*Type, 0, len(parameters.GetParameters()))
+	for _, parameter := range parameters.GetParameters() {
+		if parameter == nil {
+			toReturn = append(toReturn, unknownType)
+			continue
+		}
+		toReturn = append(toReturn, c.parameterType(parameter, scope))
+	}
+	return toReturn
+}
+
+// functionType returns the type of the function, called internally or externally.
+func (c *TypeChecker) functionType(function *Function, kind string, scope *Scope) *Type {
+	scope = c.declarationScope(function, scope)
+	if functionScope := c.symbols.GetScope(function.GetId()); functionScope != nil {
+		scope = functionScope
+	}
+
+	toReturn := &Type{
+		Kind:            TypeFunction,
+		FunctionKind:    kind,
+		StateMutability: mutabilityString(function.GetStateMutability()),
+		Parameters:      c.parameterTypes(function.GetParameters(), scope),
+		Returns:         c.parameterTypes(function.GetReturnParameters(), scope),
+	}
+
+	// Arguments of external calls are ABI encoded from memory, and return values decoded into it.
+	if kind == "external" {
+		for _, types := range [][]*Type{toReturn.Parameters, toReturn.Returns} {
+			for i, t := range types {
+				if t.IsReference() {
+					types[i] = t.WithLocation(LocationMemory, false)
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// structMember returns the type of the member of the struct, at the data location of the struct.
+func (c *TypeChecker) structMember(structType *Type, name string, scope *Scope) *Type {
+	definition, ok := c.declaration(structType.Declaration).(*StructDefinition)
+	if !ok {
+		return unknownType
+	}
+
+	scope = c.declarationScope(definition, scope)
+	for _, member := range definition.GetMembers() {
+		if member.GetName() == name {
+			return c.typeName(member.TypeName, scope).WithLocation(structType.Location, false)
+		}
+	}
+	return unknownType
+}
+
+// userType returns the type of the contract, struct, enum or user defined value type declaration.
+// Structs are returned without data location.
+func (c *TypeChecker) userType(declaration Node[NodeType]) *Type {
+	if canonical, ok := c.nodes[declaration.GetId()]; ok {
+		declaration = canonical
+	}
+
+	switch declarationCtx := declaration.(type) {
+	case *Contract:
+		return &Type{Kind: TypeContract, Name: declarationCtx.GetName(), Declaration: declaration.GetId(), ContractKind: "contract"}
+	case *Library:
+		return &Type{Kind: TypeContract, Name: declarationCtx.GetName(), Declaration: declaration.GetId(), ContractKind: "library"}
+	case *Interface:
+		return &Type{Kind: TypeContract, Name: declarationCtx.GetName(), Declaration: declaration.GetId(), ContractKind: "interface"}
+	case *StructDefinition:
+		return &Type{Kind: TypeStruct, Name: declarationCtx.GetName(), CanonicalName: c.canonicalName(declaration), Declaration: declaration.GetId()}
+	case *EnumDefinition:
+		return &Type{Kind: TypeEnum, Name: declarationCtx.GetName(), CanonicalName: c.canonicalName(declaration), Declaration: declaration.GetId()}
+	case *UserDefinedValueTypeDefinition:
+		underlying := c.typeName(declarationCtx.TypeName, nil)
+		if !underlying.IsKnown() {
+			if elementary := elementaryType(declarationCtx.Type); elementary != nil {
+				underlying = elementary
+			}
+		}
+		return &Type{
+			Kind:          TypeUserDefinedValue,
+			Name:          declarationCtx.GetName(),
+			CanonicalName: c.canonicalName(declaration),
+			Declaration:   declaration.GetId(),
+			Underlying:    underlying,
+		}
+	}
+
+	return unknownType
+}
+
+// canonicalName returns the name of the declaration qualified with the name of its contract.
+func (c *TypeChecker) canonicalName(declaration Node[NodeType]) string {
+	if parent, ok := c.parents[declaration.GetId()]; ok {
+		return getName(parent) + "." + getName(declaration)
+	}
+	return getName(declaration)
+}
+
+// typeName returns the type of the type name, without data location. User defined types are looked
+// up within the scope unless the type name is resolved already.
+func (c *TypeChecker) typeName(typeName *TypeName, scope *Scope) *Type {
+	if typeName == nil {
+		return unknownType
+	}
+
+	if cached, ok := c.cache[typeName][scope]; ok {
+		return cached
+	}
+
+	var toReturn *Type
+	if typeName.KeyType != nil || typeName.ValueType != nil {
+		// Keys are hashed from memory, while values are always in storage.
+		toReturn = &Type{
+			Kind:      TypeMapping,
+			KeyType:   c.typeName(typeName.KeyType, scope).WithLocation(LocationMemory, false),
+			ValueType: c.typeName(typeName.ValueType, scope).WithLocation(LocationStorage, false),
+		}
+	} else {
+		name := typeName.Name
+		if name == "" && typeName.PathNode != nil {
+			name = typeName.PathNode.Name
+		}
+		toReturn = c.typeNameString(typeName, name, scope)
+	}
+
+	if c.cache[typeName] == nil {
+		c.cache[typeName] = make(map[*Scope]*Type)
+	}
+	c.cache[typeName][scope] = toReturn
+	return toReturn
+}
+
+// typeNameString returns the type of the type name written as text, such as uint256[3][] or
+// Info[], where user defined types are resolved for the type name node.
+func (c *TypeChecker) typeNameString(typeName *TypeName, name string, scope *Scope) *Type {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasPrefix(name, "function") || strings.HasPrefix(name, "mapping") {
+		return unknownType
+	}
+
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndex(name, "[")
+		if open <= 0 {
+			return unknownType
+		}
+
+		base := c.typeNameString(typeName, name[:open], scope)
+		if !base.IsKnown() {
+			return unknownType
+		}
+
+		array := &Type{Kind: TypeArray, Base: base, Length: -1}
+		if length := strings.TrimSpace(name[open+1 : len(name)-1]); length != "" {
+			if value, err := strconv.ParseInt(length, 0, 64); err == nil {
+				array.Length = value
+			} else {
+				array.LengthText = length
+			}
+		}
+		return array
+	}
+
+	if name == "address" && typeName.StateMutability == ast_pb.Mutability_PAYABLE {
+		return &Type{Kind: TypeAddress, Payable: true}
+	}
+	if elementary := elementaryType(name); elementary != nil {
+		return elementary
+	}
+
+	var declaration Node[NodeType]
+	if reference := c.declaration(c.symbols.GetReference(typeName.GetId())); reference != nil && isTypeDeclaration(reference) && getName(reference) == lastSegment(name) {
+		declaration = reference
+	} else if scope != nil {
+		declaration = c.symbols.lookupPath(scope, name, isTypeDeclaration)
+	}
+	if declaration == nil {
+		if reference := c.declaration(typeName.GetReferencedDeclaration()); reference != nil && isTypeDeclaration(reference) && getName(reference) == lastSegment(name) {
+			declaration = reference
+		}
+	}
+
+	if declaration == nil {
+		return unknownType
+	}
+	return c.userType(declaration)
+}
+
+// errorMessage wraps the message of the error found while determining the type, so that it can be
+// reported in place of the generic message.
+func errorMessage(message string) *Type {
+	return &Type{Kind: TypeUnknown, Name: message}
+}
+
+// messageOf returns the message wrapped by errorMessage.
+func (t *Type) messageOf() (string, bool) {
+	if t == nil || t.Kind != TypeUnknown || t.Name == "" {
+		return "", false
+	}
+	return t.Name, true
+}
+
+// returnType returns the type of the call returning values of the given types.
+func returnType(returns []*Type) *Type {
+	if len(returns) == 1 {
+		return returns[0]
+	}
+	if returns == nil {
+		return emptyTuple
+	}
+	return NewTupleType(returns...)
+}
+
+// foldRationals evaluates the binary operator on the constants. It returns nil if the operator is
+// not evaluated on constants, and a message if the evaluation fails.
+func foldRationals(operator string, left *big.Rat, right *big.Rat) (*Type, string) {
+	integers := left.IsInt() && right.IsInt()
+
+	var result *big.Rat
+	switch operator {
+	case "+":
+		result = new(big.Rat).Add(left, right)
+	case "-":
+		result = new(big.Rat).Sub(left, right)
+	case "*":
+		result = new(big.Rat).Mul(left, right)
+	case "/":
+		if right.Sign() == 0 {
+			return nil, "Division by zero."
+		}
+		result = new(big.Rat).Quo(left, right)
+	case "%":
+		if right.Sign() == 0 {
+			return nil, "Modulo zero."
+		}
+		if !integers {
+			return nil, ""
+		}
+		result = new(big.Rat).SetInt(new(big.Int).Rem(left.Num(), right.Num()))
+	case "**":
+		if !right.IsInt() || right.Num().BitLen() > 32 {
+			return unknownType, ""
+		}
+		exponent := right.Num().Int64()
+		if exponent < 0 {
+			exponent = -exponent
+		}
+		if int64(max(left.Num().BitLen(), left.Denom().BitLen()))*exponent > 4096 {
+			return unknownType, ""
+		}
+		numerator := new(big.Int).Exp(left.Num(), big.NewInt(exponent), nil)
+		denominator := new(big.Int).Exp(left.Denom(), big.NewInt(exponent), nil)
+		if right.Sign() < 0 {
+			if numerator.Sign() == 0 {
+				return nil, "Division by zero."
+			}
+			numerator, denominator = denominator, numerator
+		}
+		result = new(big.Rat).SetFrac(numerator, denominator)
+	case "&", "|", "^", "<<", ">>":
+		if !integers {
+			return nil, ""
+		}
+		value := new(big.Int)
+		switch operator {
+		case "&":
+			value.And(left.Num(), right.Num())
+		case "|":
+			value.Or(left.Num(), right.Num())
+		case "^":
+			value.Xor(left.Num(), right.Num())
+		case "<<", ">>":
+			if right.Sign() < 0 || right.Num().BitLen() > 16 || right.Num().Int64() > 4096 {
+				return unknownType, ""
+			}
+			if operator == "<<" {
+				value.Lsh(left.Num(), uint(right.Num().Int64()))
+			} else {
+				value.Rsh(left.Num(), uint(right.Num().Int64()))
+			}
+		}
+		result = new(big.Rat).SetInt(value)
+	case "==", "!=", "<", "<=", ">", ">=":
+		return boolType, ""
+	default:
+		return nil, ""
+	}
+
+	if result.Num().BitLen() > 4096 || result.Denom().BitLen() > 4096 {
+		return unknownType, ""
+	}
+	return NewRationalType(result), ""
+}
+
+// subdenominations are the multipliers of the number literal units, longest suffixes first.
+var subdenominations = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"seconds", 1}, {"minutes", 60}, {"hours", 3600}, {"days", 86400}, {"weeks", 604800}, {"years", 31536000},
+	{"finney", 1e15}, {"szabo", 1e12}, {"ether", 1e18}, {"gwei", 1e9}, {"wei", 1},
+}
+
+// pragmaMinor returns the lowest minor version of the compiler allowed by the solidity pragma of
+// the source unit, or the latest minor version if the source unit has no such pragma.
+func pragmaMinor(unit *SourceUnit[Node[ast_pb.SourceUnit]]) int {
+	for _, pragma := range unit.GetPragmas() {
+		if !strings.Contains(pragma.GetText(), "solidity") {
+			continue
+		}
+
+		if match := versionPattern.FindStringSubmatch(pragma.GetText()); match != nil {
+			if minor, err := strconv.Atoi(match[1]); err == nil {
+				return minor
+			}
+		}
+	}
+	return 8
+}
+
+// numberLiteralType returns the type of the number literal, which is the constant of its value.
+// Hex literals of 40 digits are addresses, other hex literals keep their digits.
+func numberLiteralType(literal string) *Type {
+	text := strings.ReplaceAll(strings.TrimSpace(literal), "_", "")
+
+	multiplier := big.NewRat(1, 1)
+	for _, unit := range subdenominations {
+		if strings.HasSuffix(text, unit.suffix) && !strings.HasPrefix(text, "0x") {
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix))
+			multiplier.SetInt64(unit.multiplier)
+			break
+		}
+	}
+
+	if strings.HasPrefix(text, "0x") || strings.HasPrefix(text, "0X") {
+		if len(text) == 42 {
+			return addressType
+		}
+		value, ok := new(big.Int).SetString(text[2:], 16)
+		if !ok {
+			return unknownType
+		}
+		// The digits are kept, as hex literals convert to fixed bytes of the same width.
+		toReturn := NewRationalType(new(big.Rat).SetInt(value))
+		toReturn.Hex, toReturn.Literal = true, text[2:]
+		return toReturn
+	}
+
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return unknownType
+	}
+	return NewRationalType(value.Mul(value, multiplier))
+}
+
+// binaryOperator returns the symbol of the operator of the binary operation.
+func binaryOperator(operator ast_pb.Operator) string {
+	switch operator {
+	case ast_pb.Operator_ADDITION:
+		return "+"
+	case ast_pb.Operator_SUBTRACTION:
+		return "-"
+	case ast_pb.Operator_MULTIPLICATION:
+		return "*"
+	case ast_pb.Operator_DIVISION:
+		return "/"
+	case ast_pb.Operator_MODULO:
+		return "%"
+	case ast_pb.Operator_EXPONENTIATION:
+		return "**"
+	case ast_pb.Operator_GREATER_THAN:
+		return ">"
+	case ast_pb.Operator_GREATER_THAN_OR_EQUAL:
+		return ">="
+	case ast_pb.Operator_LESS_THAN:
+		return "<"
+	case ast_pb.Operator_LESS_THAN_OR_EQUAL:
+		return "<="
+	case ast_pb.Operator_EQUAL:
+		return "=="
+	case ast_pb.Operator_NOT_EQUAL:
+		return "!="
+	case ast_pb.Operator_OR:
+		return "||"
+	}
+	return ""
+}
+
+// assignmentOperator returns the symbol of the binary operator of the compound assignment, or an
+// empty string for plain assignments.
+func assignmentOperator(operator ast_pb.Operator) string {
+	switch operator {
+	case ast_pb.Operator_PLUS_EQUAL:
+		return "+"
+	case ast_pb.Operator_MINUS_EQUAL:
+		return "-"
+	case ast_pb.Operator_MUL_EQUAL:
+		return "*"
+	case ast_pb.Operator_DIVISION, ast_pb.Operator_DIV_EQUAL:
+		return "/"
+	case ast_pb.Operator_MOD_EQUAL:
+		return "%"
+	case ast_pb.Operator_AND_EQUAL, ast_pb.Operator_BIT_AND_EQUAL:
+		return "&"
+	case ast_pb.Operator_OR_EQUAL, ast_pb.Operator_BIT_OR_EQUAL:
+		return "|"
+	case ast_pb.Operator_XOR_EQUAL, ast_pb.Operator_BIT_XOR_EQUAL:
+		return "^"
+	case ast_pb.Operator_SHIFT_LEFT_EQUAL:
+		return "<<"
+	case ast_pb.Operator_SHIFT_RIGHT_EQUAL, ast_pb.Operator_POW_EQUAL:
+		return ">>"
+	}
+	return ""
+}
+
+// isComparison returns true for operators comparing their operands.
+func isComparison(operator string) bool {
+	switch operator {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// isExpression returns true if the node is an expression typed by the TypeChecker.
+func isExpression(node Node[NodeType]) bool {
+	switch node.(type) {
+	case *PrimaryExpression, *MemberAccessExpression, *IndexAccess, *IndexRange, *FunctionCall,
+		*FunctionCallOption, *NewExpr, *PayableConversion, *MetaType, *TupleExpression, *InlineArray,
+		*Conditional, *Assignment, *UnaryPrefix, *UnarySuffix, *BinaryOperation, *AndOperation,
+		*BitAndOperation, *BitOrOperation, *BitXorOperation, *ShiftOperation, *ExprOperation:
+		return true
+	}
+	return false
+}
+
+// lastSegment returns the last segment of the qualified name.
+func lastSegment(name string) string {
+	_, toReturn := cutLast(name, ".")
+	return toReturn
+}
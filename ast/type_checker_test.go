@@ -0,0 +1,348 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+)
+
+const typeCheckerContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+type Price is uint128;
+
+function add(Price a, Price b) pure returns (Price) {
+    return Price.wrap(Price.unwrap(a) + Price.unwrap(b));
+}
+
+using {add as +} for Price global;
+
+contract Market {
+    struct Order { address owner; uint64 amount; }
+
+    mapping(address => Order[]) internal orders;
+    uint8 internal small;
+
+    function total(Price a, Price b) public pure returns (Price) {
+        return a + b;
+    }
+
+    function literals() public pure returns (uint256) {
+        uint256 sum = 1 + 2;
+        int8 negative = -1;
+        uint256 half = 1 / 2 * 4;
+        return sum + uint256(int256(negative)) + half;
+    }
+
+    function access(address owner) public view returns (uint64, address) {
+        Order storage order = orders[owner][0];
+        return (order.amount, order.owner);
+    }
+
+    function invalid() public {
+        small = 256;
+        bool flag = 1 + true;
+    }
+}`
+
+func TestTypeChecker(t *testing.T) {
+	b := buildSymbolsTree(t, typeCheckerContent)
+	checker := b.GetTypeChecker()
+	require.NotNil(t, checker)
+
+	descriptions := make(map[string][]*TypeDescription)
+	for _, node := range findNodes(b, isExpression) {
+		if description := node.GetTypeDescription(); description != nil {
+			text := typeCheckerContent[node.GetSrc().Start : node.GetSrc().End+1]
+			descriptions[text] = append(descriptions[text], description)
+		}
+	}
+	describe := func(text string) *TypeDescription {
+		require.NotEmpty(t, descriptions[text], text)
+		return descriptions[text][0]
+	}
+
+	// Integer literals are constants of their exact rational value.
+	assert.Equal(t, &TypeDescription{TypeString: "int_const 3", TypeIdentifier: "t_rational_3_by_1"}, describe("1 + 2"))
+	assert.Equal(t, &TypeDescription{TypeString: "int_const -1", TypeIdentifier: "t_rational_minus_1_by_1"}, describe("-1"))
+	assert.Equal(t, &TypeDescription{TypeString: "rational_const 1 / 2", TypeIdentifier: "t_rational_1_by_2"}, describe("1 / 2"))
+	assert.Equal(t, "int_const 2", describe("1 / 2 * 4").GetString())
+
+	// Operators of user defined value types are the functions attached to them.
+	globals := make(map[string]Node[NodeType])
+	for _, node := range b.GetRoot().GetGlobalNodes() {
+		globals[getName(node)] = node
+	}
+	price, add := globals["Price"], globals["add"]
+	require.NotNil(t, price)
+	require.NotNil(t, add)
+	sum := findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*BinaryOperation)
+		return ok && typeCheckerContent[node.GetSrc().Start:node.GetSrc().End+1] == "a + b"
+	})
+	require.Len(t, sum, 1)
+	assert.Equal(t, &TypeDescription{
+		TypeString:     "Price",
+		TypeIdentifier: fmt.Sprintf("t_userDefinedValueType$_Price_$%d", price.GetId()),
+	}, sum[0].GetTypeDescription())
+	assert.Equal(t, add.GetId(), checker.GetOperatorFunction(sum[0].GetId()))
+
+	// Index and member accesses follow the data location of their base.
+	order := findNamed[*StructDefinition](t, b, "Order")
+	assert.Equal(t, "mapping(address => struct Market.Order[])", describe("orders").GetString())
+	assert.Equal(t, &TypeDescription{
+		TypeString:     "struct Market.Order[] storage ref",
+		TypeIdentifier: fmt.Sprintf("t_array$_t_struct$_Order_$%d_storage_$dyn_storage", order.GetId()),
+	}, describe("orders[owner]"))
+	assert.Equal(t, "struct Market.Order storage ref", describe("orders[owner][0]").GetString())
+	assert.Equal(t, "uint64", describe("order.amount").GetString())
+	assert.Equal(t, &TypeDescription{
+		TypeString:     "tuple(uint64,address)",
+		TypeIdentifier: "t_tuple$_t_uint64_$_t_address_$",
+	}, describe("(order.amount, order.owner)"))
+
+	// Type errors are reported where implicit conversions or operators are not possible.
+	messages := make([]string, 0)
+	for _, typeError := range b.GetTypeErrors() {
+		messages = append(messages, typeError.Error())
+	}
+	assert.Equal(t, []string{
+		"Type int_const 256 is not implicitly convertible to expected type uint8.",
+		"Built-in binary operator + cannot be applied to types int_const 1 and bool.",
+	}, messages)
+}
+
+func TestTypeCheckerExternalCalls(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+interface IRegistry {
+    function set(string calldata name, uint256[] calldata values) external;
+    function get() external view returns (string memory);
+}
+
+contract Caller {
+    function forward(IRegistry registry, string memory name, uint256[] memory values) public returns (bool) {
+        registry.set(name, values);
+        string memory stored = registry.get();
+        (bool success, ) = address(registry).call(bytes(stored));
+        return success;
+    }
+}`
+
+	b := buildSymbolsTree(t, content)
+	require.NotNil(t, b.GetTypeChecker())
+
+	// Memory arguments are accepted by calldata parameters of external functions, and calls
+	// returning tuples are not matched against the only declared component.
+	assert.Empty(t, b.GetTypeErrors())
+
+	set := findNodes(b, func(node Node[NodeType]) bool {
+		_, ok := node.(*MemberAccessExpression)
+		return ok && content[node.GetSrc().Start:node.GetSrc().End+1] == "registry.set"
+	})
+	require.Len(t, set, 1)
+	assert.Equal(t, "function (string memory,uint256[] memory) external", set[0].GetTypeDescription().GetString())
+}
+
+func TestTypeCheckerWithResolverErrors(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.19;
+
+contract Market {
+    uint8 internal small;
+
+    function invalid() public {
+        small = 256 + missing;
+        small = 256;
+    }
+}`
+
+	_, b := parseTestSources(t, newTestSources("Market", &solgo.SourceUnit{Name: "Market", Path: "Market.sol", Content: content}))
+
+	// Type errors are still reported when the resolver fails on the undeclared identifier.
+	assert.NotEmpty(t, b.ResolveReferences())
+	require.NotNil(t, b.GetTypeChecker())
+
+	messages := make([]string, 0)
+	for _, typeError := range b.GetTypeErrors() {
+		messages = append(messages, typeError.Error())
+	}
+	assert.Contains(t, messages, "Type int_const 256 is not implicitly convertible to expected type uint8.")
+}
+
+func TestTypeCheckerLibraries(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+library Counters {
+    struct Counter { uint256 _value; }
+    function current(Counter storage counter) internal view returns (uint256) { return counter._value; }
+    function increment(Counter storage counter) internal { unchecked { counter._value += 1; } }
+}
+
+library EnumerableSet {
+    struct Set { bytes32[] _values; }
+    struct AddressSet { Set _inner; }
+    struct UintSet { Set _inner; }
+    function add(AddressSet storage set, address value) internal returns (bool) { return true; }
+    function add(UintSet storage set, uint256 value) internal returns (bool) { return true; }
+    function at(AddressSet storage set, uint256 index) internal view returns (address) { return address(0); }
+    function at(UintSet storage set, uint256 index) internal view returns (uint256) { return index; }
+}
+
+interface IERC165 {
+    function supportsInterface(bytes4 interfaceId) external view returns (bool);
+}
+
+interface IERC721 is IERC165 {
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+    function balanceOf(address owner) external view returns (uint256 balance);
+}
+
+interface IERC20 {
+    function transfer(address to, uint256 amount) external returns (bool);
+}
+
+library SafeERC20 {
+    function safeTransfer(IERC20 token, address to, uint256 value) internal { token.transfer(to, value); }
+}
+
+abstract contract ERC165 is IERC165 {
+    function supportsInterface(bytes4 interfaceId) public view virtual override returns (bool) {
+        return interfaceId == type(IERC165).interfaceId;
+    }
+}
+
+contract ERC721 is ERC165, IERC721 {
+    using Counters for Counters.Counter;
+    using EnumerableSet for EnumerableSet.AddressSet;
+    using EnumerableSet for EnumerableSet.UintSet;
+    using SafeERC20 for IERC20;
+
+    bytes4 private constant _INTERFACE_ID_ERC721 = 0x80ac58cd;
+    bytes32 private constant _SLOT = 0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc;
+    uint256 public constant DELAY = 24 hours;
+
+    Counters.Counter private _ids;
+    EnumerableSet.AddressSet private _owners;
+    EnumerableSet.UintSet private _tokens;
+    mapping(address => uint256) public override balanceOf;
+
+    function supportsInterface(bytes4 interfaceId) public view virtual override(ERC165, IERC165) returns (bool) {
+        return interfaceId == _INTERFACE_ID_ERC721 || interfaceId == 0x01ffc9a7 || super.supportsInterface(interfaceId);
+    }
+
+    function mint(IERC20 token) public returns (address owner, uint256 id) {
+        _ids.increment();
+        id = _ids.current();
+        _owners.add(msg.sender);
+        _tokens.add(id);
+        token.safeTransfer(msg.sender, this.balanceOf(msg.sender));
+        emit Transfer({from: address(0), to: msg.sender, tokenId: id});
+        (, owner) = (_tokens.at(0), _owners.at(0));
+    }
+}`
+
+	b := buildSymbolsTree(t, content)
+	require.NotNil(t, b.GetTypeChecker())
+
+	// Functions attached with using for take the expression as their first argument, overloads are
+	// picked by argument types, and hex literals convert to fixed bytes of their width.
+	messages := make([]string, 0)
+	for _, typeError := range b.GetTypeErrors() {
+		messages = append(messages, typeError.Error())
+	}
+	assert.Empty(t, messages)
+
+	describe := func(text string, kind any) *TypeDescription {
+		for _, node := range findNodes(b, isExpression) {
+			if content[node.GetSrc().Start:node.GetSrc().End+1] == text && reflect.TypeOf(node) == reflect.TypeOf(kind) {
+				return node.GetTypeDescription()
+			}
+		}
+		return nil
+	}
+	assert.Equal(t, "function (struct Counters.Counter storage pointer)", describe("_ids.increment", &MemberAccessExpression{}).GetString())
+	assert.Equal(t, "address", describe("_owners.at(0)", &FunctionCall{}).GetString())
+	assert.Equal(t, "uint256", describe("_tokens.at(0)", &FunctionCall{}).GetString())
+	assert.Equal(t, "int_const 86400", describe("24 hours", &PrimaryExpression{}).GetString())
+}
+
+func TestTypeCheckerHexLiterals(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Literals {
+    bytes4 internal narrow = 0x80ac58cd00;
+    bytes4 internal wide = 0x80ac;
+    bytes4 internal zero = 0;
+}`
+
+	b := buildSymbolsTree(t, content)
+	require.NotNil(t, b.GetTypeChecker())
+
+	// Only hex literals of exactly the width of the fixed bytes, and zero, are convertible to them.
+	messages := make([]string, 0)
+	for _, typeError := range b.GetTypeErrors() {
+		messages = append(messages, typeError.Error())
+	}
+	assert.Equal(t, []string{
+		"Type int_const 552647314688 is not implicitly convertible to expected type bytes4.",
+		"Type int_const 32940 is not implicitly convertible to expected type bytes4.",
+	}, messages)
+}
+
+func TestTypeCheckerLegacySender(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.5.0;
+
+contract Legacy {
+    function sender() public view returns (address payable) {
+        return msg.sender;
+    }
+}`
+
+	b := buildSymbolsTree(t, content)
+	require.NotNil(t, b.GetTypeChecker())
+
+	// Before 0.8 the sender of the message is a payable address.
+	assert.Empty(t, b.GetTypeErrors())
+}
+
+func TestTypeCheckerReturnArity(t *testing.T) {
+	content := `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Pairs {
+    function single() public pure returns (uint256, uint256) {
+        return 1;
+    }
+
+    function mismatch() public pure returns (uint256, bool) {
+        return (1, 2);
+    }
+
+    function pair() public pure returns (uint256, uint256) {
+        return (1, 2);
+    }
+}`
+
+	b := buildSymbolsTree(t, content)
+	require.NotNil(t, b.GetTypeChecker())
+
+	// Values not matching the number of return variables are reported once, without comparing
+	// them against the whole tuple.
+	messages := make([]string, 0)
+	for _, typeError := range b.GetTypeErrors() {
+		messages = append(messages, typeError.Error())
+	}
+	assert.Equal(t, []string{
+		"Different number of arguments in return statement than in returns declaration.",
+		"Return argument type tuple(int_const 1,int_const 2) is not implicitly convertible to expected type tuple(uint256,bool).",
+	}, messages)
+}
@@ -481,9 +481,18 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 
 	if len(ctx.AllIdentifier()) > 0 {
 		identifierCtx := ctx.Identifier(0)
+
+		// Qualified paths such as Counters.Counter name the last segment; the path node keeps the
+		// full path so the library or contract prefix is not mistaken for the type itself.
+		name := identifierCtx.GetText()
+		if identifiers := ctx.AllIdentifier(); len(identifiers) > 1 {
+			name = identifiers[len(identifiers)-1].GetText()
+			t.Name = name
+		}
+
 		t.PathNode = &PathNode{
 			Id:   t.GetNextID(),
-			Name: identifierCtx.GetText(),
+			Name: ctx.GetText(),
 			Src: SrcNode{
 				Line:        int64(ctx.GetStart().GetLine()),
 				Column:      int64(ctx.GetStart().GetColumn()),
@@ -504,7 +513,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 		}
 
 		normalizedTypeName, normalizedTypeIdentifier, found := normalizeTypeDescriptionWithStatus(
-			identifierCtx.GetText(),
+			name,
 		)
 
 		switch normalizedTypeIdentifier {
@@ -520,7 +529,7 @@ func (t *TypeName) parseIdentifierPath(unit *SourceUnit[Node[ast_pb.SourceUnit]]
 				TypeString:     normalizedTypeName,
 			}
 		} else {
-			if refId, refTypeDescription := t.GetResolver().ResolveByNode(t, identifierCtx.GetText()); refTypeDescription != nil {
+			if refId, refTypeDescription := t.GetResolver().ResolveByNode(t, name); refTypeDescription != nil {
 				t.PathNode.ReferencedDeclaration = refId
 				t.ReferencedDeclaration = refId
 				t.TypeDescription = refTypeDescription
@@ -773,6 +782,11 @@ func (t *TypeName) Parse(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node[
 		case *parser.FunctionTypeNameContext:
 			t.parseFunctionTypeName(unit, parentNodeId, childCtx)
 		case *parser.PrimaryExpressionContext:
+			// Fixed-size arrays such as uint256[2] carry the length as a primary expression, which
+			// is parsed below together with the rest of the length expressions.
+			if ctx.TypeName() != nil {
+				continue
+			}
 			t.parsePrimaryExpression(unit, fnNode, parentNodeId, childCtx)
 		case *antlr.TerminalNodeImpl:
 			continue
@@ -825,6 +839,27 @@ func (t *TypeName) ParseMul(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode No
 	}
 }
 
+// ParseByteAlias parses the `byte` alias of bytes1 from the given token. The alias was removed in
+// 0.8.0 and the grammar only knows it as a reserved keyword, which the parser skips.
+func (t *TypeName) ParseByteAlias(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node[NodeType], parentNodeId int64, token antlr.Token) {
+	t.NodeType = ast_pb.NodeType_ELEMENTARY_TYPE_NAME
+	t.Name = token.GetText()
+
+	t.Src = SrcNode{
+		Line:        int64(token.GetLine()),
+		Column:      int64(token.GetColumn()),
+		Start:       int64(token.GetStart()),
+		End:         int64(token.GetStop()),
+		Length:      int64(token.GetStop() - token.GetStart() + 1),
+		ParentIndex: parentNodeId,
+	}
+
+	t.TypeDescription = &TypeDescription{
+		TypeString:     "bytes1",
+		TypeIdentifier: "t_bytes1",
+	}
+}
+
 // ParseElementaryType parses the ElementaryTypeName from the given ElementaryTypeNameContext.
 func (t *TypeName) ParseElementaryType(unit *SourceUnit[Node[ast_pb.SourceUnit]], fnNode Node[NodeType], parentNodeId int64, ctx parser.IElementaryTypeNameContext) {
 	t.Src = SrcNode{
@@ -0,0 +1,720 @@
+package ast
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+)
+
+// TypeKind is the kind of the Solidity type.
+type TypeKind int
+
+const (
+	TypeUnknown          TypeKind = iota // Type could not be determined.
+	TypeBool                             // bool
+	TypeInteger                          // uintN and intN
+	TypeRational                         // Constants such as number literals and expressions of them.
+	TypeAddress                          // address and address payable
+	TypeFixedBytes                       // bytesN
+	TypeBytes                            // bytes
+	TypeString                           // string
+	TypeStringLiteral                    // String and hex string literals.
+	TypeArray                            // Fixed and dynamically sized arrays.
+	TypeMapping                          // mapping(K => V)
+	TypeStruct                           // Structs.
+	TypeEnum                             // Enums.
+	TypeContract                         // Contracts, libraries and interfaces, including super.
+	TypeUserDefinedValue                 // User defined value types.
+	TypeTuple                            // Tuples, including the empty tuple of functions without return values.
+	TypeFunction                         // Functions, including builtins.
+	TypeMeta                             // Type names used as expressions, such as uint256 in uint256(x).
+	TypeMagic                            // msg, block, tx, abi and type(X).
+)
+
+// DataLocation is the data location of the reference types.
+type DataLocation string
+
+const (
+	LocationNone     DataLocation = ""
+	LocationStorage  DataLocation = "storage"
+	LocationMemory   DataLocation = "memory"
+	LocationCalldata DataLocation = "calldata"
+)
+
+// Type is the Solidity type of an expression or a declaration, as determined by the TypeChecker.
+type Type struct {
+	Kind TypeKind `json:"kind"`
+
+	// Bits is the size of integers in bits, while Size is the size of fixed bytes in bytes.
+	Bits   int  `json:"bits,omitempty"`
+	Size   int  `json:"size,omitempty"`
+	Signed bool `json:"signed,omitempty"`
+
+	// Payable is set for address payable.
+	Payable bool `json:"payable,omitempty"`
+
+	// Rational is the value of the constant, Literal the value of the string literal or the digits
+	// of the hex number literal.
+	Rational *big.Rat `json:"-"`
+	Literal  string   `json:"literal,omitempty"`
+	Hex      bool     `json:"hex,omitempty"`
+
+	// Base, Length and LengthText describe arrays. Length is -1 for dynamically sized arrays, while
+	// LengthText holds the length as written when it is not a number literal.
+	Base       *Type  `json:"base,omitempty"`
+	Length     int64  `json:"length,omitempty"`
+	LengthText string `json:"lengthText,omitempty"`
+
+	// KeyType and ValueType describe mappings.
+	KeyType   *Type `json:"keyType,omitempty"`
+	ValueType *Type `json:"valueType,omitempty"`
+
+	// Location is the data location of reference types. Pointer is set for local storage variables,
+	// which point to storage rather than being a storage reference.
+	Location DataLocation `json:"location,omitempty"`
+	Pointer  bool         `json:"pointer,omitempty"`
+
+	// Slice is set for slices of calldata arrays, such as data[4:].
+	Slice bool `json:"slice,omitempty"`
+
+	// Name, CanonicalName and Declaration describe structs, enums, contracts and user defined value
+	// types, while ContractKind is contract, library or interface. Name is also the name of magic
+	// types and builtin functions.
+	Name          string `json:"name,omitempty"`
+	CanonicalName string `json:"canonicalName,omitempty"`
+	Declaration   int64  `json:"declaration,omitempty"`
+	ContractKind  string `json:"contractKind,omitempty"`
+	Super         bool   `json:"super,omitempty"`
+
+	// Underlying is the type a user defined value type wraps.
+	Underlying *Type `json:"underlying,omitempty"`
+
+	// Components are the types of the tuple.
+	Components []*Type `json:"components,omitempty"`
+
+	// Parameters, Returns, FunctionKind, StateMutability and AttachedTo describe functions.
+	// FunctionKind is internal, external, declaration or the name of a builtin.
+	Parameters      []*Type `json:"parameters,omitempty"`
+	Returns         []*Type `json:"returns,omitempty"`
+	FunctionKind    string  `json:"functionKind,omitempty"`
+	StateMutability string  `json:"stateMutability,omitempty"`
+	AttachedTo      *Type   `json:"attachedTo,omitempty"`
+
+	// Actual is the type the meta type stands for.
+	Actual *Type `json:"actual,omitempty"`
+}
+
+// Commonly used types.
+var (
+	unknownType = &Type{Kind: TypeUnknown}
+	boolType    = &Type{Kind: TypeBool}
+	uint256Type = &Type{Kind: TypeInteger, Bits: 256}
+	addressType = &Type{Kind: TypeAddress}
+	emptyTuple  = &Type{Kind: TypeTuple, Components: []*Type{}}
+)
+
+// NewIntegerType returns the integer type of the given size in bits.
+func NewIntegerType(bits int, signed bool) *Type {
+	return &Type{Kind: TypeInteger, Bits: bits, Signed: signed}
+}
+
+// NewRationalType returns the type of the constant.
+func NewRationalType(value *big.Rat) *Type {
+	return &Type{Kind: TypeRational, Rational: value}
+}
+
+// NewTupleType returns the tuple of the given types.
+func NewTupleType(components ...*Type) *Type {
+	return &Type{Kind: TypeTuple, Components: components}
+}
+
+// IsKnown returns true if the type was determined.
+func (t *Type) IsKnown() bool {
+	return t != nil && t.Kind != TypeUnknown
+}
+
+// IsReference returns true if the type is stored at a data location.
+func (t *Type) IsReference() bool {
+	switch t.Kind {
+	case TypeBytes, TypeString, TypeArray, TypeMapping, TypeStruct:
+		return true
+	}
+	return false
+}
+
+// IsInteger returns true for integers and integer constants.
+func (t *Type) IsInteger() bool {
+	return t.Kind == TypeInteger || (t.Kind == TypeRational && t.Rational != nil && t.Rational.IsInt())
+}
+
+// WithLocation returns a copy of the reference type at the given data location. Other types are
+// returned as they are.
+func (t *Type) WithLocation(location DataLocation, pointer bool) *Type {
+	if !t.IsKnown() || !t.IsReference() {
+		return t
+	}
+
+	toReturn := *t
+	toReturn.Location = location
+	toReturn.Pointer = pointer && location == LocationStorage
+
+	// Members of memory and calldata arrays are at the same location, while members of storage
+	// arrays are storage references.
+	if toReturn.Base != nil {
+		toReturn.Base = t.Base.WithLocation(location, false)
+	}
+	return &toReturn
+}
+
+// Equal returns true if the types are the same, including their data locations.
+func (t *Type) Equal(other *Type) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.Identifier() == other.Identifier()
+}
+
+// String returns the type string of the type, as the Solidity compiler formats it.
+func (t *Type) String() string {
+	if t == nil {
+		return ""
+	}
+
+	switch t.Kind {
+	case TypeBool:
+		return "bool"
+	case TypeInteger:
+		if t.Signed {
+			return fmt.Sprintf("int%d", t.Bits)
+		}
+		return fmt.Sprintf("uint%d", t.Bits)
+	case TypeRational:
+		if t.Rational == nil {
+			return "int_const"
+		}
+		if t.Rational.IsInt() {
+			return "int_const " + formatNumberReadable(t.Rational.Num())
+		}
+		return "rational_const " + formatNumberReadable(t.Rational.Num()) + " / " + formatNumberReadable(t.Rational.Denom())
+	case TypeAddress:
+		if t.Payable {
+			return "address payable"
+		}
+		return "address"
+	case TypeFixedBytes:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case TypeBytes:
+		return "bytes" + t.locationString()
+	case TypeString:
+		return "string" + t.locationString()
+	case TypeStringLiteral:
+		if t.Hex {
+			return fmt.Sprintf("literal_string hex\"%s\"", t.Literal)
+		}
+		return fmt.Sprintf("literal_string \"%s\"", t.Literal)
+	case TypeArray:
+		return t.Base.withoutLocation().String() + "[" + t.lengthString() + "]" + t.locationString()
+	case TypeMapping:
+		return "mapping(" + t.KeyType.withoutLocation().String() + " => " + t.ValueType.withoutLocation().String() + ")"
+	case TypeStruct:
+		return "struct " + t.canonicalName() + t.locationString()
+	case TypeEnum:
+		return "enum " + t.canonicalName()
+	case TypeContract:
+		if t.Super {
+			return "contract super " + t.Name
+		}
+		return t.contractKind() + " " + t.Name
+	case TypeUserDefinedValue:
+		return t.canonicalName()
+	case TypeTuple:
+		components := make([]string, 0, len(t.Components))
+		for _, component := range t.Components {
+			components = append(components, component.String())
+		}
+		return "tuple(" + strings.Join(components, ",") + ")"
+	case TypeFunction:
+		return t.functionString()
+	case TypeMeta:
+		return "type(" + t.Actual.String() + ")"
+	case TypeMagic:
+		if t.Actual != nil {
+			return "type(" + t.Actual.String() + ")"
+		}
+		return t.Name
+	}
+
+	return ""
+}
+
+// Identifier returns the type identifier of the type, as the Solidity compiler formats it.
+func (t *Type) Identifier() string {
+	if t == nil {
+		return ""
+	}
+
+	switch t.Kind {
+	case TypeBool:
+		return "t_bool"
+	case TypeInteger:
+		return "t_" + t.String()
+	case TypeRational:
+		if t.Rational == nil {
+			return "t_rational"
+		}
+		return "t_rational_" + formatSignedNumber(t.Rational.Num()) + "_by_" + t.Rational.Denom().String()
+	case TypeAddress:
+		if t.Payable {
+			return "t_address_payable"
+		}
+		return "t_address"
+	case TypeFixedBytes:
+		return fmt.Sprintf("t_bytes%d", t.Size)
+	case TypeBytes:
+		return "t_bytes" + t.locationIdentifier()
+	case TypeString:
+		return "t_string" + t.locationIdentifier()
+	case TypeStringLiteral:
+		return "t_stringliteral_" + fmt.Sprintf("%x", crypto.Keccak256([]byte(t.Literal)))
+	case TypeArray:
+		length := "dyn"
+		if t.Length >= 0 {
+			length = strconv.FormatInt(t.Length, 10)
+		} else if t.LengthText != "" {
+			length = t.LengthText
+		}
+		return "t_array" + identifierList(t.Base) + length + t.locationIdentifier()
+	case TypeMapping:
+		return "t_mapping" + identifierList(t.KeyType, t.ValueType)
+	case TypeStruct:
+		return fmt.Sprintf("t_struct$_%s_$%d", t.Name, t.Declaration) + t.locationIdentifier()
+	case TypeEnum:
+		return fmt.Sprintf("t_enum$_%s_$%d", t.Name, t.Declaration)
+	case TypeContract:
+		if t.Super {
+			return fmt.Sprintf("t_super$_%s_$%d", t.Name, t.Declaration)
+		}
+		return fmt.Sprintf("t_contract$_%s_$%d", t.Name, t.Declaration)
+	case TypeUserDefinedValue:
+		return fmt.Sprintf("t_userDefinedValueType$_%s_$%d", t.Name, t.Declaration)
+	case TypeTuple:
+		return "t_tuple" + identifierList(t.Components...)
+	case TypeFunction:
+		kind := t.FunctionKind
+		if kind == "" {
+			kind = "internal"
+		}
+		toReturn := "t_function_" + kind + "_" + t.mutability() + identifierList(t.Parameters...) + "returns" + identifierList(t.Returns...)
+		if t.AttachedTo != nil {
+			toReturn += "attached_to" + identifierList(t.AttachedTo)
+		}
+		return toReturn
+	case TypeMeta:
+		return "t_type" + identifierList(t.Actual)
+	case TypeMagic:
+		if t.Actual != nil {
+			return "t_magic_meta_type_" + t.Actual.Identifier()
+		}
+		switch t.Name {
+		case "msg":
+			return "t_magic_message"
+		case "tx":
+			return "t_magic_transaction"
+		}
+		return "t_magic_" + t.Name
+	}
+
+	return ""
+}
+
+// ToTypeDescription returns the TypeDescription of the type, or nil if the type is not known.
+func (t *Type) ToTypeDescription() *TypeDescription {
+	if !t.IsKnown() {
+		return nil
+	}
+
+	return &TypeDescription{
+		TypeIdentifier: t.Identifier(),
+		TypeString:     t.String(),
+	}
+}
+
+// Mobile returns the type values of the type are stored as, such as the smallest integer type the
+// constant fits into, or the string type of string literals. It returns unknown for fractional
+// constants, as they cannot be stored.
+func (t *Type) Mobile() *Type {
+	switch t.Kind {
+	case TypeRational:
+		if t.Rational == nil || !t.Rational.IsInt() {
+			return unknownType
+		}
+		return integerTypeOf(t.Rational.Num())
+	case TypeStringLiteral:
+		return (&Type{Kind: TypeString}).WithLocation(LocationMemory, false)
+	case TypeTuple:
+		components := make([]*Type, 0, len(t.Components))
+		for _, component := range t.Components {
+			components = append(components, component.Mobile())
+		}
+		return NewTupleType(components...)
+	}
+	return t
+}
+
+// IsImplicitlyConvertibleTo returns true if values of the type can be used where values of the other
+// type are expected without an explicit conversion. Unknown types are convertible to anything, so
+// that errors are only reported for types that were determined.
+func (t *Type) IsImplicitlyConvertibleTo(other *Type) bool {
+	if !t.IsKnown() || !other.IsKnown() {
+		return true
+	}
+
+	switch t.Kind {
+	case TypeBool:
+		return other.Kind == TypeBool
+	case TypeInteger:
+		if other.Kind != TypeInteger {
+			return false
+		}
+		if t.Signed == other.Signed {
+			return other.Bits >= t.Bits
+		}
+		return !t.Signed && other.Bits > t.Bits
+	case TypeRational:
+		return t.rationalConvertibleTo(other)
+	case TypeAddress:
+		return other.Kind == TypeAddress && (t.Payable || !other.Payable)
+	case TypeFixedBytes:
+		return other.Kind == TypeFixedBytes && other.Size >= t.Size
+	case TypeStringLiteral:
+		switch other.Kind {
+		case TypeString, TypeBytes:
+			return true
+		case TypeFixedBytes:
+			return len(literalBytes(t)) <= other.Size
+		}
+		return false
+	case TypeBytes, TypeString, TypeArray, TypeStruct:
+		if other.Kind != t.Kind {
+			return false
+		}
+		// Data can be copied between locations, apart from into calldata.
+		if other.Location == LocationCalldata && t.Location != LocationCalldata {
+			return false
+		}
+		if other.Location == LocationStorage && other.Pointer && t.Location != LocationStorage {
+			return false
+		}
+		return !other.Slice && t.withoutLocation().Identifier() == other.withoutLocation().Identifier()
+	case TypeMapping:
+		return other.Kind == TypeMapping && t.Identifier() == other.Identifier()
+	case TypeEnum, TypeUserDefinedValue:
+		return other.Kind == t.Kind && other.Declaration == t.Declaration
+	case TypeContract:
+		// Contracts convert to their bases, which is checked by the TypeChecker knowing the
+		// linearization. Here only the same contract is accepted.
+		return other.Kind == TypeContract && other.Declaration == t.Declaration
+	case TypeTuple:
+		if other.Kind != TypeTuple || len(other.Components) != len(t.Components) {
+			return false
+		}
+		for i, component := range t.Components {
+			if component != nil && other.Components[i] != nil && !component.IsImplicitlyConvertibleTo(other.Components[i]) {
+				return false
+			}
+		}
+		return true
+	case TypeFunction, TypeMeta, TypeMagic:
+		return true
+	}
+
+	return false
+}
+
+// rationalConvertibleTo returns true if the constant fits into the other type.
+func (t *Type) rationalConvertibleTo(other *Type) bool {
+	if t.Rational == nil {
+		return true
+	}
+
+	switch other.Kind {
+	case TypeRational:
+		return true
+	case TypeInteger:
+		if !t.Rational.IsInt() {
+			return false
+		}
+		return fitsInteger(t.Rational.Num(), other.Bits, other.Signed)
+	case TypeFixedBytes:
+		// Only zero and hex literals of the exact size convert to fixed bytes.
+		return t.Rational.Sign() == 0 || (t.Hex && len(t.Literal) == 2*other.Size)
+	}
+	return false
+}
+
+// withoutLocation returns the type with data locations removed, as used within array and mapping
+// type names.
+func (t *Type) withoutLocation() *Type {
+	if t == nil || !t.IsReference() {
+		return t
+	}
+
+	toReturn := *t
+	toReturn.Location = LocationNone
+	toReturn.Pointer = false
+	toReturn.Slice = false
+	if toReturn.Base != nil {
+		toReturn.Base = t.Base.withoutLocation()
+	}
+	return &toReturn
+}
+
+// locationString returns the suffix of the type string describing the data location.
+func (t *Type) locationString() string {
+	if t.Slice {
+		return " " + string(t.Location) + " slice"
+	}
+
+	switch t.Location {
+	case LocationStorage:
+		if t.Pointer {
+			return " storage pointer"
+		}
+		return " storage ref"
+	case LocationMemory:
+		return " memory"
+	case LocationCalldata:
+		return " calldata"
+	}
+	return ""
+}
+
+// locationIdentifier returns the suffix of the type identifier describing the data location.
+func (t *Type) locationIdentifier() string {
+	if t.Slice {
+		return "_" + string(t.Location) + "_ptr_slice"
+	}
+
+	switch t.Location {
+	case LocationStorage:
+		if t.Pointer {
+			return "_storage_ptr"
+		}
+		return "_storage"
+	case LocationMemory:
+		return "_memory_ptr"
+	case LocationCalldata:
+		return "_calldata_ptr"
+	}
+	return ""
+}
+
+// lengthString returns the length of the array as written within the type name.
+func (t *Type) lengthString() string {
+	if t.Length >= 0 {
+		return strconv.FormatInt(t.Length, 10)
+	}
+	return t.LengthText
+}
+
+// canonicalName returns the name of the declaration qualified with the name of its contract.
+func (t *Type) canonicalName() string {
+	if t.CanonicalName != "" {
+		return t.CanonicalName
+	}
+	return t.Name
+}
+
+// contractKind returns the kind of the contract, defaulting to contract.
+func (t *Type) contractKind() string {
+	if t.ContractKind == "" {
+		return "contract"
+	}
+	return t.ContractKind
+}
+
+// mutability returns the state mutability of the function, defaulting to nonpayable.
+func (t *Type) mutability() string {
+	if t.StateMutability == "" {
+		return "nonpayable"
+	}
+	return t.StateMutability
+}
+
+// functionString returns the type string of the function type.
+func (t *Type) functionString() string {
+	parameters := make([]string, 0, len(t.Parameters))
+	for _, parameter := range t.Parameters {
+		parameters = append(parameters, parameter.String())
+	}
+
+	toReturn := "function (" + strings.Join(parameters, ",") + ")"
+	if t.FunctionKind == "external" {
+		toReturn += " external"
+	}
+	if t.StateMutability != "" && t.StateMutability != "nonpayable" {
+		toReturn += " " + t.StateMutability
+	}
+
+	if len(t.Returns) > 0 {
+		returns := make([]string, 0, len(t.Returns))
+		for _, returnType := range t.Returns {
+			returns = append(returns, returnType.String())
+		}
+		toReturn += " returns (" + strings.Join(returns, ",") + ")"
+	}
+	return toReturn
+}
+
+// identifierList returns the identifiers of the types enclosed as the compiler encloses nested type
+// identifiers.
+func identifierList(types ...*Type) string {
+	if len(types) == 0 {
+		return "$__$"
+	}
+
+	identifiers := make([]string, 0, len(types))
+	for _, t := range types {
+		identifiers = append(identifiers, t.Identifier())
+	}
+	return "$_" + strings.Join(identifiers, "_$_") + "_$"
+}
+
+// formatNumberReadable formats the number, omitting the middle digits of numbers with more than 32
+// digits as the compiler does within type strings.
+func formatNumberReadable(value *big.Int) string {
+	toReturn := value.String()
+	sign := ""
+	if strings.HasPrefix(toReturn, "-") {
+		sign, toReturn = "-", toReturn[1:]
+	}
+
+	if len(toReturn) > 32 {
+		toReturn = fmt.Sprintf("%s...(%d digits omitted)...%s", toReturn[:4], len(toReturn)-8, toReturn[len(toReturn)-4:])
+	}
+	return sign + toReturn
+}
+
+// formatSignedNumber formats the number for type identifiers, which spell out the minus sign.
+func formatSignedNumber(value *big.Int) string {
+	if value.Sign() < 0 {
+		return "minus_" + new(big.Int).Neg(value).String()
+	}
+	return value.String()
+}
+
+// fitsInteger returns true if the value fits into the integer type.
+func fitsInteger(value *big.Int, bits int, signed bool) bool {
+	if signed {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		return value.Cmp(new(big.Int).Neg(limit)) >= 0 && value.Cmp(limit) < 0
+	}
+	return value.Sign() >= 0 && value.BitLen() <= bits
+}
+
+// integerTypeOf returns the smallest integer type the value fits into.
+func integerTypeOf(value *big.Int) *Type {
+	signed := value.Sign() < 0
+	for bits := 8; bits <= 256; bits += 8 {
+		if fitsInteger(value, bits, signed) {
+			return NewIntegerType(bits, signed)
+		}
+	}
+	return unknownType
+}
+
+// literalBytes returns the bytes of the string literal.
+func literalBytes(t *Type) []byte {
+	if t.Hex {
+		decoded := make([]byte, 0, len(t.Literal)/2)
+		digits := strings.ReplaceAll(t.Literal, "_", "")
+		for i := 0; i+1 < len(digits); i += 2 {
+			value, err := strconv.ParseUint(digits[i:i+2], 16, 8)
+			if err != nil {
+				return []byte(t.Literal)
+			}
+			decoded = append(decoded, byte(value))
+		}
+		return decoded
+	}
+	return []byte(t.Literal)
+}
+
+// elementaryType returns the type of the elementary type name, such as uint or address payable, or
+// nil if the name is not an elementary type.
+func elementaryType(name string) *Type {
+	name = strings.TrimSpace(name)
+	switch name {
+	case "bool":
+		return boolType
+	case "address":
+		return addressType
+	case "address payable", "addresspayable":
+		return &Type{Kind: TypeAddress, Payable: true}
+	case "string":
+		return &Type{Kind: TypeString}
+	case "bytes":
+		return &Type{Kind: TypeBytes}
+	case "byte":
+		return &Type{Kind: TypeFixedBytes, Size: 1}
+	case "uint":
+		return uint256Type
+	case "int":
+		return NewIntegerType(256, true)
+	}
+
+	for _, prefix := range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		size, err := strconv.Atoi(name[len(prefix):])
+		if err != nil {
+			return nil
+		}
+
+		if prefix == "bytes" {
+			if size < 1 || size > 32 {
+				return nil
+			}
+			return &Type{Kind: TypeFixedBytes, Size: size}
+		}
+
+		if size < 8 || size > 256 || size%8 != 0 {
+			return nil
+		}
+		return NewIntegerType(size, prefix == "int")
+	}
+
+	return nil
+}
+
+// locationOf returns the DataLocation of the storage location of the ast.
+func locationOf(location ast_pb.StorageLocation) DataLocation {
+	switch location {
+	case ast_pb.StorageLocation_STORAGE:
+		return LocationStorage
+	case ast_pb.StorageLocation_MEMORY:
+		return LocationMemory
+	case ast_pb.StorageLocation_CALLDATA:
+		return LocationCalldata
+	}
+	return LocationNone
+}
+
+// mutabilityString returns the state mutability as written within function types.
+func mutabilityString(mutability ast_pb.Mutability) string {
+	switch mutability {
+	case ast_pb.Mutability_PURE:
+		return "pure"
+	case ast_pb.Mutability_VIEW:
+		return "view"
+	case ast_pb.Mutability_PAYABLE:
+		return "payable"
+	}
+	return "nonpayable"
+}
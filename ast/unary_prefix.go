@@ -270,6 +270,10 @@ func (u *UnaryPrefix) Parse(
 		u.Operator = ast_pb.Operator_BIT_NOT
 	} else if ctx.Sub() != nil {
 		u.Operator = ast_pb.Operator_SUBTRACT
+	} else if ctx.Delete() != nil {
+		// There is no dedicated delete operator in the protobuf definitions, so the default
+		// operator is used to distinguish `delete x` from the rest of the prefix operations.
+		u.Operator = ast_pb.Operator_O_DEFAULT
 	}
 
 	expression := NewExpression(u.ASTBuilder)
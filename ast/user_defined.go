@@ -248,4 +248,10 @@ func (b *UserDefinedValueTypeDefinition) ParseGlobal(
 func (b *ASTBuilder) EnterUserDefinedValueTypeDefinition(ctx *parser.UserDefinedValueTypeDefinitionContext) {
 	child := NewUserDefinedValueTypeDefinition(b)
 	child.ParseGlobal(ctx)
+
+	// Value types declared at the top level of a source unit do not belong to any contract, so
+	// they are kept along with the rest of the global definitions.
+	if _, ok := ctx.GetParent().(*parser.SourceUnitContext); ok {
+		b.globalDefinitions = append(b.globalDefinitions, child)
+	}
 }
@@ -15,6 +15,8 @@ type UsingDirective struct {
 	TypeDescription *TypeDescription `json:"typeDescription"`
 	TypeName        *TypeName        `json:"typeName"`
 	LibraryName     *LibraryName     `json:"libraryName"`
+	Functions       []*UsingFunction `json:"functionList,omitempty"`
+	Global          bool             `json:"global,omitempty"`
 }
 
 // LibraryName represents the name of an external library referenced in a using directive.
@@ -28,6 +30,37 @@ type LibraryName struct {
 	ReferencedDeclaration int64           `json:"referencedDeclaration"`
 }
 
+// UsingFunction represents a function attached to a type by a using directive with a function list,
+// such as f in using {f, g as +} for T. Operator is set when the function implements the operator.
+type UsingFunction struct {
+	Id                    int64           `json:"id"`
+	NodeType              ast_pb.NodeType `json:"nodeType"`
+	Src                   SrcNode         `json:"src"`
+	Name                  string          `json:"name"`
+	Operator              string          `json:"operator,omitempty"`
+	ReferencedDeclaration int64           `json:"referencedDeclaration"`
+}
+
+// GetId returns the unique identifier of the UsingFunction.
+func (f *UsingFunction) GetId() int64 {
+	return f.Id
+}
+
+// GetName returns the name, possibly qualified, of the attached function.
+func (f *UsingFunction) GetName() string {
+	return f.Name
+}
+
+// GetOperator returns the operator implemented by the function, or an empty string.
+func (f *UsingFunction) GetOperator() string {
+	return f.Operator
+}
+
+// GetReferencedDeclaration returns the id of the attached function declaration.
+func (f *UsingFunction) GetReferencedDeclaration() int64 {
+	return f.ReferencedDeclaration
+}
+
 // ToProto converts the LibraryName instance to its corresponding protocol buffer representation.
 func (ln *LibraryName) ToProto() *ast_pb.LibraryName {
 	return &ast_pb.LibraryName{
@@ -51,7 +84,9 @@ func NewUsingDirective(b *ASTBuilder) *UsingDirective {
 // SetReferenceDescriptor sets the reference descriptions of the UsingDirective node.
 func (u *UsingDirective) SetReferenceDescriptor(refId int64, refDesc *TypeDescription) bool {
 	u.TypeDescription = refDesc
-	u.LibraryName.ReferencedDeclaration = refId
+	if u.LibraryName != nil {
+		u.LibraryName.ReferencedDeclaration = refId
+	}
 	return false
 }
 
@@ -85,6 +120,16 @@ func (u *UsingDirective) GetLibraryName() *LibraryName {
 	return u.LibraryName
 }
 
+// GetFunctions returns the functions attached by the function list of the UsingDirective.
+func (u *UsingDirective) GetFunctions() []*UsingFunction {
+	return u.Functions
+}
+
+// IsGlobal returns true if the UsingDirective applies to every source unit using the type.
+func (u *UsingDirective) IsGlobal() bool {
+	return u.Global
+}
+
 // GetReferencedDeclaration returns the referenced declaration of the UsingDirective.
 func (u *UsingDirective) GetReferencedDeclaration() int64 {
 	return u.TypeName.ReferencedDeclaration
@@ -105,12 +150,18 @@ func (u *UsingDirective) GetNodes() []Node[NodeType] {
 // ToProto converts the UsingDirective instance to its corresponding protocol buffer representation.
 func (u *UsingDirective) ToProto() NodeType {
 	proto := ast_pb.Using{
-		Id:          u.Id,
-		Name:        u.LibraryName.Name,
-		NodeType:    u.NodeType,
-		Src:         u.Src.ToProto(),
-		LibraryName: u.LibraryName.ToProto(),
-		TypeName:    u.TypeName.ToProto().(*ast_pb.TypeName),
+		Id:       u.Id,
+		NodeType: u.NodeType,
+		Src:      u.Src.ToProto(),
+	}
+
+	if u.LibraryName != nil {
+		proto.Name = u.LibraryName.Name
+		proto.LibraryName = u.LibraryName.ToProto()
+	}
+
+	if u.TypeName != nil {
+		proto.TypeName = u.TypeName.ToProto().(*ast_pb.TypeName)
 	}
 
 	return NewTypedStruct(&proto, "Using")
@@ -131,7 +182,7 @@ func (u *UsingDirective) Parse(
 		ParentIndex: contractNode.GetId(),
 	}
 
-	u.LibraryName = u.getLibraryName(ctx.IdentifierPath(0))
+	u.parseLibraryAndFunctions(ctx)
 
 	if ctx.TypeName() != nil {
 		typeName := NewTypeName(u.ASTBuilder)
@@ -146,6 +197,84 @@ func (u *UsingDirective) Parse(
 	}
 }
 
+// ParseGlobal populates the UsingDirective declared at the top level of a source unit, such as the
+// ones attaching operators to user defined value types.
+func (u *UsingDirective) ParseGlobal(ctx *parser.UsingDirectiveContext) Node[NodeType] {
+	u.Src = SrcNode{
+		Line:        int64(ctx.GetStart().GetLine()),
+		Start:       int64(ctx.GetStart().GetStart()),
+		End:         int64(ctx.GetStop().GetStop()),
+		Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
+		ParentIndex: 0,
+	}
+
+	u.parseLibraryAndFunctions(ctx)
+
+	if ctx.TypeName() != nil {
+		typeName := NewTypeName(u.ASTBuilder)
+		typeName.Parse(nil, nil, u.GetId(), ctx.TypeName())
+		u.TypeName = typeName
+		u.TypeDescription = typeName.TypeDescription
+	} else if ctx.Mul() != nil {
+		typeName := NewTypeName(u.ASTBuilder)
+		typeName.ParseMul(nil, nil, u.GetId(), ctx.Mul())
+		u.TypeName = typeName
+		u.TypeDescription = typeName.TypeDescription
+	}
+
+	u.globalDefinitions = append(u.globalDefinitions, u)
+	return u
+}
+
+// parseLibraryAndFunctions parses the library, or the function list along with the operators the
+// functions implement. The first function of the list is kept as the library name as well, so that
+// the directive can be used the same way regardless of its form.
+func (u *UsingDirective) parseLibraryAndFunctions(ctx *parser.UsingDirectiveContext) {
+	u.Global = ctx.Global() != nil
+
+	if len(ctx.AllIdentifierPath()) > 0 {
+		u.LibraryName = u.getLibraryName(ctx.IdentifierPath(0))
+	}
+
+	if ctx.LBrace() == nil {
+		return
+	}
+
+	for _, child := range ctx.GetChildren() {
+		switch childCtx := child.(type) {
+		case *parser.IdentifierPathContext:
+			u.Functions = append(u.Functions, &UsingFunction{
+				Id:       u.GetNextID(),
+				NodeType: ast_pb.NodeType_IDENTIFIER_PATH,
+				Src: SrcNode{
+					Line:        int64(childCtx.GetStart().GetLine()),
+					Start:       int64(childCtx.GetStart().GetStart()),
+					End:         int64(childCtx.GetStop().GetStop()),
+					Length:      int64(childCtx.GetStop().GetStop() - childCtx.GetStart().GetStart() + 1),
+					ParentIndex: u.Id,
+				},
+				Name: childCtx.GetText(),
+			})
+		case *parser.UserDefinableOperatorContext:
+			if len(u.Functions) > 0 {
+				u.Functions[len(u.Functions)-1].Operator = childCtx.GetText()
+			}
+		}
+	}
+}
+
+// EnterUsingDirective is called when the ASTBuilder enters a using directive. Directives declared
+// within contracts are parsed together with the contract body, so only the top level ones are
+// handled here.
+func (b *ASTBuilder) EnterUsingDirective(ctx *parser.UsingDirectiveContext) {
+	if _, ok := ctx.GetParent().(*parser.SourceUnitContext); !ok {
+		return
+	}
+
+	using := NewUsingDirective(b)
+	using.ParseGlobal(ctx)
+}
+
 // getLibraryName extracts and returns the LibraryName instance from the provided identifier context.
 func (u *UsingDirective) getLibraryName(identifierCtx parser.IIdentifierPathContext) *LibraryName {
 	return &LibraryName{
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"github.com/antlr4-go/antlr/v4"
 	"github.com/goccy/go-json"
 
 	v3 "github.com/cncf/xds/go/xds/type/v3"
@@ -208,11 +209,26 @@ func (v *VariableDeclaration) Parse(
 	}
 
 	if ctx.VariableDeclarationTuple() != nil {
-		for _, declarationCtx := range ctx.VariableDeclarationTuple().AllVariableDeclaration() {
-			declaration := NewDeclaration(v.ASTBuilder)
-			declaration.ParseVariableDeclaration(unit, contractNode, fnNode, bodyNode, v, declarationCtx)
-			v.Declarations = append(v.Declarations, declaration)
-			v.Assignments = append(v.Assignments, declaration.GetId())
+		// Tuple components can be left empty, such as in `(bool success, ) = ...`. Same as solc does,
+		// empty components are kept in assignments (as 0) so the tuple shape is not lost.
+		expectingComponent := true
+		for _, childCtx := range ctx.VariableDeclarationTuple().GetChildren() {
+			switch child := childCtx.(type) {
+			case parser.IVariableDeclarationContext:
+				declaration := NewDeclaration(v.ASTBuilder)
+				declaration.ParseVariableDeclaration(unit, contractNode, fnNode, bodyNode, v, child)
+				v.Declarations = append(v.Declarations, declaration)
+				v.Assignments = append(v.Assignments, declaration.GetId())
+				expectingComponent = false
+			case antlr.TerminalNode:
+				switch child.GetSymbol().GetTokenType() {
+				case parser.SolidityParserComma, parser.SolidityParserRParen:
+					if expectingComponent {
+						v.Assignments = append(v.Assignments, 0)
+					}
+					expectingComponent = true
+				}
+			}
 		}
 	}
 
@@ -176,15 +176,8 @@ func (w *WhileStatement) Parse(
 	// Parsing the body of the while loop.
 	if ctx.Statement() != nil && ctx.Statement().Block() != nil && !ctx.Statement().Block().IsEmpty() {
 		w.Body.ParseBlock(unit, contractNode, w, ctx.Statement().Block())
-
-		// Parsing unchecked blocks within the body.
-		if ctx.Statement().Block() != nil && ctx.Statement().Block().AllUncheckedBlock() != nil {
-			for _, uncheckedCtx := range ctx.Statement().Block().AllUncheckedBlock() {
-				bodyNode := NewBodyNode(w.ASTBuilder, false)
-				bodyNode.ParseUncheckedBlock(unit, contractNode, w, uncheckedCtx)
-				w.Body.Statements = append(w.Body.Statements, bodyNode)
-			}
-		}
+	} else if ctx.Statement() != nil && ctx.Statement().Block() == nil {
+		w.Body.ParseStatement(unit, contractNode, w, ctx.Statement())
 	}
 
 	return w
@@ -9,10 +9,11 @@ import (
 type Yul struct {
 	*ASTBuilder // Embedded ASTBuilder provides building functionalities for AST nodes.
 
-	Id       int64           `json:"id"`       // Id uniquely identifies the assembly statement.
-	NodeType ast_pb.NodeType `json:"nodeType"` // NodeType specifies the type of the node.
-	Src      SrcNode         `json:"src"`      // Src contains source location details of the node.
-	Body     *BodyNode       `json:"body"`     // Body represents the content of the assembly statement.
+	Id       int64           `json:"id"`              // Id uniquely identifies the assembly statement.
+	NodeType ast_pb.NodeType `json:"nodeType"`        // NodeType specifies the type of the node.
+	Src      SrcNode         `json:"src"`             // Src contains source location details of the node.
+	Body     *BodyNode       `json:"body"`            // Body represents the content of the assembly statement.
+	Flags    []string        `json:"flags,omitempty"` // Flags holds the assembly flags such as "memory-safe", including quotes.
 }
 
 // NewYul creates a new Yul and initializes its fields.
@@ -44,6 +45,11 @@ func (a *Yul) GetBody() *BodyNode {
 	return a.Body
 }
 
+// GetFlags returns the assembly flags, such as "memory-safe".
+func (a *Yul) GetFlags() []string {
+	return a.Flags
+}
+
 // GetNodes retrieves the list of statements present in the assembly statement's body.
 func (a *Yul) GetNodes() []Node[NodeType] {
 	return a.Body.Statements
@@ -98,9 +104,14 @@ func (a *Yul) Parse(
 	a.Body.NodeType = ast_pb.NodeType_YUL_BLOCK
 	a.Body.Statements = make([]Node[NodeType], 0)
 
-	yulStatement := NewYulStatement(a.ASTBuilder)
+	if ctx.AssemblyFlags() != nil {
+		for _, flag := range ctx.AssemblyFlags().AllAssemblyFlagString() {
+			a.Flags = append(a.Flags, flag.GetText())
+		}
+	}
 
 	for _, yulCtx := range ctx.AllYulStatement() {
+		yulStatement := NewYulStatement(a.ASTBuilder)
 		a.Body.Statements = append(a.Body.Statements,
 			yulStatement.Parse(
 				unit, contractNode, fnNode, a.Body, a, a, yulCtx.(*parser.YulStatementContext),
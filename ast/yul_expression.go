@@ -162,6 +162,10 @@ func (y *YulExpressionStatement) Parse(
 		)
 	}
 
+	if ctx.YulPath() != nil {
+		y.Expression = NewYulIdentifierFromPath(y.ASTBuilder, y.GetId(), ctx.YulPath())
+	}
+
 	if ctx.YulFunctionCall() != nil {
 		fcStatement := NewYulFunctionCallStatement(y.ASTBuilder)
 		y.Expression = fcStatement.Parse(
@@ -195,6 +199,10 @@ func ParseYulExpression(
 		)
 	}
 
+	if ctx.YulPath() != nil {
+		return NewYulIdentifierFromPath(b, parentNode.GetId(), ctx.YulPath())
+	}
+
 	if ctx.YulFunctionCall() != nil {
 		fcStatement := NewYulFunctionCallStatement(b)
 		return fcStatement.Parse(
@@ -2,6 +2,7 @@ package ast
 
 import (
 	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/parser"
 )
 
 // YulIdentifier represents a YUL identifier in the abstract syntax tree.
@@ -19,6 +20,28 @@ type YulIdentifier struct {
 
 	// Name is the name of the YUL identifier.
 	Name string `json:"name"`
+
+	// ReferencedDeclaration is the id of the Yul or Solidity declaration the identifier refers to.
+	ReferencedDeclaration int64 `json:"referencedDeclaration,omitempty"`
+}
+
+// NewYulIdentifierFromPath creates a YulIdentifier out of a Yul path such as `x` or `x.slot`.
+// Dotted paths are kept as a single identifier so that the original member access is preserved.
+func NewYulIdentifierFromPath(b *ASTBuilder, parentId int64, ctx parser.IYulPathContext) *YulIdentifier {
+	return &YulIdentifier{
+		ASTBuilder: b,
+		Id:         b.GetNextID(),
+		NodeType:   ast_pb.NodeType_YUL_IDENTIFIER,
+		Src: SrcNode{
+			Line:        int64(ctx.GetStart().GetLine()),
+			Column:      int64(ctx.GetStart().GetColumn()),
+			Start:       int64(ctx.GetStart().GetStart()),
+			End:         int64(ctx.GetStop().GetStop()),
+			Length:      int64(ctx.GetStop().GetStop() - ctx.GetStart().GetStart() + 1),
+			ParentIndex: parentId,
+		},
+		Name: ctx.GetText(),
+	}
 }
 
 // SetReferenceDescriptor sets the reference descriptions of the YulIdentifier node.
@@ -26,6 +49,11 @@ func (y *YulIdentifier) SetReferenceDescriptor(refId int64, refDesc *TypeDescrip
 	return false
 }
 
+// GetReferencedDeclaration returns the id of the declaration the YulIdentifier refers to.
+func (y *YulIdentifier) GetReferencedDeclaration() int64 {
+	return y.ReferencedDeclaration
+}
+
 // GetId returns the ID of the YulIdentifier.
 func (y *YulIdentifier) GetId() int64 {
 	return y.Id
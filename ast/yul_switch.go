@@ -11,10 +11,12 @@ import (
 type YulSwitchStatement struct {
 	*ASTBuilder // Embedded ASTBuilder for utility functions.
 
-	Id       int64            `json:"id"`       // Id is the unique identifier for the switch statement.
-	NodeType ast_pb.NodeType  `json:"nodeType"` // NodeType specifies the type of the node.
-	Src      SrcNode          `json:"src"`      // Src provides source location details of the switch statement.
-	Cases    []Node[NodeType] `json:"cases"`    // Cases holds the different cases of the switch statement.
+	Id         int64            `json:"id"`                // Id is the unique identifier for the switch statement.
+	NodeType   ast_pb.NodeType  `json:"nodeType"`          // NodeType specifies the type of the node.
+	Src        SrcNode          `json:"src"`               // Src provides source location details of the switch statement.
+	Expression Node[NodeType]   `json:"expression"`        // Expression is the value the switch statement is matching against.
+	Cases      []Node[NodeType] `json:"cases"`             // Cases holds the different cases of the switch statement.
+	Default    Node[NodeType]   `json:"default,omitempty"` // Default holds the block of the default case, if any.
 }
 
 // NewYulSwitchStatement creates and initializes a new YulSwitchStatement.
@@ -51,7 +53,13 @@ func (y *YulSwitchStatement) GetSrc() SrcNode {
 // GetNodes returns a list of nodes associated with the YulSwitchStatement.
 func (y *YulSwitchStatement) GetNodes() []Node[NodeType] {
 	toReturn := make([]Node[NodeType], 0)
+	if y.Expression != nil {
+		toReturn = append(toReturn, y.Expression)
+	}
 	toReturn = append(toReturn, y.Cases...)
+	if y.Default != nil {
+		toReturn = append(toReturn, y.Default)
+	}
 	return toReturn
 }
 
@@ -61,10 +69,21 @@ func (y *YulSwitchStatement) GetTypeDescription() *TypeDescription {
 	return &TypeDescription{}
 }
 
+// GetExpression returns the expression the YulSwitchStatement is matching against.
+func (y *YulSwitchStatement) GetExpression() Node[NodeType] {
+	return y.Expression
+}
+
+// GetCases returns the cases of the YulSwitchStatement.
 func (y *YulSwitchStatement) GetCases() []Node[NodeType] {
 	return y.Cases
 }
 
+// GetDefault returns the block of the default case or nil if the switch has no default case.
+func (y *YulSwitchStatement) GetDefault() Node[NodeType] {
+	return y.Default
+}
+
 // ToProto converts the YulSwitchStatement into its protobuf representation.
 // Note: This method currently returns an empty Statement.
 func (y *YulSwitchStatement) ToProto() NodeType {
@@ -133,6 +152,34 @@ func (f *YulSwitchStatement) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	for _, key := range []string{"expression", "default"} {
+		raw, ok := tempMap[key]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		var tempNodeMap map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &tempNodeMap); err != nil {
+			return err
+		}
+
+		var tempNodeType ast_pb.NodeType
+		if err := json.Unmarshal(tempNodeMap["nodeType"], &tempNodeType); err != nil {
+			return err
+		}
+
+		node, err := unmarshalNode(raw, tempNodeType)
+		if err != nil {
+			return err
+		}
+
+		if key == "expression" {
+			f.Expression = node
+		} else {
+			f.Default = node
+		}
+	}
+
 	return nil
 }
 
@@ -156,6 +203,14 @@ func (y *YulSwitchStatement) Parse(
 		ParentIndex: statementNode.GetId(),
 	}
 
+	// Parse the expression the switch is matching against.
+	if ctx.YulExpression() != nil {
+		y.Expression = ParseYulExpression(
+			y.ASTBuilder, unit, contractNode, fnNode, bodyNode, assemblyNode, statementNode, nil, nil, y,
+			ctx.YulExpression(),
+		)
+	}
+
 	// Parse all switch cases if present.
 	if ctx.AllYulSwitchCase() != nil {
 		for _, switchCase := range ctx.AllYulSwitchCase() {
@@ -167,5 +222,14 @@ func (y *YulSwitchStatement) Parse(
 		}
 	}
 
+	// Parse the default case if present.
+	if ctx.YulDefault() != nil && ctx.YulBlock() != nil {
+		block := NewYulBlockStatement(y.ASTBuilder)
+		y.Default = block.Parse(
+			unit, contractNode, fnNode, bodyNode, assemblyNode, statementNode, nil, y,
+			ctx.YulBlock().(*parser.YulBlockContext),
+		)
+	}
+
 	return y
 }
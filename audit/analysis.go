@@ -0,0 +1,479 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/dataflow"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// AnalyzedFunction is a function of a contract along with the data-flow analysis of its control
+// flow graph.
+type AnalyzedFunction struct {
+	Contract *ir.Contract
+	Function *ir.Function
+	Analysis *dataflow.FunctionAnalysis
+}
+
+// GetContract returns the contract declaring the function.
+func (f *AnalyzedFunction) GetContract() *ir.Contract {
+	return f.Contract
+}
+
+// GetFunction returns the function.
+func (f *AnalyzedFunction) GetFunction() *ir.Function {
+	return f.Function
+}
+
+// GetGraph returns the control flow graph of the function, with its modifiers inlined.
+func (f *AnalyzedFunction) GetGraph() *cfg.FunctionGraph {
+	return f.Analysis.GetGraph()
+}
+
+// GetAnalysis returns the data-flow analysis of the function.
+func (f *AnalyzedFunction) GetAnalysis() *dataflow.FunctionAnalysis {
+	return f.Analysis
+}
+
+// IsEntryPoint returns true if anyone can call the function from outside.
+func (f *AnalyzedFunction) IsEntryPoint() bool {
+	visibility := f.Function.GetAST().GetVisibility()
+	return visibility == ast_pb.Visibility_PUBLIC || visibility == ast_pb.Visibility_EXTERNAL
+}
+
+// Analysis is the analyzed sources the native detectors run on. It exposes the IR, the control flow
+// and call graphs, the data-flow analyses of every function and helpers to build the elements of
+// detected issues.
+type Analysis struct {
+	ctx       context.Context
+	builder   *ir.Builder
+	dataflow  *dataflow.Builder
+	functions []*AnalyzedFunction
+	byId      map[int64]*AnalyzedFunction
+	ranges    []*solgo.SourceRange
+	contents  map[*solgo.SourceUnit][]rune
+	taint     []*dataflow.TaintFinding
+	protected map[int64]bool
+}
+
+// NewAnalysis runs the data-flow analyses of every function of the contracts built by the IR builder.
+func NewAnalysis(ctx context.Context, builder *ir.Builder) (*Analysis, error) {
+	dataflowBuilder, err := dataflow.NewBuilder(ctx, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	toReturn := &Analysis{
+		ctx:       ctx,
+		builder:   builder,
+		dataflow:  dataflowBuilder,
+		functions: make([]*AnalyzedFunction, 0),
+		byId:      make(map[int64]*AnalyzedFunction),
+		contents:  make(map[*solgo.SourceUnit][]rune),
+		protected: make(map[int64]bool),
+	}
+
+	if sources := builder.GetSources(); sources != nil {
+		toReturn.ranges = sources.GetCombinedSourceRanges()
+	}
+
+	graph := dataflowBuilder.GetCfgBuilder().GetGraph()
+	for _, contract := range builder.GetRoot().GetContracts() {
+		node := graph.GetNode(contract.GetName())
+		if node == nil {
+			continue
+		}
+
+		for _, functionGraph := range node.GetFunctions() {
+			function := functionGraph.GetFunction()
+			if function == nil || toReturn.byId[function.GetId()] != nil {
+				continue
+			}
+
+			analyzed := &AnalyzedFunction{
+				Contract: contract,
+				Function: function,
+				Analysis: dataflowBuilder.Analyze(functionGraph),
+			}
+			toReturn.functions = append(toReturn.functions, analyzed)
+			toReturn.byId[function.GetId()] = analyzed
+		}
+	}
+
+	return toReturn, nil
+}
+
+// GetBuilder returns the IR builder of the analyzed sources.
+func (a *Analysis) GetBuilder() *ir.Builder {
+	return a.builder
+}
+
+// GetRoot returns the root of the IR of the analyzed sources.
+func (a *Analysis) GetRoot() *ir.RootSourceUnit {
+	return a.builder.GetRoot()
+}
+
+// GetDataFlow returns the builder of the data-flow analyses.
+func (a *Analysis) GetDataFlow() *dataflow.Builder {
+	return a.dataflow
+}
+
+// GetCallGraph returns the call graph over all of the contracts.
+func (a *Analysis) GetCallGraph() *cfg.CallGraph {
+	return a.dataflow.GetCfgBuilder().GetCallGraph()
+}
+
+// GetFunctions returns the analyzed functions of all of the contracts, in the order of the contracts.
+func (a *Analysis) GetFunctions() []*AnalyzedFunction {
+	return a.functions
+}
+
+// GetFunction returns the analyzed function with the given id, or nil if there is no such function.
+func (a *Analysis) GetFunction(id int64) *AnalyzedFunction {
+	return a.byId[id]
+}
+
+// GetTaintFindings returns the sinks reached by values tainted by any of the sources within every
+// analyzed function. Findings are computed once and shared by all of the detectors.
+func (a *Analysis) GetTaintFindings() []*dataflow.TaintFinding {
+	if a.taint == nil {
+		tracker := dataflow.NewTaintTracker(nil)
+		a.taint = make([]*dataflow.TaintFinding, 0)
+		for _, function := range a.functions {
+			a.taint = append(a.taint, tracker.Track(function.Analysis)...)
+		}
+	}
+	return a.taint
+}
+
+// IsProtected returns true if the function checks the caller before completing, either within its
+// modifiers or body, or within the internal functions they call. Functions checking msg.sender
+// within a condition, a require or an assert are considered protected.
+func (a *Analysis) IsProtected(function *AnalyzedFunction) bool {
+	return a.isProtected(function, make(map[int64]bool))
+}
+
+// isProtected returns true if the function checks the caller, following internal calls not yet visited.
+func (a *Analysis) isProtected(function *AnalyzedFunction, visited map[int64]bool) bool {
+	id := function.Function.GetId()
+	if protected, ok := a.protected[id]; ok {
+		return protected
+	}
+	if visited[id] {
+		return false
+	}
+	visited[id] = true
+
+	protected := false
+	for _, block := range function.GetGraph().GetBlocks() {
+		statements := block.GetStatements()
+		for i, statement := range statements {
+			// Conditions are the last statements of the blocks they branch or revert from.
+			if i == len(statements)-1 && IsConditional(block) && ContainsNode(statement.GetNode(), isCaller) {
+				protected = true
+			}
+
+			ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+				if callee := a.internalCallee(node); callee != nil && a.isProtected(callee, visited) {
+					protected = true
+				}
+				return protected
+			})
+
+			if protected {
+				break
+			}
+		}
+		if protected {
+			break
+		}
+	}
+
+	a.protected[id] = protected
+	return protected
+}
+
+// internalCallee returns the analyzed function the node calls internally, or nil if the node is not
+// an internal call of an analyzed function.
+func (a *Analysis) internalCallee(node ast.Node[ast.NodeType]) *AnalyzedFunction {
+	call, ok := node.(*ast.FunctionCall)
+	if !ok {
+		return nil
+	}
+
+	primary, ok := call.GetExpression().(*ast.PrimaryExpression)
+	if !ok || primary == nil || primary.GetReferencedDeclaration() == 0 {
+		return nil
+	}
+	return a.byId[primary.GetReferencedDeclaration()]
+}
+
+// NewFinding returns the issue detected by the detector. The message holds a %s verb for each of the
+// elements, which is replaced with a reference to the element within the description and with a
+// link to the element within the markdown.
+func (a *Analysis) NewFinding(detector NativeDetector, message string, elements ...Element) Detector {
+	descriptions := make([]any, len(elements))
+	links := make([]any, len(elements))
+	hash := sha256.New()
+	hash.Write([]byte(detector.Check()))
+
+	for i, element := range elements {
+		label, location := elementLabel(element), elementLocation(element)
+		descriptions[i] = fmt.Sprintf("%s (%s)", label, location)
+		links[i] = fmt.Sprintf("[%s](%s)", label, location)
+		hash.Write([]byte(fmt.Sprintf("%s:%s:%d", element.Type, label, element.SourceMapping.Start)))
+	}
+
+	toReturn := Detector{
+		Elements:    elements,
+		Description: fmt.Sprintf(message, descriptions...),
+		Markdown:    fmt.Sprintf(message, links...),
+		ID:          hex.EncodeToString(hash.Sum(nil)),
+		Check:       detector.Check(),
+		Impact:      detector.Impact().String(),
+		Confidence:  detector.Confidence().String(),
+	}
+
+	if len(elements) > 0 {
+		toReturn.FirstMarkdownElement = elementLocation(elements[0])
+	}
+
+	return toReturn
+}
+
+// ContractElement returns the element of the contract.
+func (a *Analysis) ContractElement(contract *ir.Contract) Element {
+	return Element{
+		Type:          "contract",
+		Name:          contract.GetName(),
+		SourceMapping: a.sourceMapping(contract.GetSrc()),
+	}
+}
+
+// FunctionElement returns the element of the function declared by the contract.
+func (a *Analysis) FunctionElement(contract *ir.Contract, function *ir.Function) Element {
+	parent := a.ContractElement(contract)
+	return Element{
+		Type:               "function",
+		Name:               function.GetName(),
+		SourceMapping:      a.sourceMapping(function.GetSrc()),
+		TypeSpecificFields: TypeSpecificFields{Parent: &parent},
+		Signature:          function.GetAST().GetSignatureRaw(),
+	}
+}
+
+// NodeElement returns the element of the statement or expression executed by the function.
+func (a *Analysis) NodeElement(contract *ir.Contract, function *ir.Function, node ast.Node[ast.NodeType]) Element {
+	parent := a.FunctionElement(contract, function)
+	src := node.GetSrc()
+	return Element{
+		Type:               "node",
+		Name:               strings.TrimSuffix(a.text(src), ";"),
+		SourceMapping:      a.sourceMapping(src),
+		TypeSpecificFields: TypeSpecificFields{Parent: &parent},
+	}
+}
+
+// VariableElement returns the element of the state variable declared by the contract.
+func (a *Analysis) VariableElement(contract *ir.Contract, variable *ir.StateVariable) Element {
+	parent := a.ContractElement(contract)
+	return Element{
+		Type:               "variable",
+		Name:               variable.GetName(),
+		SourceMapping:      a.sourceMapping(variable.GetSrc()),
+		TypeSpecificFields: TypeSpecificFields{Parent: &parent},
+		AdditionalFields: &AdditionalFields{
+			UnderlyingType: variable.GetType(),
+			VariableName:   variable.GetName(),
+		},
+	}
+}
+
+// sourceRange returns the source unit the offset within the combined source belongs to, along with
+// the content of the source unit.
+func (a *Analysis) sourceRange(offset int64) (*solgo.SourceRange, []rune) {
+	for _, sourceRange := range a.ranges {
+		if sourceRange.Contains(offset) {
+			content, ok := a.contents[sourceRange.Unit]
+			if !ok {
+				content = []rune(sourceRange.Unit.Content)
+				a.contents[sourceRange.Unit] = content
+			}
+			return sourceRange, content
+		}
+	}
+	return nil, nil
+}
+
+// sourceMapping returns the location of the source node within the source unit declaring it.
+func (a *Analysis) sourceMapping(src ast.SrcNode) SourceMapping {
+	sourceRange, content := a.sourceRange(src.Start)
+	if sourceRange == nil {
+		return SourceMapping{
+			Start:  int(src.Start),
+			Length: int(src.Length),
+			Lines:  []int32{int32(src.Line)},
+		}
+	}
+
+	start := int(src.Start - sourceRange.Start)
+	end := min(start+int(src.Length), len(content))
+
+	toReturn := SourceMapping{
+		Start:            start,
+		Length:           end - start,
+		FilenameRelative: sourceRange.Unit.Path,
+		FilenameAbsolute: sourceRange.Unit.Path,
+		FilenameShort:    filepath.Base(sourceRange.Unit.Path),
+		IsDependency:     a.builder.GetSources() != nil && sourceRange.Unit.Name != a.builder.GetSources().EntrySourceUnitName,
+	}
+
+	if absolute, err := filepath.Abs(sourceRange.Unit.Path); err == nil {
+		toReturn.FilenameAbsolute = absolute
+	}
+
+	line, column := int32(1), 1
+	for i := 0; i < end; i++ {
+		if i == start {
+			toReturn.StartingColumn = column
+		}
+		if i >= start {
+			if len(toReturn.Lines) == 0 || toReturn.Lines[len(toReturn.Lines)-1] != line {
+				toReturn.Lines = append(toReturn.Lines, line)
+			}
+		}
+		if content[i] == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+	}
+	toReturn.EndingColumn = column
+
+	return toReturn
+}
+
+// text returns the source code of the source node, with its whitespace collapsed.
+func (a *Analysis) text(src ast.SrcNode) string {
+	sourceRange, content := a.sourceRange(src.Start)
+	if sourceRange == nil {
+		return ""
+	}
+
+	start := int(src.Start - sourceRange.Start)
+	end := min(start+int(src.Length), len(content))
+	if start < 0 || start >= end {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(content[start:end])), " ")
+}
+
+// elementLabel returns the name the element is referred to by within descriptions.
+func elementLabel(element Element) string {
+	parent := element.TypeSpecificFields.Parent
+	switch {
+	case element.Type == "function" && parent != nil:
+		return parent.Name + "." + element.Signature
+	case element.Type == "variable" && parent != nil:
+		return parent.Name + "." + element.Name
+	}
+	return element.Name
+}
+
+// elementLocation returns the file and lines of the element, such as Vault.sol#L10-L12.
+func elementLocation(element Element) string {
+	lines := element.SourceMapping.Lines
+	switch len(lines) {
+	case 0:
+		return element.SourceMapping.FilenameShort
+	case 1:
+		return fmt.Sprintf("%s#L%d", element.SourceMapping.FilenameShort, lines[0])
+	}
+	return fmt.Sprintf("%s#L%d-L%d", element.SourceMapping.FilenameShort, lines[0], lines[len(lines)-1])
+}
+
+// IsConditional returns true if the block branches or reverts depending on its last statement.
+func IsConditional(block *cfg.BasicBlock) bool {
+	for _, edge := range block.GetSuccessors() {
+		switch edge.GetKind() {
+		case cfg.EdgeTrue, cfg.EdgeFalse, cfg.EdgeRevert:
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsNode returns true if the node or any of its descendants matches.
+func ContainsNode(node ast.Node[ast.NodeType], match func(node ast.Node[ast.NodeType]) bool) bool {
+	if ast.IsNilNode(node) {
+		return false
+	}
+	if match(node) {
+		return true
+	}
+	for _, child := range node.GetNodes() {
+		if ContainsNode(child, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlobal returns true if the node is the member of the global variable, such as msg.sender.
+func isGlobal(node ast.Node[ast.NodeType], base string, member string) bool {
+	access, ok := node.(*ast.MemberAccessExpression)
+	if !ok || access == nil || access.GetMemberName() != member {
+		return false
+	}
+
+	primary, ok := access.GetExpression().(*ast.PrimaryExpression)
+	return ok && primary != nil && primary.GetName() == base && primary.GetReferencedDeclaration() == 0
+}
+
+// isCaller returns true if the node is the caller, that is msg.sender or a call of _msgSender.
+func isCaller(node ast.Node[ast.NodeType]) bool {
+	if isGlobal(node, "msg", "sender") {
+		return true
+	}
+
+	if call, ok := node.(*ast.FunctionCall); ok && call != nil {
+		if primary, ok := call.GetExpression().(*ast.PrimaryExpression); ok && primary != nil {
+			return primary.GetName() == "_msgSender"
+		}
+	}
+	return false
+}
+
+// lowLevelCall returns the member and the target of the low level call, such as call, delegatecall
+// or send, or empty values if the node is not a low level call.
+func lowLevelCall(node ast.Node[ast.NodeType]) (string, ast.Node[ast.NodeType]) {
+	call, ok := node.(*ast.FunctionCall)
+	if !ok || call == nil {
+		return "", nil
+	}
+
+	expression := call.GetExpression()
+	if option, ok := expression.(*ast.FunctionCallOption); ok && option != nil {
+		expression = option.GetExpression()
+	}
+
+	access, ok := expression.(*ast.MemberAccessExpression)
+	if !ok || access == nil || access.GetReferencedDeclaration() != 0 {
+		return "", nil
+	}
+
+	switch access.GetMemberName() {
+	case "call", "delegatecall", "staticcall", "callcode", "send", "transfer":
+		return access.GetMemberName(), access.GetExpression()
+	}
+	return "", nil
+}
@@ -8,13 +8,15 @@ import (
 )
 
 // Auditor represents a structure that manages the auditing process
-// of smart contracts using the Slither tool.
+// of smart contracts using the Slither tool, or the native detectors
+// on machines where Slither is not installed and the native fallback is enabled.
 type Auditor struct {
 	ctx      context.Context // Context for the auditor operations.
 	config   *Config         // Configuration for the Slither tool.
 	sources  *solgo.Sources  // Sources of the smart contracts to be audited.
 	compiler *solc.Solc      // Instance of the solc compiler.
 	slither  *Slither        // Instance of the Slither tool.
+	native   *Native         // Instance of the native auditor.
 }
 
 // NewAuditor initializes a new Auditor instance with the provided context,
@@ -39,13 +41,15 @@ func NewAuditor(ctx context.Context, compiler *solc.Solc, config *Config, source
 		sources:  sources,
 		slither:  slither,
 		compiler: compiler,
+		native:   NewNative(ctx, nil),
 	}, nil
 }
 
 // IsReady checks if the Auditor is ready to perform an analysis.
-// It ensures that the Slither tool is installed and that the sources are prepared.
+// It ensures that the Slither tool is installed, or that the native fallback is enabled
+// within the configuration, and that the sources are prepared.
 func (a *Auditor) IsReady() bool {
-	return a.slither.IsInstalled() && a.sources.ArePrepared()
+	return (a.slither.IsInstalled() || a.config.NativeFallback) && a.sources.ArePrepared()
 }
 
 // GetConfig returns the configuration used by the Auditor.
@@ -63,9 +67,25 @@ func (a *Auditor) GetSlither() *Slither {
 	return a.slither
 }
 
+// GetNative returns the instance of the native auditor used by the Auditor.
+func (a *Auditor) GetNative() *Native {
+	return a.native
+}
+
+// SetRegistry replaces the registry of the detectors run by the native auditor.
+func (a *Auditor) SetRegistry(registry *Registry) {
+	a.native = NewNative(a.ctx, registry)
+}
+
 // Analyze performs an analysis of the smart contracts using the Slither tool.
+// When Slither is not installed and the native fallback is enabled within the configuration,
+// the native detectors are run instead. The engine producing the report is recorded in it.
 // It returns the analysis response or an error if the analysis fails.
 func (a *Auditor) Analyze() (*Report, error) {
+	if a.config.NativeFallback && !a.slither.IsInstalled() {
+		return a.AnalyzeNative()
+	}
+
 	response, _, err := a.slither.Analyze(a.sources)
 	if err != nil {
 		return nil, err
@@ -73,3 +93,9 @@ func (a *Auditor) Analyze() (*Report, error) {
 
 	return response, nil
 }
+
+// AnalyzeNative performs an analysis of the smart contracts using the native detectors only.
+// It returns the analysis response or an error if the sources cannot be parsed.
+func (a *Auditor) AnalyzeNative() (*Report, error) {
+	return a.native.Analyze(a.sources)
+}
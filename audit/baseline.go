@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suppression is a known and accepted issue, identified by its fingerprint, along with the reason
+// it was accepted.
+type Suppression struct {
+	Fingerprint   string `yaml:"fingerprint"`
+	Check         string `yaml:"check"`
+	Description   string `yaml:"description,omitempty"` // Description of the issue when it was accepted.
+	Justification string `yaml:"justification"`         // Reason the issue was accepted.
+}
+
+// Baseline holds the suppressions of known and accepted issues, so that they are not reported
+// again by following audits. Baselines are stored as YAML documents, such as:
+//
+//	suppressions:
+//	  - fingerprint: 5f1c...
+//	    check: reentrancy
+//	    # Reviewed during the upgrade.
+//	    justification: withdraw is only callable by the vault manager
+//
+// Comments of the parsed documents, such as the one above, are kept when the baseline is saved.
+type Baseline struct {
+	Suppressions []*Suppression `yaml:"suppressions"`
+	document     *yaml.Node     // Parsed document, holding its comments.
+}
+
+// NewBaseline creates and returns a new baseline suppressing all of the issues detected by the
+// report with the given justification.
+func NewBaseline(report *Report, justification string) *Baseline {
+	toReturn := &Baseline{Suppressions: make([]*Suppression, 0)}
+	for _, detector := range reportDetectors(report) {
+		toReturn.Add(detector, justification)
+	}
+	return toReturn
+}
+
+// ParseBaseline parses the baseline from the YAML document. Every suppression has to hold a
+// fingerprint and a justification.
+func ParseBaseline(data []byte) (*Baseline, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	toReturn := &Baseline{Suppressions: make([]*Suppression, 0)}
+	if err := decoder.Decode(toReturn); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBaseline, err)
+	}
+
+	toReturn.document = &yaml.Node{}
+	if err := yaml.Unmarshal(data, toReturn.document); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBaseline, err)
+	}
+
+	for i, suppression := range toReturn.Suppressions {
+		if suppression == nil || suppression.Fingerprint == "" {
+			return nil, fmt.Errorf("%w: suppression %d: fingerprint is not set", ErrInvalidBaseline, i)
+		}
+		if suppression.Justification == "" {
+			return nil, fmt.Errorf("%w: suppression %s: justification is not set", ErrInvalidBaseline, suppression.Fingerprint)
+		}
+	}
+
+	return toReturn, nil
+}
+
+// LoadBaseline reads and parses the baseline from the YAML file at the given path.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBaseline(data)
+}
+
+// Save writes the baseline as a YAML document to the file at the given path. Comments of the parsed
+// document are written along with the suppressions and fields they belong to.
+func (b *Baseline) Save(path string) error {
+	document := &yaml.Node{}
+	if err := document.Encode(b); err != nil {
+		return err
+	}
+
+	if b.document != nil && len(b.document.Content) > 0 {
+		keepComments(b.document.Content[0], document)
+	}
+
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// keepComments copies the comments of the parsed node to the encoded one, along with the comments of
+// their children. Fields are matched by their keys, and suppressions by their fingerprints.
+func keepComments(parsed *yaml.Node, encoded *yaml.Node) {
+	encoded.HeadComment = parsed.HeadComment
+	encoded.LineComment = parsed.LineComment
+	encoded.FootComment = parsed.FootComment
+
+	switch {
+	case parsed.Kind == yaml.MappingNode && encoded.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(encoded.Content); i += 2 {
+			for j := 0; j+1 < len(parsed.Content); j += 2 {
+				if parsed.Content[j].Value == encoded.Content[i].Value {
+					keepComments(parsed.Content[j], encoded.Content[i])
+					keepComments(parsed.Content[j+1], encoded.Content[i+1])
+					break
+				}
+			}
+		}
+	case parsed.Kind == yaml.SequenceNode && encoded.Kind == yaml.SequenceNode:
+		for _, item := range encoded.Content {
+			for _, parsedItem := range parsed.Content {
+				if fingerprint := mappingValue(item, "fingerprint"); fingerprint != "" && fingerprint == mappingValue(parsedItem, "fingerprint") {
+					keepComments(parsedItem, item)
+					break
+				}
+			}
+		}
+	}
+}
+
+// mappingValue returns the value of the given key of the mapping node.
+func mappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// Add suppresses the detected issue with the given justification, unless it is already suppressed.
+func (b *Baseline) Add(detector Detector, justification string) {
+	if b.GetSuppression(detector) != nil {
+		return
+	}
+
+	b.Suppressions = append(b.Suppressions, &Suppression{
+		Fingerprint:   detector.Fingerprint(),
+		Check:         detector.Check,
+		Description:   detector.Description,
+		Justification: justification,
+	})
+}
+
+// GetSuppressions returns all of the suppressions of the baseline.
+func (b *Baseline) GetSuppressions() []*Suppression {
+	return b.Suppressions
+}
+
+// GetSuppression returns the suppression of the detected issue, or nil if the issue is not suppressed.
+func (b *Baseline) GetSuppression(detector Detector) *Suppression {
+	fingerprint := detector.Fingerprint()
+	for _, suppression := range b.Suppressions {
+		if suppression.Fingerprint == fingerprint {
+			return suppression
+		}
+	}
+	return nil
+}
+
+// IsSuppressed returns true if the detected issue is suppressed by the baseline.
+func (b *Baseline) IsSuppressed(detector Detector) bool {
+	return b.GetSuppression(detector) != nil
+}
+
+// Apply returns a copy of the report without the suppressed issues, along with the issues it removed.
+func (b *Baseline) Apply(report *Report) (*Report, []Detector) {
+	if report == nil {
+		return nil, nil
+	}
+
+	detectors, suppressed := make([]Detector, 0), make([]Detector, 0)
+	for _, detector := range reportDetectors(report) {
+		if b.IsSuppressed(detector) {
+			suppressed = append(suppressed, detector)
+		} else {
+			detectors = append(detectors, detector)
+		}
+	}
+
+	return &Report{
+		Success: report.Success,
+		Error:   report.Error,
+		Engine:  report.Engine,
+		Results: &Results{Detectors: detectors},
+	}, suppressed
+}
+
+// GetStale returns the suppressions of issues no longer detected by the report, which can be removed
+// from the baseline.
+func (b *Baseline) GetStale(report *Report) []*Suppression {
+	fingerprints := make(map[string]bool)
+	for _, detector := range reportDetectors(report) {
+		fingerprints[detector.Fingerprint()] = true
+	}
+
+	toReturn := make([]*Suppression, 0)
+	for _, suppression := range b.Suppressions {
+		if !fingerprints[suppression.Fingerprint] {
+			toReturn = append(toReturn, suppression)
+		}
+	}
+	return toReturn
+}
@@ -28,6 +28,7 @@ type Config struct {
 	tempDir         string   // Directory to store temporary contract files.
 	Arguments       []string // Arguments to pass to the Slither tool.
 	CompilerVersion string   // Compiler version to use.
+	NativeFallback  bool     // Run the native detectors when the Slither tool is not installed.
 }
 
 // NewDefaultConfig creates and returns a default configuration for Slither.
@@ -100,6 +101,11 @@ func (c *Config) GetTempDir() string {
 	return c.tempDir
 }
 
+// SetNativeFallback sets whether the native detectors are run when the Slither tool is not installed.
+func (c *Config) SetNativeFallback(enabled bool) {
+	c.NativeFallback = enabled
+}
+
 // SetArguments sets the arguments to be passed to the Slither tool.
 func (c *Config) SetArguments(args []string) {
 	c.Arguments = args
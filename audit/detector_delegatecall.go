@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/dataflow"
+)
+
+// ArbitraryDelegatecallDetector detects delegatecalls to targets the caller controls, which lets
+// anyone execute arbitrary code within the storage of the contract.
+type ArbitraryDelegatecallDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *ArbitraryDelegatecallDetector) Check() string {
+	return "controlled-delegatecall"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *ArbitraryDelegatecallDetector) Impact() ImpactLevel {
+	return ImpactHigh
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *ArbitraryDelegatecallDetector) Confidence() ConfidenceLevel {
+	return ConfidenceMedium
+}
+
+// Detect returns the delegatecalls of unprotected functions whose target is tainted by the caller.
+// Delegatecalls forwarding the calldata to a fixed implementation, as proxies do, are not considered.
+func (d *ArbitraryDelegatecallDetector) Detect(analysis *Analysis) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, finding := range analysis.GetTaintFindings() {
+		if finding.GetSink() != dataflow.SinkDelegatecall || finding.GetGraph().GetFunction() == nil {
+			continue
+		}
+
+		function := analysis.GetFunction(finding.GetGraph().GetFunction().GetId())
+		if function == nil || !function.IsEntryPoint() {
+			continue
+		}
+
+		target := d.target(finding.GetNode())
+		if ast.IsNilNode(target) || !finding.IsOperandTainted(target.GetId()) || analysis.IsProtected(function) {
+			continue
+		}
+
+		toReturn = append(toReturn, analysis.NewFinding(d, "%s uses delegatecall to an input controlled target: %s\n",
+			analysis.FunctionElement(function.Contract, function.Function),
+			analysis.NodeElement(function.Contract, function.Function, finding.GetNode()),
+		))
+	}
+
+	return toReturn
+}
+
+// target returns the address the delegatecall is made to.
+func (d *ArbitraryDelegatecallDetector) target(node ast.Node[ast.NodeType]) ast.Node[ast.NodeType] {
+	if call, ok := node.(*ast.YulFunctionCallStatement); ok && call != nil {
+		// Delegatecall of inline assembly takes the gas, followed by the target.
+		if arguments := call.GetArguments(); len(arguments) > 1 {
+			return arguments[1]
+		}
+		return nil
+	}
+
+	_, target := lowLevelCall(node)
+	return target
+}
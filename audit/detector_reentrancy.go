@@ -0,0 +1,312 @@
+package audit
+
+import (
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/cfg"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// ReentrancyDetector detects functions writing state variables after making an external call,
+// which lets the callee re-enter the function while its state is stale.
+type ReentrancyDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *ReentrancyDetector) Check() string {
+	return "reentrancy"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *ReentrancyDetector) Impact() ImpactLevel {
+	return ImpactHigh
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *ReentrancyDetector) Confidence() ConfidenceLevel {
+	return ConfidenceMedium
+}
+
+// Detect returns the functions writing state variables on any path following an external call,
+// made directly or within the internal functions they call. Calls forwarding a limited amount of gas, such as transfer and send, along with static calls and
+// functions guarded by a nonReentrant modifier writing a storage lock before the function body are not
+// considered. Functions invoked with such a modifier writing no lock are reported at low confidence.
+func (d *ReentrancyDetector) Detect(analysis *Analysis) []Detector {
+	externalCalls := make(map[int64]bool)
+	for _, edge := range analysis.GetCallGraph().GetEdges() {
+		if edge.GetKind() != cfg.CallExternal && edge.GetKind() != cfg.CallDelegatecall {
+			continue
+		}
+		switch edge.GetTo() {
+		case "staticcall", "send", "transfer":
+			continue
+		}
+		externalCalls[edge.GetCallId()] = true
+	}
+
+	toReturn := make([]Detector, 0)
+	for _, function := range analysis.GetFunctions() {
+		mutability := function.Function.GetStateMutability()
+		if mutability == ast_pb.Mutability_VIEW || mutability == ast_pb.Mutability_PURE {
+			continue
+		}
+
+		guarded, locked := d.guard(analysis, function)
+		if locked {
+			continue
+		}
+
+		graph := function.GetGraph()
+		calls, writes := make([]ast.Node[ast.NodeType], 0), make([]ast.Node[ast.NodeType], 0)
+		seen := make(map[int64]bool)
+
+		for _, block := range graph.GetBlocks() {
+			for i, statement := range block.GetStatements() {
+				var call ast.Node[ast.NodeType]
+				ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+					if externalCalls[node.GetId()] {
+						call = node
+						return true
+					}
+					if callee := analysis.internalCallee(node); callee != nil && d.callsExternally(analysis, callee, externalCalls, map[int64]bool{function.Function.GetId(): true}) {
+						call = node
+						return true
+					}
+					return false
+				})
+				if call == nil {
+					continue
+				}
+
+				found := d.writesAfter(function, block, i)
+				if len(found) > 0 && !seen[call.GetId()] {
+					seen[call.GetId()] = true
+					calls = append(calls, call)
+				}
+				for _, write := range found {
+					if !seen[write.GetId()] {
+						seen[write.GetId()] = true
+						writes = append(writes, write)
+					}
+				}
+			}
+		}
+
+		if len(calls) == 0 {
+			continue
+		}
+
+		message := strings.Builder{}
+		message.WriteString("Reentrancy in %s:\n\tExternal calls:\n")
+		elements := []Element{analysis.FunctionElement(function.Contract, function.Function)}
+		for _, call := range calls {
+			message.WriteString("\t- %s\n")
+			elements = append(elements, analysis.NodeElement(function.Contract, function.Function, call))
+		}
+		message.WriteString("\tState variables written after the calls:\n")
+		for _, write := range writes {
+			message.WriteString("\t- %s\n")
+			elements = append(elements, analysis.NodeElement(function.Contract, function.Function, write))
+		}
+
+		finding := analysis.NewFinding(d, message.String(), elements...)
+		if guarded {
+			// The modifier is named after preventing reentrancy but holds no lock it could be
+			// trusted with, so the finding is left for manual review.
+			finding.Confidence = ConfidenceLow.String()
+		}
+		toReturn = append(toReturn, finding)
+	}
+
+	return toReturn
+}
+
+// callsExternally returns true if the function, or any of the internal functions it calls in turn,
+// makes one of the external calls.
+func (d *ReentrancyDetector) callsExternally(analysis *Analysis, function *AnalyzedFunction, externalCalls map[int64]bool, visited map[int64]bool) bool {
+	if visited[function.Function.GetId()] {
+		return false
+	}
+	visited[function.Function.GetId()] = true
+
+	for _, block := range function.GetGraph().GetBlocks() {
+		for _, statement := range block.GetStatements() {
+			found := ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+				if externalCalls[node.GetId()] {
+					return true
+				}
+				callee := analysis.internalCallee(node)
+				return callee != nil && d.callsExternally(analysis, callee, externalCalls, visited)
+			})
+			if found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// guard returns whether the function is invoked with a modifier named after preventing reentrancy,
+// and whether any such modifier writes a storage lock before it enters the function body.
+func (d *ReentrancyDetector) guard(analysis *Analysis, function *AnalyzedFunction) (bool, bool) {
+	named := false
+	for _, modifier := range function.Function.GetModifiers() {
+		name := strings.ToLower(modifier.GetName())
+		if !strings.Contains(name, "nonreentrant") && !strings.Contains(name, "noreentrant") {
+			continue
+		}
+		named = true
+
+		if definition := d.modifierDefinition(analysis, function, modifier); definition != nil && d.locks(analysis, definition) {
+			return true, true
+		}
+	}
+	return named, false
+}
+
+// modifierDefinition returns the definition of the modifier the function is invoked with, or nil if
+// it cannot be resolved.
+func (d *ReentrancyDetector) modifierDefinition(analysis *Analysis, function *AnalyzedFunction, modifier *ir.Modifier) *ast.ModifierDefinition {
+	astBuilder := analysis.GetBuilder().GetAstBuilder()
+	tree := astBuilder.GetTree()
+	if symbols := astBuilder.GetSymbolTable(); symbols != nil {
+		if definition, ok := tree.GetById(symbols.GetReference(modifier.GetId())).(*ast.ModifierDefinition); ok {
+			return definition
+		}
+	}
+
+	// Trees imported from JSON have no symbol table, so the modifier is looked up by its name
+	// within the contract declaring the function.
+	contract := tree.GetById(function.Function.GetAST().GetScope())
+	if ast.IsNilNode(contract) {
+		return nil
+	}
+	for _, node := range contract.GetNodes() {
+		if definition, ok := node.(*ast.ModifierDefinition); ok && definition.GetName() == modifier.GetName() {
+			return definition
+		}
+	}
+	return nil
+}
+
+// locks returns true if the modifier writes a state variable before reaching its placeholder, either
+// directly, within inline assembly or within the internal functions it calls.
+func (d *ReentrancyDetector) locks(analysis *Analysis, definition *ast.ModifierDefinition) bool {
+	tree := analysis.GetBuilder().GetAstBuilder().GetTree()
+	reached := false
+
+	var walk func(node ast.Node[ast.NodeType]) bool
+	walk = func(node ast.Node[ast.NodeType]) bool {
+		if reached || ast.IsNilNode(node) {
+			return false
+		}
+		if node.GetType() == ast_pb.NodeType_PLACEHOLDER_STATEMENT {
+			reached = true
+			return false
+		}
+
+		switch nodeCtx := node.(type) {
+		case *ast.Assignment:
+			if isStateVariable(tree, nodeCtx.GetLeftExpression()) {
+				return true
+			}
+		case *ast.UnaryPrefix:
+			if isIncrement(nodeCtx.GetOperator()) && isStateVariable(tree, nodeCtx.GetExpression()) {
+				return true
+			}
+		case *ast.UnarySuffix:
+			if isIncrement(nodeCtx.GetOperator()) && isStateVariable(tree, nodeCtx.GetExpression()) {
+				return true
+			}
+		case *ast.YulFunctionCallStatement:
+			if name := nodeCtx.GetFunctionName(); name != nil && (name.GetName() == "sstore" || name.GetName() == "tstore") {
+				return true
+			}
+		}
+
+		if callee := analysis.internalCallee(node); callee != nil {
+			for _, definition := range callee.Analysis.GetDefinitions() {
+				if definition.GetVariable().IsStateVariable() {
+					return true
+				}
+			}
+		}
+
+		for _, child := range node.GetNodes() {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return walk(definition.GetBody())
+}
+
+// isIncrement returns true if the operator increments or decrements its operand.
+func isIncrement(operator ast_pb.Operator) bool {
+	return operator == ast_pb.Operator_INCREMENT || operator == ast_pb.Operator_DECREMENT
+}
+
+// isStateVariable returns true if the expression refers to a state variable, or to a member or an
+// element of one.
+func isStateVariable(tree *ast.Tree, node ast.Node[ast.NodeType]) bool {
+	for !ast.IsNilNode(node) {
+		switch nodeCtx := node.(type) {
+		case *ast.IndexAccess:
+			node = nodeCtx.GetBaseExpression()
+		case *ast.MemberAccessExpression:
+			node = nodeCtx.GetExpression()
+		case *ast.PrimaryExpression:
+			_, ok := tree.GetById(nodeCtx.GetReferencedDeclaration()).(*ast.StateVariableDeclaration)
+			return ok
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// writesAfter returns the statements writing state variables on any path following the statement
+// at the given index of the block.
+func (d *ReentrancyDetector) writesAfter(function *AnalyzedFunction, block *cfg.BasicBlock, index int) []ast.Node[ast.NodeType] {
+	graph := function.GetGraph()
+	toReturn := make([]ast.Node[ast.NodeType], 0)
+
+	collect := func(statements []*cfg.Statement) {
+		for _, statement := range statements {
+			for _, definition := range function.Analysis.GetDefinitionsByStatement(statement) {
+				if definition.GetVariable().IsStateVariable() {
+					toReturn = append(toReturn, statement.GetNode())
+					break
+				}
+			}
+		}
+	}
+
+	collect(block.GetStatements()[index+1:])
+
+	visited := make(map[int]bool)
+	queue := make([]int, 0)
+	for _, edge := range block.GetSuccessors() {
+		queue = append(queue, edge.GetTo())
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		next := graph.GetBlock(current)
+		collect(next.GetStatements())
+		for _, edge := range next.GetSuccessors() {
+			queue = append(queue, edge.GetTo())
+		}
+	}
+
+	return toReturn
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"github.com/unpackdev/solgo/ast"
+)
+
+// UnprotectedSelfdestructDetector detects functions anyone can call to destroy the contract.
+type UnprotectedSelfdestructDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *UnprotectedSelfdestructDetector) Check() string {
+	return "suicidal"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *UnprotectedSelfdestructDetector) Impact() ImpactLevel {
+	return ImpactHigh
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *UnprotectedSelfdestructDetector) Confidence() ConfidenceLevel {
+	return ConfidenceHigh
+}
+
+// Detect returns the public and external functions calling selfdestruct, either directly or within
+// inline assembly, without checking the caller.
+func (d *UnprotectedSelfdestructDetector) Detect(analysis *Analysis) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, function := range analysis.GetFunctions() {
+		if !function.IsEntryPoint() {
+			continue
+		}
+
+		var call ast.Node[ast.NodeType]
+		for _, block := range function.GetGraph().GetBlocks() {
+			for _, statement := range block.GetStatements() {
+				ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+					if d.isSelfdestruct(node) {
+						call = node
+						return true
+					}
+					return false
+				})
+				if call != nil {
+					break
+				}
+			}
+			if call != nil {
+				break
+			}
+		}
+
+		if call == nil || analysis.IsProtected(function) {
+			continue
+		}
+
+		toReturn = append(toReturn, analysis.NewFinding(d, "%s allows anyone to destruct the contract: %s\n",
+			analysis.FunctionElement(function.Contract, function.Function),
+			analysis.NodeElement(function.Contract, function.Function, call),
+		))
+	}
+
+	return toReturn
+}
+
+// isSelfdestruct returns true if the node calls selfdestruct or its deprecated suicide alias.
+func (d *UnprotectedSelfdestructDetector) isSelfdestruct(node ast.Node[ast.NodeType]) bool {
+	switch call := node.(type) {
+	case *ast.FunctionCall:
+		primary, ok := call.GetExpression().(*ast.PrimaryExpression)
+		return ok && primary != nil && primary.GetReferencedDeclaration() == 0 &&
+			(primary.GetName() == "selfdestruct" || primary.GetName() == "suicide")
+	case *ast.YulFunctionCallStatement:
+		name := call.GetFunctionName()
+		return name != nil && name.GetReferencedDeclaration() == 0 && name.GetName() == "selfdestruct"
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package audit
+
+// ShadowedStateDetector detects state variables declared again by a derived contract, so that the
+// functions of the base contract and the ones of the derived contract use different storage.
+type ShadowedStateDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *ShadowedStateDetector) Check() string {
+	return "shadowing-state"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *ShadowedStateDetector) Impact() ImpactLevel {
+	return ImpactHigh
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *ShadowedStateDetector) Confidence() ConfidenceLevel {
+	return ConfidenceHigh
+}
+
+// Detect returns the state variables sharing their name with a state variable of any of the base
+// contracts, along with the variables they shadow.
+func (d *ShadowedStateDetector) Detect(analysis *Analysis) []Detector {
+	root := analysis.GetRoot()
+
+	toReturn := make([]Detector, 0)
+	for _, contract := range root.GetContracts() {
+		for _, variable := range contract.GetStateVariables() {
+			for _, baseId := range contract.GetLinearizedBaseContracts() {
+				base := root.GetContractById(baseId)
+				if base == nil || base == contract {
+					continue
+				}
+
+				for _, shadowed := range base.GetStateVariables() {
+					if shadowed.GetName() != variable.GetName() {
+						continue
+					}
+
+					toReturn = append(toReturn, analysis.NewFinding(d, "%s shadows:\n\t- %s\n",
+						analysis.VariableElement(contract, variable),
+						analysis.VariableElement(base, shadowed),
+					))
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
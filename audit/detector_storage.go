@@ -0,0 +1,54 @@
+package audit
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// UninitializedStorageDetector detects local storage pointers declared without a value, which older
+// compilers point to the first slot of the storage, so that writing through them overwrites state
+// variables.
+type UninitializedStorageDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *UninitializedStorageDetector) Check() string {
+	return "uninitialized-storage"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *UninitializedStorageDetector) Impact() ImpactLevel {
+	return ImpactHigh
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *UninitializedStorageDetector) Confidence() ConfidenceLevel {
+	return ConfidenceHigh
+}
+
+// Detect returns the declarations of local storage variables without an initial value.
+func (d *UninitializedStorageDetector) Detect(analysis *Analysis) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, function := range analysis.GetFunctions() {
+		for _, block := range function.GetGraph().GetBlocks() {
+			for _, statement := range block.GetStatements() {
+				declaration, ok := statement.GetNode().(*ast.VariableDeclaration)
+				if !ok || declaration == nil || !ast.IsNilNode(declaration.InitialValue) {
+					continue
+				}
+
+				for _, variable := range declaration.Declarations {
+					if variable == nil || variable.StorageLocation != ast_pb.StorageLocation_STORAGE {
+						continue
+					}
+
+					toReturn = append(toReturn, analysis.NewFinding(d, "%s is a storage variable never initialized: %s\n",
+						analysis.FunctionElement(function.Contract, function.Function),
+						analysis.NodeElement(function.Contract, function.Function, declaration),
+					))
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// TxOriginDetector detects authorization checks made against tx.origin, which any contract the
+// origin interacts with can pass by calling on its behalf.
+type TxOriginDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *TxOriginDetector) Check() string {
+	return "tx-origin"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *TxOriginDetector) Impact() ImpactLevel {
+	return ImpactMedium
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *TxOriginDetector) Confidence() ConfidenceLevel {
+	return ConfidenceMedium
+}
+
+// Detect returns the comparisons of tx.origin made by the functions. Comparisons of tx.origin with
+// msg.sender, used to reject calls made by contracts, are not considered authorization checks.
+func (d *TxOriginDetector) Detect(analysis *Analysis) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, function := range analysis.GetFunctions() {
+		seen := make(map[int64]bool)
+		for _, block := range function.GetGraph().GetBlocks() {
+			for _, statement := range block.GetStatements() {
+				ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+					if seen[node.GetId()] || !d.isAuthorization(node) {
+						return false
+					}
+					seen[node.GetId()] = true
+
+					toReturn = append(toReturn, analysis.NewFinding(d, "%s uses tx.origin for authorization: %s\n",
+						analysis.FunctionElement(function.Contract, function.Function),
+						analysis.NodeElement(function.Contract, function.Function, statement.GetNode()),
+					))
+					return false
+				})
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// isAuthorization returns true if the node compares tx.origin with anything but msg.sender.
+func (d *TxOriginDetector) isAuthorization(node ast.Node[ast.NodeType]) bool {
+	operation, ok := node.(*ast.BinaryOperation)
+	if !ok || operation == nil {
+		return false
+	}
+	if operation.GetOperator() != ast_pb.Operator_EQUAL && operation.GetOperator() != ast_pb.Operator_NOT_EQUAL {
+		return false
+	}
+
+	left, right := operation.GetLeftExpression(), operation.GetRightExpression()
+	switch {
+	case isGlobal(left, "tx", "origin"):
+		return !isCaller(right)
+	case isGlobal(right, "tx", "origin"):
+		return !isCaller(left)
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/dataflow"
+)
+
+// UncheckedCallDetector detects low level calls whose success is never checked, so that failed
+// calls go unnoticed.
+type UncheckedCallDetector struct{}
+
+// Check returns the unique name of the check.
+func (d *UncheckedCallDetector) Check() string {
+	return "unchecked-lowlevel"
+}
+
+// Impact returns the severity of the issues the detector reports.
+func (d *UncheckedCallDetector) Impact() ImpactLevel {
+	return ImpactMedium
+}
+
+// Confidence returns how certain the detector is that the issues it reports are real.
+func (d *UncheckedCallDetector) Confidence() ConfidenceLevel {
+	return ConfidenceMedium
+}
+
+// Detect returns the low level calls, along with send, whose result is either discarded or stored
+// in a variable that is never read.
+func (d *UncheckedCallDetector) Detect(analysis *Analysis) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, function := range analysis.GetFunctions() {
+		unused := make(map[*dataflow.Definition]bool)
+		for _, definition := range function.Analysis.GetUnusedDefinitions() {
+			unused[definition] = true
+		}
+
+		for _, block := range function.GetGraph().GetBlocks() {
+			for _, statement := range block.GetStatements() {
+				call, success := d.lowLevelCall(statement.GetNode())
+				if call == nil {
+					continue
+				}
+
+				checked := false
+				if success != "" {
+					for _, definition := range function.Analysis.GetDefinitionsByStatement(statement) {
+						if definition.GetVariable().GetName() == success && !unused[definition] {
+							checked = true
+						}
+					}
+				}
+				if checked {
+					continue
+				}
+
+				toReturn = append(toReturn, analysis.NewFinding(d, "%s ignores return value by %s\n",
+					analysis.FunctionElement(function.Contract, function.Function),
+					analysis.NodeElement(function.Contract, function.Function, call),
+				))
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// lowLevelCall returns the low level call made by the statement along with the name of the variable
+// its success is stored in, which is empty if the success is discarded. Calls used within other
+// expressions, such as require(payable(to).send(amount)), are considered checked and nil is returned.
+func (d *UncheckedCallDetector) lowLevelCall(statement ast.Node[ast.NodeType]) (ast.Node[ast.NodeType], string) {
+	var value ast.Node[ast.NodeType]
+	var success string
+
+	switch node := statement.(type) {
+	case *ast.FunctionCall:
+		value = node
+	case *ast.VariableDeclaration:
+		value = node.InitialValue
+		if len(node.Declarations) > 0 && node.Declarations[0] != nil {
+			success = node.Declarations[0].GetName()
+		}
+	case *ast.Assignment:
+		if !ast.IsNilNode(node.GetExpression()) {
+			return d.lowLevelCall(node.GetExpression())
+		}
+		value = node.GetRightExpression()
+		switch left := node.GetLeftExpression().(type) {
+		case *ast.PrimaryExpression:
+			success = left.GetName()
+		case *ast.TupleExpression:
+			if components := left.GetComponents(); len(components) > 0 {
+				if primary, ok := components[0].(*ast.PrimaryExpression); ok && primary != nil {
+					success = primary.GetName()
+				}
+			}
+		}
+	default:
+		return nil, ""
+	}
+
+	member, _ := lowLevelCall(value)
+	switch member {
+	case "call", "delegatecall", "staticcall", "callcode", "send":
+		return value, success
+	}
+	return nil, ""
+}
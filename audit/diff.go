@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// DiffStatus describes how a detected issue changed between two reports.
+type DiffStatus string
+
+// String returns the string representation of the DiffStatus.
+func (s DiffStatus) String() string {
+	return string(s)
+}
+
+// Predefined statuses of detected issues.
+const (
+	DiffNew       DiffStatus = "new"       // Issue is only detected by the new report.
+	DiffFixed     DiffStatus = "fixed"     // Issue is only detected by the old report.
+	DiffUnchanged DiffStatus = "unchanged" // Issue is detected by both reports.
+)
+
+// Fingerprint returns the identifier of the detected issue that does not depend on where the issue
+// is located, so that it remains the same when the sources around the issue change. It is built
+// from the check and the type, name and parents of the elements, where names of statements are
+// their source code.
+func (d *Detector) Fingerprint() string {
+	hash := sha256.New()
+	hash.Write([]byte(d.Check))
+	for _, element := range d.Elements {
+		hash.Write([]byte{0})
+		hash.Write([]byte(elementPath(&element)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// elementPath returns the type and name of the element along with the ones of its parents, such
+// as function:Vault.withdraw(uint256).
+func elementPath(element *Element) string {
+	names := make([]string, 0)
+	for current := element; current != nil; current = current.TypeSpecificFields.Parent {
+		name := current.Signature
+		if name == "" {
+			name = strings.Join(strings.Fields(current.Name), " ")
+		}
+		names = append([]string{name}, names...)
+	}
+	return element.Type + ":" + strings.Join(names, ".")
+}
+
+// DiffEntry is a detected issue along with how it changed between two reports.
+type DiffEntry struct {
+	Status      DiffStatus `json:"status"`
+	Fingerprint string     `json:"fingerprint"`
+	Detector    Detector   `json:"detector"` // Issue of the new report, or of the old report when fixed.
+}
+
+// Diff is the comparison of two reports, such as the ones of two versions of a contract.
+type Diff struct {
+	Entries []DiffEntry `json:"entries"`
+}
+
+// DiffReports compares the old report with the new one, classifying every detected issue as new,
+// fixed or unchanged by its fingerprint. Issues detected more than once with the same fingerprint
+// are matched one to one, so that any additional occurrence is reported as new or fixed.
+func DiffReports(oldReport *Report, newReport *Report) *Diff {
+	toReturn := &Diff{Entries: make([]DiffEntry, 0)}
+
+	remaining := make(map[string][]Detector)
+	for _, detector := range reportDetectors(oldReport) {
+		fingerprint := detector.Fingerprint()
+		remaining[fingerprint] = append(remaining[fingerprint], detector)
+	}
+
+	for _, detector := range reportDetectors(newReport) {
+		fingerprint := detector.Fingerprint()
+		status := DiffNew
+		if len(remaining[fingerprint]) > 0 {
+			status = DiffUnchanged
+			remaining[fingerprint] = remaining[fingerprint][1:]
+		}
+		toReturn.Entries = append(toReturn.Entries, DiffEntry{Status: status, Fingerprint: fingerprint, Detector: detector})
+	}
+
+	for _, detector := range reportDetectors(oldReport) {
+		fingerprint := detector.Fingerprint()
+		if len(remaining[fingerprint]) > 0 {
+			remaining[fingerprint] = remaining[fingerprint][1:]
+			toReturn.Entries = append(toReturn.Entries, DiffEntry{Status: DiffFixed, Fingerprint: fingerprint, Detector: detector})
+		}
+	}
+
+	return toReturn
+}
+
+// GetEntries returns all of the compared issues.
+func (d *Diff) GetEntries() []DiffEntry {
+	return d.Entries
+}
+
+// GetNew returns the issues only detected by the new report.
+func (d *Diff) GetNew() []Detector {
+	return d.byStatus(DiffNew)
+}
+
+// GetFixed returns the issues only detected by the old report.
+func (d *Diff) GetFixed() []Detector {
+	return d.byStatus(DiffFixed)
+}
+
+// GetUnchanged returns the issues detected by both reports, as detected by the new one.
+func (d *Diff) GetUnchanged() []Detector {
+	return d.byStatus(DiffUnchanged)
+}
+
+// HasChanges returns true if any issue is new or fixed.
+func (d *Diff) HasChanges() bool {
+	for _, entry := range d.Entries {
+		if entry.Status != DiffUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// byStatus returns the issues with the given status.
+func (d *Diff) byStatus(status DiffStatus) []Detector {
+	toReturn := make([]Detector, 0)
+	for _, entry := range d.Entries {
+		if entry.Status == status {
+			toReturn = append(toReturn, entry.Detector)
+		}
+	}
+	return toReturn
+}
+
+// reportDetectors returns the detected issues of the report, which may be nil.
+func reportDetectors(report *Report) []Detector {
+	if report == nil || report.GetResults() == nil {
+		return nil
+	}
+	return report.GetResults().GetDetectors()
+}
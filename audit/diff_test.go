@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/tests/solgotest"
+)
+
+const diffContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Wallet {
+    address internal owner;
+
+    function authorize() public {
+        require(tx.origin == owner);
+        owner = msg.sender;
+    }
+
+    function kill() public {
+        selfdestruct(payable(msg.sender));
+    }
+}`
+
+// diffUpgradedContent moves every line of the contract, fixes the tx.origin check and introduces
+// a delegatecall.
+const diffUpgradedContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @title Wallet
+/// @notice Upgraded version of the wallet.
+contract Wallet {
+    address internal owner;
+
+    function authorize() public {
+        require(msg.sender == owner);
+        owner = msg.sender;
+    }
+
+    function execute(address target, bytes memory payload) public {
+        (bool ok, ) = target.delegatecall(payload);
+        require(ok);
+    }
+
+    function kill() public {
+        selfdestruct(payable(msg.sender));
+    }
+}`
+
+func newDiffReport(t *testing.T, content string) *Report {
+	report, err := NewNative(context.TODO(), nil).Analyze(solgotest.NewSourcesForTest(
+		&solgo.SourceUnit{Name: "Wallet", Path: "Wallet.sol", Content: content},
+	))
+	require.NoError(t, err)
+	return report
+}
+
+func TestDiffReports(t *testing.T) {
+	oldReport := newDiffReport(t, diffContent)
+	newReport := newDiffReport(t, diffUpgradedContent)
+
+	diff := DiffReports(oldReport, newReport)
+	assert.True(t, diff.HasChanges())
+
+	checks := func(detectors []Detector) []string {
+		toReturn := make([]string, 0, len(detectors))
+		for _, detector := range detectors {
+			toReturn = append(toReturn, detector.Check)
+		}
+		return toReturn
+	}
+
+	assert.ElementsMatch(t, []string{"controlled-delegatecall"}, checks(diff.GetNew()))
+	assert.ElementsMatch(t, []string{"tx-origin"}, checks(diff.GetFixed()))
+	assert.ElementsMatch(t, []string{"suicidal"}, checks(diff.GetUnchanged()))
+
+	// Fingerprints do not depend on lines, unlike the identifiers of the issues.
+	unchanged := diff.GetUnchanged()[0]
+	killed := oldReport.DetectorsByCheck("suicidal")[0]
+	assert.Equal(t, killed.Fingerprint(), unchanged.Fingerprint())
+	assert.NotEqual(t, killed.ID, unchanged.ID)
+
+	assert.False(t, DiffReports(newReport, newReport).HasChanges())
+	assert.Len(t, DiffReports(nil, newReport).GetNew(), len(newReport.GetResults().GetDetectors()))
+}
+
+func TestBaseline(t *testing.T) {
+	oldReport := newDiffReport(t, diffContent)
+	newReport := newDiffReport(t, diffUpgradedContent)
+
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	require.NoError(t, NewBaseline(oldReport, "accepted before the upgrade").Save(path))
+
+	baseline, err := LoadBaseline(path)
+	require.NoError(t, err)
+	require.Len(t, baseline.GetSuppressions(), 2)
+
+	filtered, suppressed := baseline.Apply(newReport)
+	require.Len(t, suppressed, 1)
+	assert.Equal(t, "suicidal", suppressed[0].Check)
+	require.Len(t, filtered.GetResults().GetDetectors(), 1)
+	assert.Equal(t, "controlled-delegatecall", filtered.GetResults().GetDetectors()[0].Check)
+	assert.Len(t, newReport.GetResults().GetDetectors(), 2)
+
+	stale := baseline.GetStale(newReport)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "tx-origin", stale[0].Check)
+
+	suppression := baseline.GetSuppression(suppressed[0])
+	require.NotNil(t, suppression)
+	assert.Equal(t, "accepted before the upgrade", suppression.Justification)
+
+	baseline.Add(suppressed[0], "accepted again")
+	assert.Len(t, baseline.GetSuppressions(), 2)
+}
+
+func TestParseBaseline(t *testing.T) {
+	baseline, err := ParseBaseline([]byte(`suppressions:
+  # Reviewed by the security team.
+  - fingerprint: abc
+    check: reentrancy
+    # Reviewed during the upgrade.
+    justification: only callable by the vault manager # See the access control.
+`))
+	require.NoError(t, err)
+	require.Len(t, baseline.GetSuppressions(), 1)
+	assert.Equal(t, "only callable by the vault manager", baseline.GetSuppressions()[0].Justification)
+
+	// Comments of the parsed suppressions are kept once the baseline is saved.
+	baseline.Add(Detector{Check: "tx-origin"}, "reviewed")
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	require.NoError(t, baseline.Save(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Reviewed by the security team.")
+	assert.Contains(t, string(data), "# Reviewed during the upgrade.\n      justification: only callable by the vault manager # See the access control.")
+
+	saved, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, baseline.GetSuppressions(), saved.GetSuppressions())
+
+	testCases := map[string]string{
+		"Missing Fingerprint":   "suppressions:\n  - check: reentrancy\n    justification: reviewed\n",
+		"Missing Justification": "suppressions:\n  - fingerprint: abc\n    check: reentrancy\n",
+		"Unknown Field":         "suppressions:\n  - fingerprint: abc\n    reason: reviewed\n",
+	}
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseBaseline([]byte(content))
+			assert.ErrorIs(t, err, ErrInvalidBaseline)
+		})
+	}
+
+	_, err = LoadBaseline(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,4 +1,7 @@
 // Package audit provides a comprehensive suite of tools for auditing
 // smart contracts. It integrates with the Slither static analysis tool
 // to facilitate in-depth contract analysis and ensures best practices in contract development.
+// Where Slither cannot be installed, a registry of native detectors running on the IR,
+// control flow graphs and data-flow analyses produces the same reports. User-defined rules, declared
+// in YAML and loaded with LoadRules, run alongside the native detectors.
 package audit
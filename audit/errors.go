@@ -11,4 +11,16 @@ var (
 
 	// ErrSourcesNotSet is returned when sources are not set
 	ErrSourcesNotSet = errors.New("sources are not set")
+
+	// ErrDetectorNotSet is returned when a nil detector is registered
+	ErrDetectorNotSet = errors.New("detector is not set")
+
+	// ErrDetectorAlreadyRegistered is returned when a detector with the same check is already registered
+	ErrDetectorAlreadyRegistered = errors.New("detector is already registered")
+
+	// ErrInvalidRule is returned when a user-defined rule cannot be parsed or validated
+	ErrInvalidRule = errors.New("invalid rule")
+
+	// ErrInvalidBaseline is returned when a baseline of suppressed issues cannot be parsed or validated
+	ErrInvalidBaseline = errors.New("invalid baseline")
 )
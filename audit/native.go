@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// Native represents an auditor running the native detectors of its registry on the IR, control
+// flow graphs and data-flow analyses of the sources. Unlike Slither, it requires no tooling to be
+// installed on the machine.
+type Native struct {
+	ctx      context.Context // Context for the native auditor operations.
+	registry *Registry       // Registry of the detectors to run.
+}
+
+// NewNative initializes a new native auditor running the detectors of the given registry. A nil
+// registry runs all of the built-in detectors.
+func NewNative(ctx context.Context, registry *Registry) *Native {
+	if registry == nil {
+		registry = NewDefaultRegistry()
+	}
+
+	return &Native{
+		ctx:      ctx,
+		registry: registry,
+	}
+}
+
+// GetRegistry returns the registry of the detectors the native auditor runs.
+func (n *Native) GetRegistry() *Registry {
+	return n.registry
+}
+
+// Analyze parses and builds the IR of the sources and runs the registered detectors on it.
+// Sources failing to parse are reported as an error, the same way Slither reports failed compilations.
+func (n *Native) Analyze(sources *solgo.Sources) (*Report, error) {
+	if sources == nil {
+		return nil, ErrSourcesNotSet
+	}
+
+	builder, err := ir.NewBuilderFromSources(n.ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := builder.Parse(); len(errs) > 0 {
+		return nil, fmt.Errorf("failed to parse sources: %w", errors.Join(errs...))
+	}
+
+	if err := builder.Build(); err != nil {
+		return nil, err
+	}
+
+	return n.AnalyzeBuilder(builder)
+}
+
+// AnalyzeBuilder runs the registered detectors on the IR already built by the given builder.
+func (n *Native) AnalyzeBuilder(builder *ir.Builder) (*Report, error) {
+	analysis, err := NewAnalysis(n.ctx, builder)
+	if err != nil {
+		return nil, err
+	}
+
+	detectors := make([]Detector, 0)
+	for _, detector := range n.registry.GetDetectors() {
+		detectors = append(detectors, detector.Detect(analysis)...)
+	}
+
+	return &Report{
+		Success: true,
+		Engine:  EngineNative,
+		Results: &Results{
+			Detectors: detectors,
+		},
+	}, nil
+}
@@ -0,0 +1,277 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo"
+	"github.com/unpackdev/solgo/tests/solgotest"
+)
+
+const nativeContent = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Base {
+    address internal owner;
+    uint256 internal total;
+}
+
+contract Vault is Base {
+    struct Data {
+        uint256 value;
+    }
+
+    mapping(address => uint256) internal balances;
+    uint256 internal total;
+    Data[] internal data;
+    uint256 internal status;
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+    }
+
+    modifier nonReentrant() {
+        _;
+    }
+
+    modifier nonReentrantLock() {
+        require(status == 0);
+        status = 1;
+        _;
+        status = 0;
+    }
+
+    modifier nonReentrantCall() {
+        enter();
+        _;
+        status = 0;
+    }
+
+    function enter() private {
+        require(status == 0);
+        status = 1;
+    }
+
+    function withdraw(uint256 amount) public {
+        require(balances[msg.sender] >= amount);
+        (bool ok, ) = msg.sender.call{value: amount}("");
+        require(ok);
+        balances[msg.sender] -= amount;
+    }
+
+    function guardedWithdraw(uint256 amount) public nonReentrant {
+        require(balances[msg.sender] >= amount);
+        (bool ok, ) = msg.sender.call{value: amount}("");
+        require(ok);
+        balances[msg.sender] -= amount;
+    }
+
+    function lockedWithdraw(uint256 amount) public nonReentrantLock {
+        require(balances[msg.sender] >= amount);
+        (bool ok, ) = msg.sender.call{value: amount}("");
+        require(ok);
+        balances[msg.sender] -= amount;
+    }
+
+    function calledLockWithdraw(uint256 amount) public nonReentrantCall {
+        require(balances[msg.sender] >= amount);
+        (bool ok, ) = msg.sender.call{value: amount}("");
+        require(ok);
+        balances[msg.sender] -= amount;
+    }
+
+    function safeWithdraw(uint256 amount) public {
+        balances[msg.sender] -= amount;
+        (bool ok, ) = msg.sender.call{value: amount}("");
+        require(ok);
+    }
+
+    function ignoreCall(address to) public onlyOwner {
+        to.call("");
+    }
+
+    function ignoreSend(address payable to) public onlyOwner {
+        bool sent = to.send(1);
+    }
+
+    function checkSend(address payable to) public onlyOwner {
+        require(to.send(1));
+    }
+
+    function authorize() public {
+        require(tx.origin == owner);
+        total = 1;
+    }
+
+    function rejectContracts() public view {
+        require(tx.origin == msg.sender);
+    }
+
+    function execute(address target, bytes memory payload) public {
+        (bool ok, ) = target.delegatecall(payload);
+        require(ok);
+    }
+
+    function executeOwner(address target, bytes memory payload) public onlyOwner {
+        (bool ok, ) = target.delegatecall(payload);
+        require(ok);
+    }
+
+    function kill() public {
+        selfdestruct(payable(msg.sender));
+    }
+
+    function killOwner() public onlyOwner {
+        selfdestruct(payable(owner));
+    }
+
+    function pointer() public {
+        Data storage d;
+        d.value = 1;
+    }
+
+    function initializedPointer() public {
+        Data storage d = data[0];
+        d.value = 1;
+    }
+
+    function _send(address to, uint256 amount) internal {
+        (bool ok, ) = to.call{value: amount}("");
+        require(ok);
+    }
+
+    function helperWithdraw() public {
+        _send(msg.sender, balances[msg.sender]);
+        balances[msg.sender] = 0;
+    }
+}`
+
+func newNativeSources() *solgo.Sources {
+	return solgotest.NewSourcesForTest(&solgo.SourceUnit{Name: "Vault", Path: "Vault.sol", Content: nativeContent})
+}
+
+func TestNative(t *testing.T) {
+	native := NewNative(context.TODO(), nil)
+	assert.Len(t, native.GetRegistry().GetDetectors(), 7)
+
+	report, err := native.Analyze(newNativeSources())
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	assert.True(t, report.IsSuccess())
+	assert.NotNil(t, report.ToProto())
+
+	// Names of the first elements, which are the functions or variables the issues are found in.
+	found := make(map[string][]string)
+	for _, detector := range report.GetResults().GetDetectors() {
+		require.NotEmpty(t, detector.Elements)
+		assert.NotEmpty(t, detector.ID)
+		assert.Contains(t, detector.Description, "Vault.sol#L")
+		assert.Contains(t, detector.Markdown, "](Vault.sol#L")
+		assert.Equal(t, "Vault.sol", detector.Elements[0].SourceMapping.FilenameShort)
+		assert.NotEmpty(t, detector.Elements[0].SourceMapping.Lines)
+		found[detector.Check] = append(found[detector.Check], detector.Elements[0].Name)
+	}
+
+	testCases := []struct {
+		check    string
+		impact   ImpactLevel
+		expected []string
+	}{
+		{check: "reentrancy", impact: ImpactHigh, expected: []string{"withdraw", "guardedWithdraw", "helperWithdraw"}},
+		{check: "unchecked-lowlevel", impact: ImpactMedium, expected: []string{"ignoreCall", "ignoreSend"}},
+		{check: "tx-origin", impact: ImpactMedium, expected: []string{"authorize"}},
+		{check: "controlled-delegatecall", impact: ImpactHigh, expected: []string{"execute"}},
+		{check: "suicidal", impact: ImpactHigh, expected: []string{"kill"}},
+		{check: "uninitialized-storage", impact: ImpactHigh, expected: []string{"pointer"}},
+		{check: "shadowing-state", impact: ImpactHigh, expected: []string{"total"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.check, func(t *testing.T) {
+			assert.ElementsMatch(t, testCase.expected, found[testCase.check])
+			for _, detector := range report.DetectorsByCheck(testCase.check) {
+				assert.Equal(t, testCase.impact.String(), detector.Impact)
+			}
+		})
+	}
+
+	reentrancy := report.DetectorsByCheck("reentrancy")
+	require.Len(t, reentrancy, 3)
+	require.Len(t, reentrancy[0].Elements, 3)
+	assert.Equal(t, "withdraw(uint256)", reentrancy[0].Elements[0].Signature)
+	assert.Equal(t, ConfidenceMedium.String(), reentrancy[0].Confidence)
+	assert.Equal(t, "node", reentrancy[0].Elements[1].Type)
+	assert.Equal(t, `msg.sender.call{value: amount}("")`, reentrancy[0].Elements[1].Name)
+	assert.Equal(t, "balances[msg.sender] -= amount", reentrancy[0].Elements[2].Name)
+
+	// The nonReentrant modifier writes no lock, so the finding is left for manual review.
+	assert.Equal(t, "guardedWithdraw(uint256)", reentrancy[1].Elements[0].Signature)
+	assert.Equal(t, ConfidenceLow.String(), reentrancy[1].Confidence)
+
+	// External calls made within internal functions are reported at the call of the function.
+	assert.Equal(t, "helperWithdraw()", reentrancy[2].Elements[0].Signature)
+	require.Len(t, reentrancy[2].Elements, 3)
+	assert.Equal(t, "_send(msg.sender, balances[msg.sender])", reentrancy[2].Elements[1].Name)
+	assert.Equal(t, "balances[msg.sender] = 0", reentrancy[2].Elements[2].Name)
+
+	shadowing := report.DetectorsByCheck("shadowing-state")
+	require.Len(t, shadowing, 1)
+	assert.Equal(t, "Vault", shadowing[0].Elements[0].TypeSpecificFields.Parent.Name)
+	assert.Equal(t, "Base", shadowing[0].Elements[1].TypeSpecificFields.Parent.Name)
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := NewRegistry(&SuicidalStub{}, &TxOriginDetector{})
+	require.NoError(t, err)
+	assert.Len(t, registry.GetDetectors(), 2)
+	assert.Equal(t, "suicidal", registry.GetDetectors()[0].Check())
+
+	assert.ErrorIs(t, registry.Register(&TxOriginDetector{}), ErrDetectorAlreadyRegistered)
+	assert.ErrorIs(t, registry.Register(nil), ErrDetectorNotSet)
+
+	registry.Unregister("suicidal")
+	assert.Nil(t, registry.GetDetector("suicidal"))
+	assert.NotNil(t, registry.GetDetector("tx-origin"))
+
+	// Only the registered detectors are run.
+	report, err := NewNative(context.TODO(), registry).Analyze(newNativeSources())
+	require.NoError(t, err)
+	require.Len(t, report.GetResults().GetDetectors(), 1)
+	assert.Equal(t, "tx-origin", report.GetResults().GetDetectors()[0].Check)
+
+	_, err = NewNative(context.TODO(), registry).Analyze(nil)
+	assert.ErrorIs(t, err, ErrSourcesNotSet)
+}
+
+// SuicidalStub is a detector reporting nothing, registered under the check of a built-in detector.
+type SuicidalStub struct{}
+
+func (s *SuicidalStub) Check() string                        { return "suicidal" }
+func (s *SuicidalStub) Impact() ImpactLevel                  { return ImpactInfo }
+func (s *SuicidalStub) Confidence() ConfidenceLevel          { return ConfidenceLow }
+func (s *SuicidalStub) Detect(analysis *Analysis) []Detector { return nil }
+
+func TestAuditorNativeFallback(t *testing.T) {
+	config, err := NewDefaultConfig(os.TempDir())
+	require.NoError(t, err)
+
+	auditor, err := NewAuditor(context.TODO(), nil, config, newNativeSources())
+	require.NoError(t, err)
+	if auditor.GetSlither().IsInstalled() {
+		t.Skip("slither is installed, native fallback is not used")
+	}
+
+	// Native detectors are only run in place of Slither once the fallback is enabled.
+	assert.False(t, auditor.IsReady())
+
+	config.SetNativeFallback(true)
+	assert.True(t, auditor.IsReady())
+	report, err := auditor.Analyze()
+	require.NoError(t, err)
+	assert.Equal(t, EngineNative, report.GetEngine())
+	assert.True(t, report.HasIssues())
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NativeDetector is a vulnerability detector running natively on the IR, control flow graphs and
+// data-flow analyses of the sources, without any external tooling such as Slither.
+type NativeDetector interface {
+	// Check returns the unique name of the check, such as reentrancy.
+	Check() string
+
+	// Impact returns the severity of the issues the detector reports.
+	Impact() ImpactLevel
+
+	// Confidence returns how certain the detector is that the issues it reports are real.
+	Confidence() ConfidenceLevel
+
+	// Detect returns the issues detected within the analyzed sources.
+	Detect(analysis *Analysis) []Detector
+}
+
+// Registry holds the native detectors run by the native auditor, keyed by their check.
+type Registry struct {
+	detectors map[string]NativeDetector
+}
+
+// NewRegistry creates and returns a new registry holding the given detectors.
+func NewRegistry(detectors ...NativeDetector) (*Registry, error) {
+	toReturn := &Registry{
+		detectors: make(map[string]NativeDetector),
+	}
+
+	for _, detector := range detectors {
+		if err := toReturn.Register(detector); err != nil {
+			return nil, err
+		}
+	}
+
+	return toReturn, nil
+}
+
+// NewDefaultRegistry creates and returns a new registry holding all of the built-in detectors.
+func NewDefaultRegistry() *Registry {
+	toReturn, _ := NewRegistry(
+		&ReentrancyDetector{},
+		&UncheckedCallDetector{},
+		&TxOriginDetector{},
+		&ArbitraryDelegatecallDetector{},
+		&UnprotectedSelfdestructDetector{},
+		&UninitializedStorageDetector{},
+		&ShadowedStateDetector{},
+	)
+	return toReturn
+}
+
+// Register adds the detector to the registry. It returns an error if a detector with the same check
+// is already registered.
+func (r *Registry) Register(detector NativeDetector) error {
+	if detector == nil {
+		return ErrDetectorNotSet
+	}
+
+	if _, ok := r.detectors[detector.Check()]; ok {
+		return fmt.Errorf("%w: %s", ErrDetectorAlreadyRegistered, detector.Check())
+	}
+
+	r.detectors[detector.Check()] = detector
+	return nil
+}
+
+// Unregister removes the detector with the given check from the registry.
+func (r *Registry) Unregister(check string) {
+	delete(r.detectors, check)
+}
+
+// GetDetector returns the detector with the given check, or nil if there is no such detector.
+func (r *Registry) GetDetector(check string) NativeDetector {
+	return r.detectors[check]
+}
+
+// GetDetectors returns all of the registered detectors, ordered by their check.
+func (r *Registry) GetDetectors() []NativeDetector {
+	toReturn := make([]NativeDetector, 0, len(r.detectors))
+	for _, detector := range r.detectors {
+		toReturn = append(toReturn, detector)
+	}
+
+	sort.Slice(toReturn, func(i, j int) bool {
+		return toReturn[i].Check() < toReturn[j].Check()
+	})
+
+	return toReturn
+}
@@ -18,6 +18,21 @@ const (
 	ImpactInfo   ImpactLevel = "Informational" // Represents informational findings.
 )
 
+// ConfidenceLevel represents how certain a detector is that a detected issue is real.
+type ConfidenceLevel string
+
+// String returns the string representation of the ConfidenceLevel.
+func (c ConfidenceLevel) String() string {
+	return string(c)
+}
+
+// Predefined confidence levels of detected issues.
+const (
+	ConfidenceHigh   ConfidenceLevel = "High"   // Represents issues that are almost certainly real.
+	ConfidenceMedium ConfidenceLevel = "Medium" // Represents issues that are likely real.
+	ConfidenceLow    ConfidenceLevel = "Low"    // Represents issues that need manual review.
+)
+
 // NewResponse parses the provided JSON data (typically from Slither) and returns
 // a structured Response object. If the data is not valid JSON or does not match
 // the expected structure, an error is returned.
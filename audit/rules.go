@@ -0,0 +1,512 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleTarget describes the declarations a rule is matched against.
+type RuleTarget string
+
+// String returns the string representation of the RuleTarget.
+func (t RuleTarget) String() string {
+	return string(t)
+}
+
+// Predefined targets of rules.
+const (
+	RuleTargetContract RuleTarget = "contract" // Contracts, interfaces and libraries.
+	RuleTargetFunction RuleTarget = "function" // Functions declared by contracts.
+	RuleTargetVariable RuleTarget = "variable" // State variables declared by contracts.
+)
+
+// RuleSet is the document rules are loaded from, such as:
+//
+//	rules:
+//	  - check: unprotected-transfer
+//	    description: transfers ether without the onlyOwner modifier
+//	    impact: High
+//	    confidence: Medium
+//	    target: function
+//	    match:
+//	      visibility: [external, public]
+//	      writes_state: true
+//	      calls: ^transfer$
+//	      not:
+//	        modifier: ^onlyOwner$
+type RuleSet struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Rule is a user-defined check, declaring the pattern of the declarations it reports. Rules are
+// native detectors, so they are registered and run alongside the built-in ones.
+type Rule struct {
+	Name        string     `yaml:"check"`       // Unique name of the check.
+	Description string     `yaml:"description"` // Description of the issue, reported after the matched declaration.
+	ImpactLevel string     `yaml:"impact"`      // High, Medium, Low or Informational.
+	Certainty   string     `yaml:"confidence"`  // High, Medium or Low.
+	Target      RuleTarget `yaml:"target"`
+	Match       *Condition `yaml:"match"`
+
+	impact     ImpactLevel
+	confidence ConfidenceLevel
+}
+
+// Condition is the pattern a declaration has to match. All of the set fields have to match, where
+// names are regular expressions and lists match any of their values. Patterns are unanchored, so
+// transfer matches _transfer and transferFrom as well; ^transfer$ matches transfer only. Conditions
+// are combined with All, Any and Not.
+type Condition struct {
+	All []*Condition `yaml:"all,omitempty"` // Every condition has to match.
+	Any []*Condition `yaml:"any,omitempty"` // At least one condition has to match.
+	Not *Condition   `yaml:"not,omitempty"` // Condition must not match.
+
+	Name     string `yaml:"name,omitempty"`     // Name of the declaration.
+	Contract string `yaml:"contract,omitempty"` // Name of the contract declaring the function or variable.
+
+	// Contracts only.
+	Kind     []string `yaml:"kind,omitempty"`     // contract, interface or library.
+	Inherits string   `yaml:"inherits,omitempty"` // Name of any of the base contracts.
+
+	// Functions and variables.
+	Visibility []string `yaml:"visibility,omitempty"` // public, external, internal or private.
+	Mutability []string `yaml:"mutability,omitempty"` // pure, view, nonpayable or payable for functions, mutable, immutable or constant for variables.
+
+	// Functions only, where calls and state accesses include the internal functions called.
+	Modifier    string `yaml:"modifier,omitempty"`     // Name of any of the modifiers invoked.
+	Calls       string `yaml:"calls,omitempty"`        // Name of any of the functions or members called, such as transfer, matched unanchored.
+	WritesState *bool  `yaml:"writes_state,omitempty"` // Writes any state variable.
+	ReadsState  *bool  `yaml:"reads_state,omitempty"`  // Reads any state variable.
+	Protected   *bool  `yaml:"protected,omitempty"`    // Checks the caller, as reported by Analysis.IsProtected.
+
+	// Variables only.
+	Type string `yaml:"type,omitempty"` // Type of the variable, such as mapping(address => bool).
+
+	patterns map[string]*regexp.Regexp
+}
+
+// ParseRules parses the rules from the YAML document and validates them.
+func ParseRules(data []byte) ([]*Rule, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	set := &RuleSet{}
+	if err := decoder.Decode(set); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRule, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range set.Rules {
+		if err := rule.compile(); err != nil {
+			return nil, err
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("%w: %s", ErrDetectorAlreadyRegistered, rule.Name)
+		}
+		seen[rule.Name] = true
+	}
+
+	return set.Rules, nil
+}
+
+// LoadRules reads and parses the rules from the YAML file at the given path.
+func LoadRules(path string) ([]*Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(data)
+}
+
+// RegisterRules adds the rules to the registry. It returns an error if any of the rules has the
+// same check as an already registered detector or as another one of the rules, in which case none
+// of the rules are registered.
+func (r *Registry) RegisterRules(rules ...*Rule) error {
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule == nil {
+			return ErrDetectorNotSet
+		}
+		if seen[rule.Check()] || r.GetDetector(rule.Check()) != nil {
+			return fmt.Errorf("%w: %s", ErrDetectorAlreadyRegistered, rule.Check())
+		}
+		seen[rule.Check()] = true
+	}
+
+	for _, rule := range rules {
+		if err := r.Register(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check returns the unique name of the check.
+func (r *Rule) Check() string {
+	return r.Name
+}
+
+// Impact returns the severity of the issues the rule reports.
+func (r *Rule) Impact() ImpactLevel {
+	return r.impact
+}
+
+// Confidence returns how certain the rule is that the issues it reports are real.
+func (r *Rule) Confidence() ConfidenceLevel {
+	return r.confidence
+}
+
+// Detect returns the declarations matching the rule.
+func (r *Rule) Detect(analysis *Analysis) []Detector {
+	message := "%s " + strings.ReplaceAll(r.Description, "%", "%%") + "\n"
+	root := analysis.GetRoot()
+
+	toReturn := make([]Detector, 0)
+	switch r.Target {
+	case RuleTargetContract:
+		for _, contract := range root.GetContracts() {
+			if r.Match.matchContract(root, contract) {
+				toReturn = append(toReturn, analysis.NewFinding(r, message, analysis.ContractElement(contract)))
+			}
+		}
+	case RuleTargetFunction:
+		for _, function := range analysis.GetFunctions() {
+			if r.Match.matchFunction(analysis, function) {
+				toReturn = append(toReturn, analysis.NewFinding(r, message,
+					analysis.FunctionElement(function.Contract, function.Function),
+				))
+			}
+		}
+	case RuleTargetVariable:
+		for _, contract := range root.GetContracts() {
+			for _, variable := range contract.GetStateVariables() {
+				if r.Match.matchVariable(contract, variable) {
+					toReturn = append(toReturn, analysis.NewFinding(r, message, analysis.VariableElement(contract, variable)))
+				}
+			}
+		}
+	}
+
+	return toReturn
+}
+
+// compile validates the rule and compiles the patterns of its conditions.
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: check is not set", ErrInvalidRule)
+	}
+
+	var ok bool
+	if r.impact, ok = parseImpact(r.ImpactLevel); !ok {
+		return fmt.Errorf("%w: %s: unknown impact %q", ErrInvalidRule, r.Name, r.ImpactLevel)
+	}
+	if r.confidence, ok = parseConfidence(r.Certainty); !ok {
+		return fmt.Errorf("%w: %s: unknown confidence %q", ErrInvalidRule, r.Name, r.Certainty)
+	}
+
+	switch r.Target {
+	case RuleTargetContract, RuleTargetFunction, RuleTargetVariable:
+	default:
+		return fmt.Errorf("%w: %s: unknown target %q", ErrInvalidRule, r.Name, r.Target)
+	}
+
+	if r.Match == nil {
+		return fmt.Errorf("%w: %s: match is not set", ErrInvalidRule, r.Name)
+	}
+	if err := r.Match.compile(r.Target); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidRule, r.Name, err)
+	}
+	return nil
+}
+
+// compile validates the condition against the target and compiles its patterns.
+func (c *Condition) compile(target RuleTarget) error {
+	unsupported := map[RuleTarget][]bool{
+		RuleTargetContract: {c.Contract != "", len(c.Visibility) > 0, len(c.Mutability) > 0, c.Modifier != "", c.Calls != "",
+			c.WritesState != nil, c.ReadsState != nil, c.Protected != nil, c.Type != ""},
+		RuleTargetFunction: {len(c.Kind) > 0, c.Inherits != "", c.Type != ""},
+		RuleTargetVariable: {len(c.Kind) > 0, c.Inherits != "", c.Modifier != "", c.Calls != "",
+			c.WritesState != nil, c.ReadsState != nil, c.Protected != nil},
+	}
+	for _, set := range unsupported[target] {
+		if set {
+			return fmt.Errorf("condition is not supported by %s targets", target)
+		}
+	}
+
+	c.patterns = make(map[string]*regexp.Regexp)
+	for key, pattern := range map[string]string{
+		"name": c.Name, "contract": c.Contract, "inherits": c.Inherits,
+		"modifier": c.Modifier, "calls": c.Calls, "type": c.Type,
+	} {
+		if pattern == "" {
+			continue
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid %s pattern: %s", key, err)
+		}
+		c.patterns[key] = compiled
+	}
+
+	children := append(append([]*Condition{}, c.All...), c.Any...)
+	if c.Not != nil {
+		children = append(children, c.Not)
+	}
+	for _, child := range children {
+		if child == nil {
+			return errors.New("condition is empty")
+		}
+		if err := child.compile(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// combine returns whether the nested conditions match, given the match function of a condition.
+func (c *Condition) combine(match func(condition *Condition) bool) bool {
+	for _, child := range c.All {
+		if !match(child) {
+			return false
+		}
+	}
+
+	if len(c.Any) > 0 {
+		matched := false
+		for _, child := range c.Any {
+			if match(child) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return c.Not == nil || !match(c.Not)
+}
+
+// matches returns true if the pattern with the given key is not set or matches any of the values.
+func (c *Condition) matches(key string, values ...string) bool {
+	pattern, ok := c.patterns[key]
+	if !ok {
+		return true
+	}
+	for _, value := range values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchContract returns true if the contract matches the condition.
+func (c *Condition) matchContract(root *ir.RootSourceUnit, contract *ir.Contract) bool {
+	if !c.matches("name", contract.GetName()) || !contains(c.Kind, kindName(contract.GetKind())) {
+		return false
+	}
+
+	if _, ok := c.patterns["inherits"]; ok {
+		bases := make([]string, 0)
+		for _, id := range contract.GetLinearizedBaseContracts() {
+			if base := root.GetContractById(id); base != nil && base != contract {
+				bases = append(bases, base.GetName())
+			}
+		}
+		if !c.matches("inherits", bases...) {
+			return false
+		}
+	}
+
+	return c.combine(func(condition *Condition) bool {
+		return condition.matchContract(root, contract)
+	})
+}
+
+// matchFunction returns true if the function matches the condition.
+func (c *Condition) matchFunction(analysis *Analysis, function *AnalyzedFunction) bool {
+	declaration := function.Function.GetAST()
+	if !c.matches("name", function.Function.GetName()) || !c.matches("contract", function.Contract.GetName()) {
+		return false
+	}
+	if !contains(c.Visibility, enumName(declaration.GetVisibility().String())) ||
+		!contains(c.Mutability, enumName(declaration.GetStateMutability().String())) {
+		return false
+	}
+
+	if _, ok := c.patterns["modifier"]; ok {
+		modifiers := make([]string, 0)
+		for _, modifier := range function.Function.GetModifiers() {
+			modifiers = append(modifiers, modifier.GetName())
+		}
+		if !c.matches("modifier", modifiers...) {
+			return false
+		}
+	}
+
+	if c.Protected != nil && analysis.IsProtected(function) != *c.Protected {
+		return false
+	}
+
+	if _, ok := c.patterns["calls"]; ok || c.WritesState != nil || c.ReadsState != nil {
+		calls, writes, reads := c.accesses(analysis, function)
+		if ok && !c.matches("calls", calls...) {
+			return false
+		}
+		if (c.WritesState != nil && writes != *c.WritesState) || (c.ReadsState != nil && reads != *c.ReadsState) {
+			return false
+		}
+	}
+
+	return c.combine(func(condition *Condition) bool {
+		return condition.matchFunction(analysis, function)
+	})
+}
+
+// accesses returns the names of the functions and members called by the function, and whether it
+// writes or reads any state variable, following the internal functions it calls.
+func (c *Condition) accesses(analysis *Analysis, function *AnalyzedFunction) ([]string, bool, bool) {
+	calls := make([]string, 0)
+	writes, reads := false, false
+
+	functions := []*AnalyzedFunction{function}
+	visited := map[int64]bool{function.Function.GetId(): true}
+	for i := 0; i < len(functions); i++ {
+		current := functions[i]
+
+		for _, definition := range current.Analysis.GetDefinitions() {
+			writes = writes || (definition.GetVariable().IsStateVariable() && !definition.IsParameter())
+		}
+		for _, use := range current.Analysis.GetUses() {
+			reads = reads || use.GetVariable().IsStateVariable()
+		}
+
+		for _, block := range current.GetGraph().GetBlocks() {
+			for _, statement := range block.GetStatements() {
+				ContainsNode(statement.GetNode(), func(node ast.Node[ast.NodeType]) bool {
+					if name := calleeName(node); name != "" {
+						calls = append(calls, name)
+					}
+					if callee := analysis.internalCallee(node); callee != nil && !visited[callee.Function.GetId()] {
+						visited[callee.Function.GetId()] = true
+						functions = append(functions, callee)
+					}
+					return false
+				})
+			}
+		}
+	}
+
+	return calls, writes, reads
+}
+
+// matchVariable returns true if the state variable declared by the contract matches the condition.
+func (c *Condition) matchVariable(contract *ir.Contract, variable *ir.StateVariable) bool {
+	mutability := enumName(variable.GetStateMutability().String())
+	if variable.IsConstant() {
+		mutability = "constant"
+	}
+
+	if !c.matches("name", variable.GetName()) || !c.matches("contract", contract.GetName()) {
+		return false
+	}
+	if !contains(c.Visibility, enumName(variable.GetVisibility().String())) || !contains(c.Mutability, mutability) {
+		return false
+	}
+
+	if _, ok := c.patterns["type"]; ok {
+		types := []string{variable.GetType()}
+		if description := variable.GetTypeDescription(); description != nil {
+			types = append(types, description.GetString())
+		}
+		if !c.matches("type", types...) {
+			return false
+		}
+	}
+
+	return c.combine(func(condition *Condition) bool {
+		return condition.matchVariable(contract, variable)
+	})
+}
+
+// calleeName returns the name of the function or member the node calls, such as _transfer for
+// _transfer(to, amount) and transfer for to.transfer(amount), or an empty string if the node is not
+// a call.
+func calleeName(node ast.Node[ast.NodeType]) string {
+	call, ok := node.(*ast.FunctionCall)
+	if !ok || call == nil {
+		return ""
+	}
+
+	expression := call.GetExpression()
+	if option, ok := expression.(*ast.FunctionCallOption); ok && option != nil {
+		expression = option.GetExpression()
+	}
+
+	switch callee := expression.(type) {
+	case *ast.PrimaryExpression:
+		if callee != nil {
+			return callee.GetName()
+		}
+	case *ast.MemberAccessExpression:
+		if callee != nil {
+			return callee.GetMemberName()
+		}
+	}
+	return ""
+}
+
+// parseImpact returns the impact level with the given name, regardless of its case.
+func parseImpact(name string) (ImpactLevel, bool) {
+	for _, impact := range []ImpactLevel{ImpactHigh, ImpactMedium, ImpactLow, ImpactInfo} {
+		if strings.EqualFold(impact.String(), name) {
+			return impact, true
+		}
+	}
+	return "", false
+}
+
+// parseConfidence returns the confidence level with the given name, regardless of its case.
+func parseConfidence(name string) (ConfidenceLevel, bool) {
+	for _, confidence := range []ConfidenceLevel{ConfidenceHigh, ConfidenceMedium, ConfidenceLow} {
+		if strings.EqualFold(confidence.String(), name) {
+			return confidence, true
+		}
+	}
+	return "", false
+}
+
+// kindName returns the name of the kind of contract, such as contract, interface or library.
+func kindName(kind ast_pb.NodeType) string {
+	return strings.TrimPrefix(enumName(kind.String()), "kind_")
+}
+
+// enumName returns the lower cased name of the protobuf enumeration value.
+func enumName(name string) string {
+	return strings.ToLower(name)
+}
+
+// contains returns true if the values are empty or hold the value, regardless of its case.
+func contains(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rulesContent = `rules:
+  - check: unguarded-delegatecall
+    description: delegates calls without the onlyOwner modifier
+    impact: high
+    confidence: medium
+    target: function
+    match:
+      visibility: [public, external]
+      calls: ^delegatecall$
+      not:
+        modifier: ^onlyOwner$
+  - check: kill-switch
+    description: destroys the contract
+    impact: Medium
+    confidence: High
+    target: function
+    match:
+      any:
+        - name: ^kill
+        - calls: ^selfdestruct$
+      protected: false
+  - check: stateless-view
+    description: is a view function reading no state
+    impact: Informational
+    confidence: Low
+    target: function
+    match:
+      mutability: [view]
+      reads_state: false
+  - check: balance-mapping
+    description: holds balances of 100%
+    impact: Low
+    confidence: High
+    target: variable
+    match:
+      type: ^mapping\(address
+  - check: derived-vault
+    description: inherits from Base
+    impact: Informational
+    confidence: High
+    target: contract
+    match:
+      kind: [contract]
+      inherits: ^Base$
+`
+
+func TestRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(rulesContent), 0600))
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 5)
+	assert.Equal(t, ImpactHigh, rules[0].Impact())
+	assert.Equal(t, ConfidenceMedium, rules[0].Confidence())
+
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+	detectors := len(registry.GetDetectors())
+
+	// Rules conflicting with each other or with registered detectors leave the registry unchanged.
+	assert.ErrorIs(t, registry.RegisterRules(rules[0], rules[1], rules[0]), ErrDetectorAlreadyRegistered)
+	assert.Len(t, registry.GetDetectors(), detectors)
+	require.NoError(t, registry.RegisterRules(rules...))
+	assert.ErrorIs(t, registry.RegisterRules(rules[0]), ErrDetectorAlreadyRegistered)
+	assert.ErrorIs(t, registry.RegisterRules(&Rule{Name: "unregistered"}, rules[1]), ErrDetectorAlreadyRegistered)
+	assert.Nil(t, registry.GetDetector("unregistered"))
+	assert.Len(t, registry.GetDetectors(), detectors+len(rules))
+
+	report, err := NewNative(context.TODO(), registry).Analyze(newNativeSources())
+	require.NoError(t, err)
+
+	found := make(map[string][]string)
+	for _, detector := range report.GetResults().GetDetectors() {
+		require.Len(t, detector.Elements, 1)
+		found[detector.Check] = append(found[detector.Check], detector.Elements[0].Name)
+	}
+
+	assert.ElementsMatch(t, []string{"execute"}, found["unguarded-delegatecall"])
+	assert.ElementsMatch(t, []string{"kill"}, found["kill-switch"])
+	assert.ElementsMatch(t, []string{"rejectContracts"}, found["stateless-view"])
+	assert.ElementsMatch(t, []string{"balances"}, found["balance-mapping"])
+	assert.ElementsMatch(t, []string{"Vault"}, found["derived-vault"])
+
+	balances := report.DetectorsByCheck("balance-mapping")
+	require.Len(t, balances, 1)
+	assert.Equal(t, ImpactLow.String(), balances[0].Impact)
+	assert.Contains(t, balances[0].Description, "balances (Vault.sol#L")
+	assert.Contains(t, balances[0].Description, "holds balances of 100%\n")
+}
+
+func TestRulesInvalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "Unknown Field", content: "rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: function\n    match:\n      calling: x\n"},
+		{name: "Missing Check", content: "rules:\n  - impact: High\n    confidence: High\n    target: function\n    match:\n      name: x\n"},
+		{name: "Unknown Impact", content: "rules:\n  - check: a\n    impact: Critical\n    confidence: High\n    target: function\n    match:\n      name: x\n"},
+		{name: "Unknown Target", content: "rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: event\n    match:\n      name: x\n"},
+		{name: "Missing Match", content: "rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: function\n"},
+		{name: "Invalid Pattern", content: "rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: function\n    match:\n      name: '('\n"},
+		{name: "Unsupported Condition", content: "rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: variable\n    match:\n      not:\n        calls: x\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseRules([]byte(testCase.content))
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+
+	_, err := ParseRules([]byte("rules:\n  - check: a\n    impact: High\n    confidence: High\n    target: function\n    match:\n      name: x\n  - check: a\n    impact: High\n    confidence: High\n    target: function\n    match:\n      name: y\n"))
+	assert.ErrorIs(t, err, ErrDetectorAlreadyRegistered)
+}
@@ -126,6 +126,9 @@ func (s *Slither) Analyze(sources *solgo.Sources) (*Report, []byte, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if response != nil {
+		response.Engine = EngineSlither
+	}
 
 	// Clean up the temporary directory.
 	if err := sources.TruncateDir(dir); err != nil {
@@ -4,11 +4,20 @@ import (
 	audit_pb "github.com/unpackdev/protos/dist/go/audit"
 )
 
+// Engine identifies the analysis engine producing a report.
+type Engine string
+
+const (
+	EngineSlither Engine = "slither" // Report produced by the Slither tool.
+	EngineNative  Engine = "native"  // Report produced by the native detectors.
+)
+
 // Report represents the top-level structure of the Slither JSON output.
 type Report struct {
-	Success bool     `json:"success"` // Indicates the success status of the audit.
-	Error   string   `json:"error"`   // Contains any error messages, if present.
-	Results *Results `json:"results"` // Contains the results of the audit.
+	Success bool     `json:"success"`          // Indicates the success status of the audit.
+	Error   string   `json:"error"`            // Contains any error messages, if present.
+	Engine  Engine   `json:"engine,omitempty"` // Engine producing the report.
+	Results *Results `json:"results"`          // Contains the results of the audit.
 }
 
 // IsSuccess returns true if the vulnerability report was generated successfully.
@@ -21,6 +30,11 @@ func (r *Report) GetError() string {
 	return r.Error
 }
 
+// GetEngine returns the engine producing the vulnerability report.
+func (r *Report) GetEngine() Engine {
+	return r.Engine
+}
+
 // GetResults returns the Results struct associated with the vulnerability report.
 func (r *Report) GetResults() *Results {
 	return r.Results
@@ -1,5 +1,6 @@
 /*
 Package bytecode provides tools for decoding and analyzing Ethereum contract, transaction, events, and log bytecode.
 The package is designed to extract and interpret metadata from Ethereum contract creation bytecode. It provides a Metadata struct that represents the metadata contained in the bytecode, as defined by the Solidity compiler. This includes information such as the IPFS hash of the metadata, the Swarm hash of the metadata, experimental metadata, and the version of the Solidity compiler used.
+Transactions and logs are decoded with the ABI of the contract, or without it, with the candidate signatures of their selector or topic held by the signatures package.
 */
 package bytecode
@@ -36,11 +36,19 @@ type Log struct {
 // DecodeLogFromAbi decodes an Ethereum event log using the provided ABI data. It returns a Log
 // instance containing the decoded event name, data, and topics. The function requires the event log
 // and its ABI as inputs. It handles errors such as missing topics or failure to parse the ABI.
+//
+// When no ABI is provided, the event is resolved from the candidate signatures of its first topic
+// in the default signature database. Signatures do not tell which parameters are indexed, so they
+// are chosen to match the topics and the data of the log.
 func DecodeLogFromAbi(log *types.Log, abiData []byte) (*Log, error) {
 	if log == nil || len(log.Topics) < 1 {
 		return nil, fmt.Errorf("log is nil or has no topics")
 	}
 
+	if len(bytes.TrimSpace(abiData)) == 0 {
+		return decodeLogFromSignatures(log)
+	}
+
 	logABI, err := abi.JSON(bytes.NewReader(abiData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse abi: %s", err)
@@ -51,24 +59,46 @@ func DecodeLogFromAbi(log *types.Log, abiData []byte) (*Log, error) {
 		return nil, fmt.Errorf("failed to get event by id %s: %s", log.Topics[0].Hex(), err)
 	}
 
+	return DecodeLogFromEvent(log, event)
+}
+
+// DecodeLogFromEvent decodes the data and the topics of the given log with the given event. The
+// first topic of the log is not checked against the event, which allows decoding with events whose
+// name is not known.
+func DecodeLogFromEvent(log *types.Log, event *abi.Event) (*Log, error) {
+	if log == nil || len(log.Topics) < 1 {
+		return nil, fmt.Errorf("log is nil or has no topics")
+	}
+
 	data := make(map[string]any)
 	if err := event.Inputs.UnpackIntoMap(data, log.Data); err != nil {
 		return nil, fmt.Errorf("failed to unpack inputs into map: %s", err)
 	}
 
+	// Topics following the event signature hold the indexed inputs, in their order.
+	indexed := make(abi.Arguments, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(log.Topics)-1 {
+		return nil, fmt.Errorf("event %s has %d indexed inputs, log has %d topics", event.Name, len(indexed), len(log.Topics)-1)
+	}
+
 	decodedTopics := make([]Topic, len(log.Topics))
 	for i, topic := range log.Topics {
 		if i == 0 {
 			continue
 		}
 
-		decodedTopic, err := decodeTopic(topic, event.Inputs[i-1])
+		decodedTopic, err := decodeTopic(topic, indexed[i-1])
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode topic: %s", err)
 		}
 
 		decodedTopics[i] = Topic{
-			Name:  event.Inputs[i-1].Name,
+			Name:  indexed[i-1].Name,
 			Value: decodedTopic,
 		}
 	}
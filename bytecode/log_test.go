@@ -0,0 +1,38 @@
+package bytecode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// depositAbi declares an event whose non-indexed parameter precedes the indexed one.
+const depositAbi = `[{"type":"event","name":"Deposit","anonymous":false,"inputs":[{"name":"amount","type":"uint256","indexed":false},{"name":"owner","type":"address","indexed":true}]}]`
+
+func TestDecodeLogFromAbi(t *testing.T) {
+	topic := crypto.Keccak256Hash([]byte("Deposit(uint256,address)"))
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	decoded, err := DecodeLogFromAbi(&types.Log{
+		Topics: []common.Hash{topic, common.BytesToHash(owner.Bytes())},
+		Data:   common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}, []byte(depositAbi))
+	require.NoError(t, err)
+	assert.Equal(t, "Deposit", decoded.Name)
+	assert.Equal(t, map[string]any{"amount": big.NewInt(1000)}, decoded.Data)
+	require.Len(t, decoded.Topics, 1)
+	assert.Equal(t, "owner", decoded.Topics[0].Name)
+	assert.Equal(t, owner, decoded.Topics[0].Value)
+
+	// Topics not matching the indexed inputs of the event.
+	_, err = DecodeLogFromAbi(&types.Log{
+		Topics: []common.Hash{topic, common.BytesToHash(owner.Bytes()), {}},
+		Data:   common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+	}, []byte(depositAbi))
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package bytecode
+
+import (
+	"bytes"
+	"fmt"
+
+	abi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/unpackdev/solgo/signatures"
+)
+
+// maxIndexedCombinations limits the number of ways of indexing the parameters of a candidate event
+// signature tried when decoding a log without its ABI.
+const maxIndexedCombinations = 64
+
+// decodeTransactionFromSignatures decodes the raw transaction data with the candidate signatures of
+// its selector from the default signature database. Candidates whose arguments are encoded exactly
+// as the data are preferred over the ones merely able to unpack it, such as with trailing bytes.
+func decodeTransactionFromSignatures(data []byte) (*Transaction, error) {
+	candidates := signatures.Default().LookupFunction(data[:4])
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to get method by id: no abi provided and no signature found for selector 0x%x", data[:4])
+	}
+
+	var fallback *Transaction
+	for _, candidate := range candidates {
+		method, err := candidate.ToMethod()
+		if err != nil {
+			continue
+		}
+
+		transaction, err := DecodeTransactionFromMethod(data, method)
+		if err != nil {
+			continue
+		}
+
+		if isCanonical(method.Inputs, data[4:]) {
+			return transaction, nil
+		} else if fallback == nil {
+			fallback = transaction
+		}
+	}
+
+	if fallback == nil {
+		return nil, fmt.Errorf("failed to unpack inputs with any of the %d signatures of selector 0x%x", len(candidates), data[:4])
+	}
+	return fallback, nil
+}
+
+// decodeLogFromSignatures decodes the log with the candidate signatures of its first topic from the
+// default signature database. As many parameters as the log has topics beyond the first one are
+// indexed, trying the leading parameters first as most events declare them.
+func decodeLogFromSignatures(log *types.Log) (*Log, error) {
+	candidates := signatures.Default().LookupEvent(log.Topics[0])
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to get event by id %s: no abi provided and no signature found", log.Topics[0].Hex())
+	}
+
+	var fallback *Log
+	for _, candidate := range candidates {
+		for _, indexed := range indexedCombinations(len(candidate.GetTypes()), len(log.Topics)-1) {
+			event, err := candidate.ToEvent(indexed)
+			if err != nil {
+				break
+			}
+
+			decoded, err := DecodeLogFromEvent(log, event)
+			if err != nil {
+				continue
+			}
+
+			if isCanonical(event.Inputs.NonIndexed(), log.Data) {
+				return decoded, nil
+			} else if fallback == nil {
+				fallback = decoded
+			}
+		}
+	}
+
+	if fallback == nil {
+		return nil, fmt.Errorf("failed to decode log with any of the %d signatures of event %s", len(candidates), log.Topics[0].Hex())
+	}
+	return fallback, nil
+}
+
+// isCanonical returns true if packing the arguments unpacked from the given data yields the very
+// same data, which tells apart the signatures actually encoding it from colliding ones.
+func isCanonical(arguments abi.Arguments, data []byte) bool {
+	values, err := arguments.UnpackValues(data)
+	if err != nil {
+		return false
+	}
+	packed, err := arguments.Pack(values...)
+	return err == nil && bytes.Equal(packed, data)
+}
+
+// indexedCombinations returns the ways of indexing k of n parameters, in lexicographic order of the
+// indexed parameters, up to maxIndexedCombinations of them.
+func indexedCombinations(n int, k int) [][]bool {
+	toReturn := make([][]bool, 0)
+	if k > n || k < 0 {
+		return toReturn
+	}
+
+	indexed := make([]bool, n)
+	var combine func(start int, remaining int)
+	combine = func(start int, remaining int) {
+		if len(toReturn) >= maxIndexedCombinations {
+			return
+		}
+		if remaining == 0 {
+			toReturn = append(toReturn, append([]bool(nil), indexed...))
+			return
+		}
+		for i := start; i <= n-remaining; i++ {
+			indexed[i] = true
+			combine(i+1, remaining-1)
+			indexed[i] = false
+		}
+	}
+	combine(0, k)
+	return toReturn
+}
@@ -0,0 +1,125 @@
+package bytecode
+
+import (
+	"math/big"
+	"testing"
+
+	abi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const erc20TransferAbi = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"}]`
+
+func TestDecodeTransactionFromSignatures(t *testing.T) {
+	recipient := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	data := append(common.FromHex("0xa9059cbb"), common.LeftPadBytes(recipient.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...)
+
+	transaction, err := DecodeTransactionFromAbi(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer", transaction.Name)
+	assert.Equal(t, common.FromHex("0xa9059cbb"), transaction.SignatureBytes)
+	assert.Equal(t, recipient, transaction.Inputs["arg0"])
+	assert.Equal(t, big.NewInt(1000), transaction.Inputs["arg1"])
+	assert.NotEmpty(t, transaction.Abi)
+
+	transaction, err = DecodeTransactionFromAbi(data, []byte(erc20TransferAbi))
+	require.NoError(t, err)
+	assert.Equal(t, recipient, transaction.Inputs["to"])
+	assert.Equal(t, big.NewInt(1000), transaction.Inputs["amount"])
+
+	_, err = DecodeTransactionFromAbi(common.FromHex("0xdeadbeef"), nil)
+	assert.Error(t, err)
+
+	_, err = DecodeTransactionFromAbi(common.FromHex("0xa9059cbb00"), nil)
+	assert.Error(t, err)
+
+	_, err = DecodeTransactionFromAbi([]byte{0xa9}, nil)
+	assert.Error(t, err)
+}
+
+func TestDecodeLogFromSignatures(t *testing.T) {
+	transferTopic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	from := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	to := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	testCases := []struct {
+		name   string
+		log    *types.Log
+		topics []any
+		data   map[string]any
+	}{
+		{
+			name: "erc20 transfer",
+			log: &types.Log{
+				Topics: []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+				Data:   common.LeftPadBytes(big.NewInt(1000).Bytes(), 32),
+			},
+			topics: []any{from, to},
+			data:   map[string]any{"arg2": big.NewInt(1000)},
+		},
+		{
+			name: "erc721 transfer",
+			log: &types.Log{
+				Topics: []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()), common.BigToHash(big.NewInt(7))},
+			},
+			topics: []any{from, to, big.NewInt(7)},
+			data:   map[string]any{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			decoded, err := DecodeLogFromAbi(testCase.log, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "Transfer", decoded.Name)
+			assert.Equal(t, transferTopic, decoded.SignatureHex)
+			assert.Equal(t, testCase.data, decoded.Data)
+			require.Len(t, decoded.Topics, len(testCase.topics))
+			for i, topic := range testCase.topics {
+				assert.Equal(t, topic, decoded.Topics[i].Value)
+			}
+		})
+	}
+
+	_, err := DecodeLogFromAbi(&types.Log{Topics: []common.Hash{common.HexToHash("0x01")}}, nil)
+	assert.Error(t, err)
+
+	// No parameters of Transfer(address,address,uint256) can be indexed in four topics.
+	_, err = DecodeLogFromAbi(&types.Log{Topics: []common.Hash{transferTopic, {}, {}, {}, {}}}, nil)
+	assert.Error(t, err)
+}
+
+func TestIndexedCombinations(t *testing.T) {
+	assert.Equal(t, [][]bool{{true, false, false}, {false, true, false}, {false, false, true}}, indexedCombinations(3, 1))
+	assert.Equal(t, [][]bool{{false, false}}, indexedCombinations(2, 0))
+	assert.Empty(t, indexedCombinations(1, 2))
+	assert.Len(t, indexedCombinations(16, 8), maxIndexedCombinations)
+}
+
+func TestDecodeFromMethodAndEvent(t *testing.T) {
+	uint256Type, err := abi.NewType("uint256", "", nil)
+	require.NoError(t, err)
+	arguments := abi.Arguments{{Name: "arg0", Type: uint256Type}}
+
+	// Names not hashing into the selector or topic, as for functions and events not resolved.
+	method := abi.NewMethod("func_12345678", "func_12345678", abi.Function, "", false, false, arguments, nil)
+	data := append(common.FromHex("0x12345678"), common.LeftPadBytes(big.NewInt(42).Bytes(), 32)...)
+	transaction, err := DecodeTransactionFromMethod(data, &method)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), transaction.Inputs["arg0"])
+
+	_, err = DecodeTransactionFromMethod([]byte{0x12}, &method)
+	assert.Error(t, err)
+
+	event := abi.NewEvent("Event_11111111", "Event_11111111", false, arguments)
+	decoded, err := DecodeLogFromEvent(&types.Log{Topics: []common.Hash{common.HexToHash("0x11")}, Data: data[4:]}, &event)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), decoded.Data["arg0"])
+
+	_, err = DecodeLogFromEvent(&types.Log{}, &event)
+	assert.Error(t, err)
+}
@@ -32,20 +32,41 @@ type Transaction struct {
 //
 // This function simplifies the process of interacting with raw Ethereum transactions, making
 // it easier to analyze and use the transaction data programmatically.
+//
+// When no ABI is provided, the method is resolved from the candidate signatures of its selector
+// in the default signature database, whose arguments are named arg0, arg1 and so on.
 func DecodeTransactionFromAbi(data []byte, abiData []byte) (*Transaction, error) {
-	// The first 4 bytes of the data represent the ID of the method in the ABI.
-	methodSigData := data[:4]
+	if len(data) < 4 {
+		return nil, fmt.Errorf("transaction data is shorter than a method selector")
+	}
+
+	if len(bytes.TrimSpace(abiData)) == 0 {
+		return decodeTransactionFromSignatures(data)
+	}
 
 	contractABI, err := abi.JSON(bytes.NewReader(abiData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse abi: %s", err)
 	}
 
-	method, err := contractABI.MethodById(methodSigData)
+	// The first 4 bytes of the data represent the ID of the method in the ABI.
+	method, err := contractABI.MethodById(data[:4])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get method by id: %s", err)
 	}
 
+	return DecodeTransactionFromMethod(data, method)
+}
+
+// DecodeTransactionFromMethod decodes the arguments of the given method from the raw transaction
+// data, and returns the transaction along with the ABI of the method. The selector of the data is
+// not checked against the method, which allows decoding with methods whose name is not known.
+func DecodeTransactionFromMethod(data []byte, method *abi.Method) (*Transaction, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("transaction data is shorter than a method selector")
+	}
+
+	methodSigData := data[:4]
 	inputsSigData := data[4:]
 	inputsMap := make(map[string]interface{})
 	if err := method.Inputs.UnpackIntoMap(inputsMap, inputsSigData); err != nil {
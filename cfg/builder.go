@@ -10,9 +10,10 @@ import (
 // Builder is responsible for constructing the control flow graph (CFG) of Solidity contracts.
 // It utilizes the Intermediate Representation (IR) provided by solgo and Graphviz for graph operations.
 type Builder struct {
-	ctx     context.Context // Context for the builder operations.
-	builder *ir.Builder     // IR builder from solgo, used for generating the IR of the contracts.
-	graph   *Graph          // Internal representation of the CFG.
+	ctx       context.Context // Context for the builder operations.
+	builder   *ir.Builder     // IR builder from solgo, used for generating the IR of the contracts.
+	graph     *Graph          // Internal representation of the CFG.
+	callGraph *CallGraph      // Calls between the functions of all of the contracts.
 }
 
 // NewBuilder initializes a new CFG builder with the given context and IR builder.
@@ -34,8 +35,14 @@ func (b *Builder) GetGraph() *Graph {
 	return b.graph
 }
 
+// GetCallGraph returns the call graph of all of the contracts, or nil if the CFG is not built yet.
+func (b *Builder) GetCallGraph() *CallGraph {
+	return b.callGraph
+}
+
 // Build processes the Solidity contracts using the IR builder to construct the CFG.
-// It identifies the entry contract and explores all dependencies and inherited contracts.
+// It identifies the entry contract and explores all dependencies and inherited contracts, building
+// the control flow graphs of the functions of each of them, along with the call graph of all of the contracts.
 // Returns an error if the root node or entry contract is not set in the IR builder.
 func (b *Builder) Build() error {
 	root := b.builder.GetRoot()
@@ -52,10 +59,19 @@ func (b *Builder) Build() error {
 		b.graph = NewGraph()
 	}
 
+	var err error
 	var dfs func(contract *ir.Contract, isEntryContract bool)
 	dfs = func(contract *ir.Contract, isEntryContract bool) {
 		if !b.graph.NodeExists(contract.GetName()) {
 			b.graph.AddNode(contract.GetName(), contract, isEntryContract)
+			for _, function := range contract.GetFunctions() {
+				functionGraph, fErr := b.BuildFunctionGraph(function)
+				if fErr != nil {
+					err = fErr
+					continue
+				}
+				b.graph.AddFunctionGraph(contract.GetName(), functionGraph)
+			}
 			allRelatedContracts := make([]*ir.Contract, 0)
 			for _, importStmt := range contract.GetImports() {
 				importedContract := root.GetContractById(importStmt.GetContractId())
@@ -79,5 +95,12 @@ func (b *Builder) Build() error {
 	}
 
 	dfs(entryContract, true)
-	return nil
+
+	callGraph, cErr := b.BuildCallGraph()
+	if cErr != nil {
+		return cErr
+	}
+	b.callGraph = callGraph
+
+	return err
 }
@@ -44,3 +44,52 @@ func (b *Builder) ToMermaid() string {
 
 	return mermaidGraph.String()
 }
+
+// ToFunctionMermaid generates the Mermaid syntax of the control flow graph of a function declared
+// within the given contract. Overloaded functions are selected by their signature.
+//
+// It returns an error if either the contract or the function is not found in the graph.
+func (b *Builder) ToFunctionMermaid(contractName string, functionName string) (string, error) {
+	node := b.GetGraph().GetNode(contractName)
+	if node == nil {
+		return "", fmt.Errorf("contract %s not found in the graph", contractName)
+	}
+
+	function := node.GetFunction(functionName)
+	if function == nil {
+		return "", fmt.Errorf("function %s not found in contract %s", functionName, contractName)
+	}
+
+	return function.ToMermaid(), nil
+}
+
+// ToMermaid generates a string representation of the control flow graph of the function in Mermaid
+// syntax. Each basic block is labeled with the line and node type of its statements, while each
+// edge that does not simply fall through is labeled with its kind.
+func (g *FunctionGraph) ToMermaid() string {
+	var mermaidGraph strings.Builder
+	mermaidGraph.WriteString("graph TD\n")
+	for _, block := range g.Blocks {
+		label := fmt.Sprintf("%d: %s", block.Id, block.Kind)
+		for _, statement := range block.Statements {
+			label += fmt.Sprintf("<br/>L%d %s", statement.Src.GetLine(), strings.ToLower(statement.NodeType.String()))
+		}
+
+		switch block.Kind {
+		case BlockEntry, BlockExit, BlockRevert, BlockYulEntry, BlockYulExit:
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d([\"%s\"])\n", block.Id, label))
+		default:
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d[\"%s\"]\n", block.Id, label))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Kind == EdgeNext {
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d --> B%d\n", edge.From, edge.To))
+		} else {
+			mermaidGraph.WriteString(fmt.Sprintf("    B%d -->|%s| B%d\n", edge.From, edge.Kind, edge.To))
+		}
+	}
+
+	return mermaidGraph.String()
+}
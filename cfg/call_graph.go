@@ -0,0 +1,313 @@
+package cfg
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+)
+
+// CallNodeKind describes the kind of declaration a node of the call graph represents.
+type CallNodeKind string
+
+const (
+	CallNodeFunction    CallNodeKind = "function"
+	CallNodeConstructor CallNodeKind = "constructor"
+	CallNodeFallback    CallNodeKind = "fallback"
+	CallNodeReceive     CallNodeKind = "receive"
+	CallNodeModifier    CallNodeKind = "modifier"
+	CallNodeBuiltin     CallNodeKind = "builtin" // Low level builtin, such as selfdestruct or delegatecall.
+)
+
+// CallKind describes how the caller enters the callee.
+type CallKind string
+
+const (
+	CallInternal     CallKind = "internal"     // Jump within the contract, dispatched virtually.
+	CallExternal     CallKind = "external"     // Message call to another contract, or to this.
+	CallDelegatecall CallKind = "delegatecall" // Delegatecall, executing foreign code within the storage of the caller.
+	CallLibrary      CallKind = "library"      // Call of a library function.
+	CallSuper        CallKind = "super"        // Call of the next implementation within the linearization.
+	CallModifier     CallKind = "modifier"     // Modifier the function is invoked with.
+)
+
+// CallNode is a function, modifier or low level builtin of the call graph.
+type CallNode struct {
+	Key             string            `json:"key"` // Unique key, such as Vault.withdraw(uint256), Vault.Base.hook() or selfdestruct.
+	Id              int64             `json:"id"`  // Id of the declaration, zero for builtins.
+	Name            string            `json:"name"`
+	Kind            CallNodeKind      `json:"kind"`
+	Contract        string            `json:"contract,omitempty"`   // Most derived contract the declaration is executed within.
+	ContractId      int64             `json:"contractId,omitempty"` // Id of the most derived contract.
+	Declarer        string            `json:"declarer,omitempty"`   // Contract declaring the function or modifier.
+	Visibility      ast_pb.Visibility `json:"visibility"`
+	StateMutability ast_pb.Mutability `json:"stateMutability"`
+	Callees         []*CallEdge       `json:"-"`
+	Callers         []*CallEdge       `json:"-"`
+}
+
+// GetKey returns the unique key of the node.
+func (n *CallNode) GetKey() string {
+	return n.Key
+}
+
+// GetId returns the id of the declaration of the node, or zero for builtins.
+func (n *CallNode) GetId() int64 {
+	return n.Id
+}
+
+// GetName returns the name of the function, modifier or builtin.
+func (n *CallNode) GetName() string {
+	return n.Name
+}
+
+// GetKind returns the kind of the node.
+func (n *CallNode) GetKind() CallNodeKind {
+	return n.Kind
+}
+
+// GetContract returns the name of the most derived contract the node is executed within.
+func (n *CallNode) GetContract() string {
+	return n.Contract
+}
+
+// GetContractId returns the id of the most derived contract the node is executed within.
+func (n *CallNode) GetContractId() int64 {
+	return n.ContractId
+}
+
+// GetDeclarer returns the name of the contract declaring the function or modifier, which differs
+// from the contract of the node for inherited ones.
+func (n *CallNode) GetDeclarer() string {
+	return n.Declarer
+}
+
+// GetVisibility returns the visibility of the function or modifier.
+func (n *CallNode) GetVisibility() ast_pb.Visibility {
+	return n.Visibility
+}
+
+// GetStateMutability returns the state mutability of the function.
+func (n *CallNode) GetStateMutability() ast_pb.Mutability {
+	return n.StateMutability
+}
+
+// GetCallees returns the edges of the calls made by the node.
+func (n *CallNode) GetCallees() []*CallEdge {
+	return n.Callees
+}
+
+// GetCallers returns the edges of the calls entering the node.
+func (n *CallNode) GetCallers() []*CallEdge {
+	return n.Callers
+}
+
+// IsEntryPoint returns true if anyone can call the node from outside, that is for public and
+// external functions along with fallback and receive functions.
+func (n *CallNode) IsEntryPoint() bool {
+	switch n.Kind {
+	case CallNodeFallback, CallNodeReceive:
+		return true
+	case CallNodeFunction:
+		return n.Visibility == ast_pb.Visibility_PUBLIC || n.Visibility == ast_pb.Visibility_EXTERNAL
+	}
+	return false
+}
+
+// CallEdge is a call from one node to another.
+type CallEdge struct {
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	Kind      CallKind    `json:"kind"`
+	Candidate bool        `json:"candidate,omitempty"` // Callee is one of the implementations of an interface the call may reach.
+	CallId    int64       `json:"callId"`              // Id of the call or modifier invocation.
+	Src       ast.SrcNode `json:"src"`
+}
+
+// GetFrom returns the key of the caller.
+func (e *CallEdge) GetFrom() string {
+	return e.From
+}
+
+// GetTo returns the key of the callee.
+func (e *CallEdge) GetTo() string {
+	return e.To
+}
+
+// GetKind returns the kind of the call.
+func (e *CallEdge) GetKind() CallKind {
+	return e.Kind
+}
+
+// IsCandidate returns true if the callee is one of the implementations the call may reach.
+func (e *CallEdge) IsCandidate() bool {
+	return e.Candidate
+}
+
+// GetCallId returns the id of the call or modifier invocation.
+func (e *CallEdge) GetCallId() int64 {
+	return e.CallId
+}
+
+// GetSrc returns the source location of the call.
+func (e *CallEdge) GetSrc() ast.SrcNode {
+	return e.Src
+}
+
+// CallGraph is the graph of calls between the functions, modifiers and low level builtins of all
+// of the contracts of the sources.
+type CallGraph struct {
+	Nodes []*CallNode `json:"nodes"`
+	Edges []*CallEdge `json:"edges"`
+	keys  map[string]*CallNode
+}
+
+// NewCallGraph creates and returns a new instance of a CallGraph.
+func NewCallGraph() *CallGraph {
+	return &CallGraph{
+		Nodes: make([]*CallNode, 0),
+		Edges: make([]*CallEdge, 0),
+		keys:  make(map[string]*CallNode),
+	}
+}
+
+// GetNodes returns all of the nodes of the graph, in the order they were added.
+func (g *CallGraph) GetNodes() []*CallNode {
+	return g.Nodes
+}
+
+// GetEdges returns all of the edges of the graph.
+func (g *CallGraph) GetEdges() []*CallEdge {
+	return g.Edges
+}
+
+// GetNode returns the node with the given key, or nil if there is no such node.
+func (g *CallGraph) GetNode(key string) *CallNode {
+	return g.keys[key]
+}
+
+// GetNodeById returns the node of the declaration with the given id, or nil if there is no such node.
+// Declarations inherited by several contracts have a node within each of them, the first one added
+// being returned.
+func (g *CallGraph) GetNodeById(id int64) *CallNode {
+	for _, node := range g.Nodes {
+		if node.Id == id && node.Kind != CallNodeBuiltin {
+			return node
+		}
+	}
+	return nil
+}
+
+// GetEntryPoints returns the nodes anyone can call from outside.
+func (g *CallGraph) GetEntryPoints() []*CallNode {
+	toReturn := make([]*CallNode, 0)
+	for _, node := range g.Nodes {
+		if node.IsEntryPoint() {
+			toReturn = append(toReturn, node)
+		}
+	}
+	return toReturn
+}
+
+// Reachable returns the nodes reachable from the node with the given key, the node itself excluded
+// unless it calls itself, in breadth first order.
+func (g *CallGraph) Reachable(key string) []*CallNode {
+	toReturn := make([]*CallNode, 0)
+	visited := make(map[string]bool)
+
+	queue := []string{key}
+	for len(queue) > 0 {
+		current := g.keys[queue[0]]
+		queue = queue[1:]
+		if current == nil {
+			continue
+		}
+
+		for _, edge := range current.Callees {
+			if visited[edge.To] {
+				continue
+			}
+			visited[edge.To] = true
+			toReturn = append(toReturn, g.keys[edge.To])
+			queue = append(queue, edge.To)
+		}
+	}
+	return toReturn
+}
+
+// PathTo returns the shortest sequence of calls from one node to another, or nil if the target is
+// not reachable.
+func (g *CallGraph) PathTo(from string, to string) []*CallEdge {
+	previous := map[string]*CallEdge{from: nil}
+
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := g.keys[queue[0]]
+		queue = queue[1:]
+		if current == nil {
+			continue
+		}
+
+		for _, edge := range current.Callees {
+			if _, visited := previous[edge.To]; visited {
+				continue
+			}
+			previous[edge.To] = edge
+
+			if edge.To == to {
+				toReturn := make([]*CallEdge, 0)
+				for step := edge; step != nil; step = previous[step.From] {
+					toReturn = append([]*CallEdge{step}, toReturn...)
+				}
+				return toReturn
+			}
+			queue = append(queue, edge.To)
+		}
+	}
+	return nil
+}
+
+// GetEntryPointsReaching returns the entry points the node with the given key is reachable from,
+// such as the public functions able to reach selfdestruct.
+func (g *CallGraph) GetEntryPointsReaching(key string) []*CallNode {
+	toReturn := make([]*CallNode, 0)
+	for _, entry := range g.GetEntryPoints() {
+		for _, node := range g.Reachable(entry.Key) {
+			if node.Key == key {
+				toReturn = append(toReturn, entry)
+				break
+			}
+		}
+	}
+	return toReturn
+}
+
+// addNode adds the node to the graph, unless a node with the same key already exists, and returns
+// the node stored in the graph.
+func (g *CallGraph) addNode(node *CallNode) *CallNode {
+	if existing, ok := g.keys[node.Key]; ok {
+		return existing
+	}
+
+	node.Callees = make([]*CallEdge, 0)
+	node.Callers = make([]*CallEdge, 0)
+	g.keys[node.Key] = node
+	g.Nodes = append(g.Nodes, node)
+	return node
+}
+
+// addEdge connects the nodes with the edge. Duplicate calls made by the same expression are skipped.
+func (g *CallGraph) addEdge(edge *CallEdge) {
+	from, to := g.keys[edge.From], g.keys[edge.To]
+	if from == nil || to == nil {
+		return
+	}
+
+	for _, existing := range from.Callees {
+		if existing.To == edge.To && existing.CallId == edge.CallId && existing.Kind == edge.Kind {
+			return
+		}
+	}
+
+	from.Callees = append(from.Callees, edge)
+	to.Callers = append(to.Callers, edge)
+	g.Edges = append(g.Edges, edge)
+}
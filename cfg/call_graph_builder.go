@@ -0,0 +1,421 @@
+package cfg
+
+import (
+	"errors"
+	"strings"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// callGraphBuilder builds the call graph of all of the contracts of the root.
+type callGraphBuilder struct {
+	graph   *CallGraph
+	root    *ir.RootSourceUnit
+	tree    *ast.Tree
+	symbols *ast.SymbolTable
+	pending []*callScope // Declarations whose calls were not added yet.
+}
+
+// callScope is a function or modifier executed within a most derived contract.
+type callScope struct {
+	node      *CallNode
+	contract  *ir.Contract // Most derived contract the declaration is executed within.
+	declarer  *ir.Contract // Contract declaring the declaration.
+	modifiers []*ast.ModifierInvocation
+	body      *ast.BodyNode
+}
+
+// BuildCallGraph builds the call graph of all of the contracts of the sources. Nodes are built for
+// each most derived contract, that is a contract no other contract inherits from, including the
+// functions and modifiers it inherits. Internal calls are resolved virtually and super calls within
+// the linearization of the most derived contract, while calls of functions of other contracts reach
+// each most derived contract inheriting them.
+func (b *Builder) BuildCallGraph() (*CallGraph, error) {
+	root := b.builder.GetRoot()
+	if root == nil {
+		return nil, errors.New("root node is not set in IR builder")
+	}
+
+	astBuilder := b.builder.GetAstBuilder()
+	if astBuilder == nil || astBuilder.GetTree() == nil {
+		return nil, errors.New("ast tree is not set in IR builder")
+	}
+
+	cb := &callGraphBuilder{
+		graph:   NewCallGraph(),
+		root:    root,
+		tree:    astBuilder.GetTree(),
+		symbols: astBuilder.GetSymbolTable(),
+		pending: make([]*callScope, 0),
+	}
+
+	// Nodes are added first, so that calls can enter functions declared later on. Implementations
+	// only reachable through super calls are added once they are called.
+	for _, contract := range root.GetContracts() {
+		if cb.isMostDerived(contract) {
+			cb.addDeclarations(contract)
+		}
+	}
+
+	for len(cb.pending) > 0 {
+		scope := cb.pending[0]
+		cb.pending = cb.pending[1:]
+		cb.addCalls(scope)
+	}
+
+	return cb.graph, nil
+}
+
+// isMostDerived returns true if none of the other contracts inherits from the contract.
+func (c *callGraphBuilder) isMostDerived(contract *ir.Contract) bool {
+	for _, candidate := range c.root.GetContracts() {
+		if candidate == contract {
+			continue
+		}
+		for _, id := range candidate.GetLinearizedBaseContracts() {
+			if id == contract.GetId() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// addDeclarations adds the functions and modifiers the most derived contract declares or inherits
+// to the graph, apart from the ones overridden within its linearization.
+func (c *callGraphBuilder) addDeclarations(contract *ir.Contract) {
+	for _, resolution := range c.root.GetMethodResolutions(contract) {
+		if resolution.Implementation == resolution.Function {
+			c.functionNode(contract, resolution.Contract, resolution.Function)
+		}
+	}
+
+	for _, base := range c.root.GetLinearization(contract) {
+		for _, definition := range modifierDefinitions(base) {
+			if _, resolved := c.resolveModifierByName(contract, definition.GetName()); resolved == definition {
+				c.modifierNode(contract, base, definition)
+			}
+		}
+	}
+
+	if constructor := contract.GetConstructor(); constructor != nil && constructor.GetAST() != nil {
+		c.addNode(&callScope{contract: contract, declarer: contract, modifiers: constructor.GetAST().GetModifiers(), body: constructor.GetAST().GetBody()},
+			constructor.GetAST().GetId(), CallNodeConstructor, "constructor", contract.GetName()+".constructor",
+			constructor.GetAST().GetVisibility(), constructor.GetAST().GetStateMutability())
+	}
+
+	// Fallback and receive functions are inherited as well, the most derived one being used.
+	for _, base := range c.root.GetLinearization(contract) {
+		if fallback := base.GetFallback(); fallback != nil && fallback.GetAST() != nil {
+			c.addNode(&callScope{contract: contract, declarer: base, modifiers: fallback.GetAST().GetModifiers(), body: fallback.GetAST().GetBody()},
+				fallback.GetAST().GetId(), CallNodeFallback, "fallback", contract.GetName()+".fallback",
+				fallback.GetAST().GetVisibility(), fallback.GetAST().GetStateMutability())
+			break
+		}
+	}
+
+	for _, base := range c.root.GetLinearization(contract) {
+		if receive := base.GetReceive(); receive != nil && receive.GetAST() != nil {
+			c.addNode(&callScope{contract: contract, declarer: base, modifiers: receive.GetAST().GetModifiers(), body: receive.GetAST().GetBody()},
+				receive.GetAST().GetId(), CallNodeReceive, "receive", contract.GetName()+".receive",
+				receive.GetAST().GetVisibility(), receive.GetAST().GetStateMutability())
+			break
+		}
+	}
+}
+
+// functionNode returns the node of the function declared by the declarer and executed within the
+// most derived contract, adding it to the graph if needed. Functions overridden within the contract
+// are keyed by their declarer as well, as they are only reachable through super calls.
+func (c *callGraphBuilder) functionNode(contract *ir.Contract, declarer *ir.Contract, function *ir.Function) *CallNode {
+	types := make([]string, 0, len(function.GetParameters()))
+	for _, parameter := range function.GetParameters() {
+		types = append(types, parameter.GetType())
+	}
+
+	key := contract.GetName() + "."
+	if declarer != contract && c.root.ResolveVirtual(contract, function) != function {
+		key += declarer.GetName() + "."
+	}
+	key += function.GetName() + "(" + strings.Join(types, ",") + ")"
+
+	if node := c.graph.GetNode(key); node != nil {
+		return node
+	}
+
+	return c.addNode(&callScope{contract: contract, declarer: declarer, modifiers: function.GetAST().GetModifiers(), body: function.GetAST().GetBody()},
+		function.GetId(), CallNodeFunction, function.GetName(), key, function.GetVisibility(), function.GetStateMutability())
+}
+
+// modifierNode returns the node of the modifier declared by the declarer and executed within the
+// most derived contract, adding it to the graph if needed.
+func (c *callGraphBuilder) modifierNode(contract *ir.Contract, declarer *ir.Contract, definition *ast.ModifierDefinition) *CallNode {
+	key := contract.GetName() + "."
+	if _, resolved := c.resolveModifierByName(contract, definition.GetName()); resolved != definition {
+		key += declarer.GetName() + "."
+	}
+	key += definition.GetName()
+
+	if node := c.graph.GetNode(key); node != nil {
+		return node
+	}
+
+	return c.addNode(&callScope{contract: contract, declarer: declarer, body: definition.GetBody()},
+		definition.GetId(), CallNodeModifier, definition.GetName(), key, definition.GetVisibility(), ast_pb.Mutability_NONPAYABLE)
+}
+
+// addNode adds the node of the declaration to the graph, and schedules the calls made within the
+// scope to be added.
+func (c *callGraphBuilder) addNode(scope *callScope, id int64, kind CallNodeKind, name string, key string, visibility ast_pb.Visibility, mutability ast_pb.Mutability) *CallNode {
+	scope.node = c.graph.addNode(&CallNode{
+		Key:             key,
+		Id:              id,
+		Name:            name,
+		Kind:            kind,
+		Contract:        scope.contract.GetName(),
+		ContractId:      scope.contract.GetId(),
+		Declarer:        scope.declarer.GetName(),
+		Visibility:      visibility,
+		StateMutability: mutability,
+	})
+	c.pending = append(c.pending, scope)
+	return scope.node
+}
+
+// addBuiltin adds the node of the low level builtin to the graph.
+func (c *callGraphBuilder) addBuiltin(name string) *CallNode {
+	return c.graph.addNode(&CallNode{Key: name, Name: name, Kind: CallNodeBuiltin})
+}
+
+// addCalls adds the edges of the modifiers the declaration is invoked with and of the calls made
+// within its body.
+func (c *callGraphBuilder) addCalls(scope *callScope) {
+	for _, invocation := range scope.modifiers {
+		if declarer, definition := c.resolveModifier(scope.contract, invocation); definition != nil {
+			c.addEdge(scope.node, c.modifierNode(scope.contract, declarer, definition), CallModifier, false, invocation)
+		}
+	}
+
+	if scope.body != nil {
+		c.walk(scope, scope.body)
+	}
+}
+
+// walk adds the edges of the calls made within the node and its children.
+func (c *callGraphBuilder) walk(scope *callScope, node ast.Node[ast.NodeType]) {
+	if ast.IsNilNode(node) {
+		return
+	}
+
+	switch nodeCtx := node.(type) {
+	case *ast.FunctionCall:
+		c.addCall(scope, nodeCtx)
+	case *ast.YulFunctionCallStatement:
+		c.addYulCall(scope.node, nodeCtx)
+	}
+
+	for _, child := range node.GetNodes() {
+		c.walk(scope, child)
+	}
+}
+
+// addCall adds the edges of the function call, classified by how the callee is entered.
+func (c *callGraphBuilder) addCall(scope *callScope, call *ast.FunctionCall) {
+	caller := scope.node
+	expression := call.GetExpression()
+	if option, ok := expression.(*ast.FunctionCallOption); ok && option != nil {
+		expression = option.GetExpression()
+	}
+
+	switch expr := expression.(type) {
+	case *ast.PrimaryExpression:
+		if expr.GetReferencedDeclaration() == 0 {
+			if expr.GetName() == "selfdestruct" || expr.GetName() == "suicide" {
+				c.addEdge(caller, c.addBuiltin("selfdestruct"), CallInternal, false, call)
+			}
+			return
+		}
+
+		declaringContract, function := c.root.GetFunctionById(expr.GetReferencedDeclaration())
+		if function == nil {
+			return
+		}
+		if declaringContract.GetKind() == ast_pb.NodeType_KIND_LIBRARY && declaringContract != scope.declarer {
+			c.addEdge(caller, c.functionNode(declaringContract, declaringContract, function), CallLibrary, false, call)
+			return
+		}
+		c.addFunctionEdge(scope, c.root.ResolveVirtual(scope.contract, function), CallInternal, call)
+
+	case *ast.MemberAccessExpression:
+		if expr.GetReferencedDeclaration() == 0 {
+			switch expr.GetMemberName() {
+			case "delegatecall", "callcode":
+				c.addEdge(caller, c.addBuiltin(expr.GetMemberName()), CallDelegatecall, false, call)
+			case "call", "staticcall", "send", "transfer":
+				c.addEdge(caller, c.addBuiltin(expr.GetMemberName()), CallExternal, false, call)
+			}
+			return
+		}
+
+		declaringContract, function := c.root.GetFunctionById(expr.GetReferencedDeclaration())
+		if function == nil {
+			return
+		}
+
+		base := ""
+		if primary, ok := expr.GetExpression().(*ast.PrimaryExpression); ok && primary != nil {
+			base = primary.GetName()
+		}
+
+		switch {
+		case declaringContract.GetKind() == ast_pb.NodeType_KIND_LIBRARY:
+			c.addEdge(caller, c.functionNode(declaringContract, declaringContract, function), CallLibrary, false, call)
+		case base == "super":
+			c.addFunctionEdge(scope, c.root.ResolveSuper(scope.contract, scope.declarer, function), CallSuper, call)
+		case base == "this":
+			c.addFunctionEdge(scope, c.root.ResolveVirtual(scope.contract, function), CallExternal, call)
+		default:
+			c.addExternalEdges(caller, declaringContract, function, call)
+		}
+	}
+}
+
+// addFunctionEdge adds the edge of the call entering the function within the most derived contract
+// of the scope.
+func (c *callGraphBuilder) addFunctionEdge(scope *callScope, function *ir.Function, kind CallKind, call ast.Node[ast.NodeType]) {
+	if callee := c.implementationNode(scope.contract, function); callee != nil {
+		c.addEdge(scope.node, callee, kind, false, call)
+	}
+}
+
+// implementationNode returns the node of the function executed within the most derived contract,
+// or nil if the function is not declared within its linearization.
+func (c *callGraphBuilder) implementationNode(contract *ir.Contract, function *ir.Function) *CallNode {
+	if function == nil {
+		return nil
+	}
+
+	for _, base := range c.root.GetLinearization(contract) {
+		for _, candidate := range base.GetFunctions() {
+			if candidate == function {
+				return c.functionNode(contract, base, function)
+			}
+		}
+	}
+	return nil
+}
+
+// addExternalEdges adds the edges of an external call of the function. The call reaches the
+// implementation of the function within each most derived contract inheriting the contract
+// declaring it, each of them being a candidate unless the declaring contract is the only one.
+func (c *callGraphBuilder) addExternalEdges(caller *CallNode, declaringContract *ir.Contract, function *ir.Function, call *ast.FunctionCall) {
+	candidate := !c.isMostDerived(declaringContract)
+
+	found := false
+	for _, contract := range c.root.GetContracts() {
+		if contract.GetKind() == ast_pb.NodeType_KIND_INTERFACE || !c.isMostDerived(contract) {
+			continue
+		}
+
+		inherits := false
+		for _, id := range contract.GetLinearizedBaseContracts() {
+			if id == declaringContract.GetId() {
+				inherits = true
+				break
+			}
+		}
+		if !inherits {
+			continue
+		}
+
+		implementation := c.root.ResolveVirtual(contract, function)
+		if implementation == nil || implementation.GetAST().GetBody() == nil {
+			continue
+		}
+		if callee := c.implementationNode(contract, implementation); callee != nil {
+			c.addEdge(caller, callee, CallExternal, candidate, call)
+			found = true
+		}
+	}
+
+	// Functions without any implementation, such as the ones of interfaces nothing implements,
+	// are entered as they are declared.
+	if !found {
+		c.addEdge(caller, c.functionNode(declaringContract, declaringContract, function), CallExternal, false, call)
+	}
+}
+
+// addYulCall adds the edge of a low level builtin called within inline assembly.
+func (c *callGraphBuilder) addYulCall(caller *CallNode, call *ast.YulFunctionCallStatement) {
+	name := call.GetFunctionName()
+	if name == nil || name.GetReferencedDeclaration() != 0 {
+		return
+	}
+	if c.symbols != nil && c.symbols.GetReference(name.GetId()) != 0 {
+		return
+	}
+
+	switch name.GetName() {
+	case "delegatecall", "callcode":
+		c.addEdge(caller, c.addBuiltin(name.GetName()), CallDelegatecall, false, call)
+	case "call", "staticcall":
+		c.addEdge(caller, c.addBuiltin(name.GetName()), CallExternal, false, call)
+	case "selfdestruct":
+		c.addEdge(caller, c.addBuiltin(name.GetName()), CallInternal, false, call)
+	}
+}
+
+// addEdge adds the edge of the call between the nodes.
+func (c *callGraphBuilder) addEdge(caller *CallNode, callee *CallNode, kind CallKind, candidate bool, call ast.Node[ast.NodeType]) {
+	c.graph.addEdge(&CallEdge{
+		From:      caller.Key,
+		To:        callee.Key,
+		Kind:      kind,
+		Candidate: candidate,
+		CallId:    call.GetId(),
+		Src:       call.GetSrc(),
+	})
+}
+
+// resolveModifier returns the modifier invoked within the most derived contract along with the
+// contract declaring it, or nil if the invocation calls a base constructor instead. Modifiers are
+// resolved virtually, by their name within the linearization of the contract.
+func (c *callGraphBuilder) resolveModifier(contract *ir.Contract, invocation *ast.ModifierInvocation) (*ir.Contract, *ast.ModifierDefinition) {
+	// Trees imported from JSON have no symbol table, so the modifier is only looked up by its name.
+	if c.symbols != nil {
+		if _, ok := c.tree.GetById(c.symbols.GetReference(invocation.GetId())).(*ast.ModifierDefinition); !ok {
+			return nil, nil
+		}
+	}
+	return c.resolveModifierByName(contract, invocation.GetName())
+}
+
+// resolveModifierByName returns the first modifier with the given name within the linearization of
+// the contract, along with the contract declaring it.
+func (c *callGraphBuilder) resolveModifierByName(contract *ir.Contract, name string) (*ir.Contract, *ast.ModifierDefinition) {
+	for _, base := range c.root.GetLinearization(contract) {
+		for _, definition := range modifierDefinitions(base) {
+			if definition.GetName() == name {
+				return base, definition
+			}
+		}
+	}
+	return nil, nil
+}
+
+// modifierDefinitions returns the modifiers declared by the contract.
+func modifierDefinitions(contract *ir.Contract) []*ast.ModifierDefinition {
+	toReturn := make([]*ast.ModifierDefinition, 0)
+	if contract.GetAST() == nil || ast.IsNilNode(contract.GetAST().GetContract()) {
+		return toReturn
+	}
+
+	for _, node := range contract.GetAST().GetContract().GetNodes() {
+		if definition, ok := node.(*ast.ModifierDefinition); ok && definition != nil {
+			toReturn = append(toReturn, definition)
+		}
+	}
+	return toReturn
+}
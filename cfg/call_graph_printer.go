@@ -0,0 +1,116 @@
+package cfg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// ToJSON converts the call graph, its nodes along with its edges, to a JSON representation.
+func (g *CallGraph) ToJSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+// ToMermaid generates a string representation of the call graph in Mermaid syntax. Nodes are
+// grouped by the contract they are executed within, while builtins are drawn as stadium shaped
+// nodes. Edges are labeled with the kind of the call, candidate implementations of interface calls
+// being drawn with dotted arrows.
+func (g *CallGraph) ToMermaid() string {
+	if len(g.Nodes) == 0 {
+		return "graph LR\n    No_Functions[No functions found]"
+	}
+
+	ids := g.nodeIds()
+
+	var mermaidGraph strings.Builder
+	mermaidGraph.WriteString("graph LR\n")
+	for _, contract := range g.contracts() {
+		mermaidGraph.WriteString(fmt.Sprintf("    subgraph %s\n", contract))
+		for _, node := range g.Nodes {
+			if node.Contract == contract {
+				mermaidGraph.WriteString(fmt.Sprintf("        %s[\"%s\"]\n", ids[node.Key], node.Key))
+			}
+		}
+		mermaidGraph.WriteString("    end\n")
+	}
+
+	for _, node := range g.Nodes {
+		if node.Kind == CallNodeBuiltin {
+			mermaidGraph.WriteString(fmt.Sprintf("    %s([\"%s\"])\n", ids[node.Key], node.Key))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Candidate {
+			arrow = "-.->"
+		}
+		mermaidGraph.WriteString(fmt.Sprintf("    %s %s|%s| %s\n", ids[edge.From], arrow, edge.Kind, ids[edge.To]))
+	}
+
+	return mermaidGraph.String()
+}
+
+// ToDOT generates a string representation of the call graph in the DOT language of Graphviz.
+// Nodes are clustered by the contract they are executed within, and candidate implementations of
+// interface calls are drawn with dashed edges.
+func (g *CallGraph) ToDOT() string {
+	ids := g.nodeIds()
+
+	var dotGraph strings.Builder
+	dotGraph.WriteString("digraph CallGraph {\n")
+	dotGraph.WriteString("    rankdir=LR;\n")
+	dotGraph.WriteString("    node [shape=box];\n")
+
+	for i, contract := range g.contracts() {
+		dotGraph.WriteString(fmt.Sprintf("    subgraph cluster_%d {\n", i))
+		dotGraph.WriteString(fmt.Sprintf("        label=%q;\n", contract))
+		for _, node := range g.Nodes {
+			if node.Contract == contract {
+				dotGraph.WriteString(fmt.Sprintf("        %s [label=%q];\n", ids[node.Key], node.Key))
+			}
+		}
+		dotGraph.WriteString("    }\n")
+	}
+
+	for _, node := range g.Nodes {
+		if node.Kind == CallNodeBuiltin {
+			dotGraph.WriteString(fmt.Sprintf("    %s [label=%q, shape=ellipse];\n", ids[node.Key], node.Key))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Candidate {
+			dotGraph.WriteString(fmt.Sprintf("    %s -> %s [label=%q, style=dashed];\n", ids[edge.From], ids[edge.To], edge.Kind))
+		} else {
+			dotGraph.WriteString(fmt.Sprintf("    %s -> %s [label=%q];\n", ids[edge.From], ids[edge.To], edge.Kind))
+		}
+	}
+
+	dotGraph.WriteString("}\n")
+	return dotGraph.String()
+}
+
+// nodeIds returns identifiers of the nodes usable within Mermaid and DOT, as keys contain
+// characters neither of them accepts.
+func (g *CallGraph) nodeIds() map[string]string {
+	toReturn := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		toReturn[node.Key] = fmt.Sprintf("N%d", i)
+	}
+	return toReturn
+}
+
+// contracts returns the names of the contracts declaring the nodes, in the order they were added.
+func (g *CallGraph) contracts() []string {
+	toReturn := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, node := range g.Nodes {
+		if node.Contract != "" && !seen[node.Contract] {
+			seen[node.Contract] = true
+			toReturn = append(toReturn, node.Contract)
+		}
+	}
+	return toReturn
+}
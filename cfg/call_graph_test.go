@@ -0,0 +1,114 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/tests/solgotest"
+)
+
+func TestCallGraph(t *testing.T) {
+	builder, err := NewBuilder(context.TODO(), solgotest.NewBuilderForTest(t, "cfg/callgraph/Vault"))
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+
+	graph := builder.GetCallGraph()
+	require.NotNil(t, graph)
+
+	// calls returns the kinds of the calls between the nodes with the given keys.
+	calls := func(from string, to string) []CallKind {
+		node := graph.GetNode(from)
+		require.NotNil(t, node, from)
+
+		toReturn := make([]CallKind, 0)
+		for _, edge := range node.GetCallees() {
+			if edge.GetTo() == to {
+				toReturn = append(toReturn, edge.GetKind())
+			}
+		}
+		return toReturn
+	}
+
+	assert.Equal(t, []CallKind{CallModifier}, calls("Vault.emergency()", "Vault.onlyOwner"))
+	assert.Equal(t, []CallKind{CallInternal}, calls("Vault.onlyOwner", "Vault.check()"))
+	assert.Equal(t, []CallKind{CallInternal}, calls("Vault.emergency()", "Vault.hook()"))
+	assert.Equal(t, []CallKind{CallInternal}, calls("Vault.emergency()", "Vault.kill()"))
+	assert.Equal(t, []CallKind{CallSuper}, calls("Vault.hook()", "Vault.Base.hook()"))
+	assert.Equal(t, []CallKind{CallLibrary}, calls("Vault.sum(uint256)", "MathLib.add(uint256,uint256)"))
+	assert.Equal(t, []CallKind{CallDelegatecall}, calls("Vault.forward(address)", "delegatecall"))
+	assert.Equal(t, []CallKind{CallInternal}, calls("Vault.kill()", "selfdestruct"))
+
+	// Functions inherited by the most derived contract are executed within it, while the base
+	// contract has no nodes of its own.
+	assert.Equal(t, "Base", graph.GetNode("Vault.kill()").GetDeclarer())
+	assert.Equal(t, "Vault", graph.GetNode("Vault.kill()").GetContract())
+	assert.Nil(t, graph.GetNode("Base.kill()"))
+
+	// Calls of interface functions reach each implementation within the sources.
+	for _, implementation := range []string{"StrategyA.run()", "StrategyB.run()"} {
+		assert.Equal(t, []CallKind{CallExternal}, calls("Vault.invest()", implementation))
+	}
+	assert.Empty(t, calls("Vault.invest()", "IStrategy.run()"))
+	for _, edge := range graph.GetNode("Vault.invest()").GetCallees() {
+		assert.True(t, edge.IsCandidate())
+	}
+
+	// Only emergency reaches selfdestruct, through a path passing the modifier check first.
+	entries := graph.GetEntryPointsReaching("selfdestruct")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Vault.emergency()", entries[0].GetKey())
+
+	path := graph.PathTo("Vault.emergency()", "selfdestruct")
+	require.Len(t, path, 2)
+	assert.Equal(t, "Vault.kill()", path[0].GetTo())
+	assert.Nil(t, graph.PathTo("Vault.sum(uint256)", "selfdestruct"))
+
+	data, err := graph.ToJSON()
+	require.NoError(t, err)
+	decoded := &CallGraph{}
+	require.NoError(t, json.Unmarshal(data, decoded))
+	assert.Len(t, decoded.GetNodes(), len(graph.GetNodes()))
+	assert.Len(t, decoded.GetEdges(), len(graph.GetEdges()))
+
+	mermaid := graph.ToMermaid()
+	assert.True(t, strings.HasPrefix(mermaid, "graph LR\n"))
+	assert.Contains(t, mermaid, "subgraph Vault")
+	assert.Contains(t, mermaid, "-.->|external|")
+
+	dot := graph.ToDOT()
+	assert.True(t, strings.HasPrefix(dot, "digraph CallGraph {"))
+	assert.Contains(t, dot, "[label=\"delegatecall\"]")
+	assert.Contains(t, dot, "style=dashed")
+}
+
+func TestCallGraphInheritance(t *testing.T) {
+	builder, err := NewBuilder(context.TODO(), solgotest.NewBuilderForTest(t, "cfg/callgraph/Evil"))
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+
+	graph := builder.GetCallGraph()
+	require.NotNil(t, graph)
+
+	// The inherited run dispatches to the override of the most derived contract.
+	entries := graph.GetEntryPointsReaching("selfdestruct")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Evil.run()", entries[0].GetKey())
+	assert.Equal(t, "Base", entries[0].GetDeclarer())
+
+	path := graph.PathTo("Evil.run()", "selfdestruct")
+	require.Len(t, path, 2)
+	assert.Equal(t, "Evil.hook()", path[0].GetTo())
+
+	// Super calls continue within the linearization of the most derived contract.
+	keys := make([]string, 0)
+	for _, node := range graph.Reachable("Evil.run()") {
+		keys = append(keys, node.GetKey())
+	}
+	assert.ElementsMatch(t, []string{"Evil.hook()", "Evil.Middle.hook()", "Evil.Base.hook()", "selfdestruct"}, keys)
+	assert.Equal(t, CallSuper, graph.GetNode("Evil.Middle.hook()").GetCallees()[0].GetKind())
+	assert.Nil(t, graph.GetNode("Base.run()"))
+}
@@ -0,0 +1,233 @@
+package cfg
+
+import (
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// BlockKind describes the role of a basic block within the control flow graph of a function.
+type BlockKind string
+
+const (
+	BlockEntry    BlockKind = "entry"     // Start of the function, before its modifiers.
+	BlockExit     BlockKind = "exit"      // Successful end of the function.
+	BlockRevert   BlockKind = "revert"    // Reverting end of the function.
+	BlockBasic    BlockKind = "basic"     // Sequence of statements executed one after another.
+	BlockYulEntry BlockKind = "yul_entry" // Start of a function defined within inline assembly.
+	BlockYulExit  BlockKind = "yul_exit"  // End of a function defined within inline assembly.
+)
+
+// EdgeKind describes how the execution moves from one basic block to another.
+type EdgeKind string
+
+const (
+	EdgeNext        EdgeKind = "next"        // Falls through to the following block.
+	EdgeTrue        EdgeKind = "true"        // Condition holds.
+	EdgeFalse       EdgeKind = "false"       // Condition does not hold.
+	EdgeLoop        EdgeKind = "loop"        // Back edge to the condition of a loop.
+	EdgeBreak       EdgeKind = "break"       // Leaves the enclosing loop.
+	EdgeContinue    EdgeKind = "continue"    // Continues with the next iteration of the enclosing loop.
+	EdgeReturn      EdgeKind = "return"      // Returns from the function or modifier body.
+	EdgeRevert      EdgeKind = "revert"      // Reverts, either always or when a require or assert fails.
+	EdgeTry         EdgeKind = "try"         // External call of a try statement succeeds.
+	EdgeCatch       EdgeKind = "catch"       // External call of a try statement fails.
+	EdgePlaceholder EdgeKind = "placeholder" // Modifier placeholder enters the next modifier or the function body.
+	EdgeCase        EdgeKind = "case"        // Yul switch case matches.
+	EdgeDefault     EdgeKind = "default"     // No Yul switch case matches.
+	EdgeLeave       EdgeKind = "leave"       // Leaves the Yul function.
+)
+
+// Statement is a statement or condition executed within a basic block.
+type Statement struct {
+	Node     ast.Node[ast.NodeType] `json:"-"`
+	Id       int64                  `json:"id"`
+	NodeType ast_pb.NodeType        `json:"nodeType"`
+	Src      ast.SrcNode            `json:"src"`
+}
+
+// GetNode returns the AST node of the statement.
+func (s *Statement) GetNode() ast.Node[ast.NodeType] {
+	return s.Node
+}
+
+// BasicBlock is a sequence of statements executed one after another, entered only at its start and
+// left only at its end.
+type BasicBlock struct {
+	Id            int          `json:"id"`
+	Kind          BlockKind    `json:"kind"`
+	DeclarationId int64        `json:"declarationId"` // Function, modifier or Yul function the statements belong to.
+	Statements    []*Statement `json:"statements"`
+	Successors    []*Edge      `json:"-"`
+	Predecessors  []*Edge      `json:"-"`
+}
+
+// GetId returns the id of the block, unique within its graph.
+func (b *BasicBlock) GetId() int {
+	return b.Id
+}
+
+// GetKind returns the kind of the block.
+func (b *BasicBlock) GetKind() BlockKind {
+	return b.Kind
+}
+
+// GetDeclarationId returns the id of the function, modifier or Yul function the statements of the
+// block belong to.
+func (b *BasicBlock) GetDeclarationId() int64 {
+	return b.DeclarationId
+}
+
+// GetStatements returns the statements of the block.
+func (b *BasicBlock) GetStatements() []*Statement {
+	return b.Statements
+}
+
+// GetSuccessors returns the edges leaving the block.
+func (b *BasicBlock) GetSuccessors() []*Edge {
+	return b.Successors
+}
+
+// GetPredecessors returns the edges entering the block.
+func (b *BasicBlock) GetPredecessors() []*Edge {
+	return b.Predecessors
+}
+
+// IsReachable returns true if the block is entered by any edge. Entry blocks are always reachable.
+func (b *BasicBlock) IsReachable() bool {
+	return b.Kind == BlockEntry || b.Kind == BlockYulEntry || len(b.Predecessors) > 0
+}
+
+// Edge is a transfer of control from one basic block to another.
+type Edge struct {
+	From int      `json:"from"`
+	To   int      `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// GetFrom returns the id of the block the edge leaves.
+func (e *Edge) GetFrom() int {
+	return e.From
+}
+
+// GetTo returns the id of the block the edge enters.
+func (e *Edge) GetTo() int {
+	return e.To
+}
+
+// GetKind returns the kind of the edge.
+func (e *Edge) GetKind() EdgeKind {
+	return e.Kind
+}
+
+// FunctionGraph is the statement level control flow graph of a function, with the bodies of its
+// modifiers inlined at their placeholders. The first three blocks are always the entry, exit and
+// revert blocks.
+type FunctionGraph struct {
+	Function  *ir.Function  `json:"-"`
+	Id        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Signature string        `json:"signature"`
+	Blocks    []*BasicBlock `json:"blocks"`
+	Edges     []*Edge       `json:"edges"`
+}
+
+// newFunctionGraph creates the graph of the function, with its entry, exit and revert blocks.
+func newFunctionGraph(function *ir.Function) *FunctionGraph {
+	toReturn := &FunctionGraph{
+		Function:  function,
+		Id:        function.GetId(),
+		Name:      function.GetName(),
+		Signature: function.GetSignature(),
+		Blocks:    make([]*BasicBlock, 0),
+		Edges:     make([]*Edge, 0),
+	}
+
+	toReturn.addBlock(BlockEntry, function.GetId())
+	toReturn.addBlock(BlockExit, function.GetId())
+	toReturn.addBlock(BlockRevert, function.GetId())
+	return toReturn
+}
+
+// GetFunction returns the IR of the function.
+func (g *FunctionGraph) GetFunction() *ir.Function {
+	return g.Function
+}
+
+// GetName returns the name of the function.
+func (g *FunctionGraph) GetName() string {
+	return g.Name
+}
+
+// GetSignature returns the signature of the function.
+func (g *FunctionGraph) GetSignature() string {
+	return g.Signature
+}
+
+// GetBlocks returns all of the blocks of the graph.
+func (g *FunctionGraph) GetBlocks() []*BasicBlock {
+	return g.Blocks
+}
+
+// GetBlock returns the block with the given id, or nil if there is no such block.
+func (g *FunctionGraph) GetBlock(id int) *BasicBlock {
+	if id < 0 || id >= len(g.Blocks) {
+		return nil
+	}
+	return g.Blocks[id]
+}
+
+// GetEdges returns all of the edges of the graph.
+func (g *FunctionGraph) GetEdges() []*Edge {
+	return g.Edges
+}
+
+// GetEntry returns the block the function starts with.
+func (g *FunctionGraph) GetEntry() *BasicBlock {
+	return g.Blocks[0]
+}
+
+// GetExit returns the block the function ends with when it succeeds.
+func (g *FunctionGraph) GetExit() *BasicBlock {
+	return g.Blocks[1]
+}
+
+// GetRevert returns the block the function ends with when it reverts.
+func (g *FunctionGraph) GetRevert() *BasicBlock {
+	return g.Blocks[2]
+}
+
+// GetBlockByStatement returns the block executing the AST node with the given id, or nil if no
+// block executes it. Nodes of modifiers used more than once are found in their first use.
+func (g *FunctionGraph) GetBlockByStatement(id int64) *BasicBlock {
+	for _, block := range g.Blocks {
+		for _, statement := range block.Statements {
+			if statement.Id == id {
+				return block
+			}
+		}
+	}
+	return nil
+}
+
+// addBlock appends a new block of the given kind to the graph.
+func (g *FunctionGraph) addBlock(kind BlockKind, declarationId int64) *BasicBlock {
+	block := &BasicBlock{
+		Id:            len(g.Blocks),
+		Kind:          kind,
+		DeclarationId: declarationId,
+		Statements:    make([]*Statement, 0),
+		Successors:    make([]*Edge, 0),
+		Predecessors:  make([]*Edge, 0),
+	}
+	g.Blocks = append(g.Blocks, block)
+	return block
+}
+
+// addEdge connects the blocks with an edge of the given kind.
+func (g *FunctionGraph) addEdge(from *BasicBlock, to *BasicBlock, kind EdgeKind) {
+	edge := &Edge{From: from.Id, To: to.Id, Kind: kind}
+	from.Successors = append(from.Successors, edge)
+	to.Predecessors = append(to.Predecessors, edge)
+	g.Edges = append(g.Edges, edge)
+}
@@ -0,0 +1,474 @@
+package cfg
+
+import (
+	"errors"
+
+	ast_pb "github.com/unpackdev/protos/dist/go/ast"
+	"github.com/unpackdev/solgo/ast"
+	"github.com/unpackdev/solgo/ir"
+)
+
+// pending is an edge waiting for the block it enters to be created.
+type pending struct {
+	from *BasicBlock
+	kind EdgeKind
+}
+
+// flowContext holds the targets of the jumps possible within the statements being processed. Jumps
+// whose target is not created yet are collected as pending edges.
+type flowContext struct {
+	declarationId int64            // Function, modifier or Yul function being processed.
+	returns       *[]pending       // Return statements of the function or modifier body.
+	breaks        *[]pending       // Break statements of the innermost loop.
+	continues     *[]pending       // Continue statements of the innermost loop.
+	leaves        *[]pending       // Leave statements of the Yul function.
+	placeholder   func() []pending // Builds the next modifier or the function body at the placeholder.
+}
+
+// loop returns a copy of the context for the body of a loop.
+func (c *flowContext) loop() *flowContext {
+	toReturn := *c
+	toReturn.breaks = &[]pending{}
+	toReturn.continues = &[]pending{}
+	return &toReturn
+}
+
+// functionGraphBuilder builds the control flow graph of a single function.
+type functionGraphBuilder struct {
+	graph   *FunctionGraph
+	tree    *ast.Tree
+	symbols *ast.SymbolTable
+	current *BasicBlock // Block statements are added to, or nil if the current statement is unreachable.
+}
+
+// BuildFunctionGraph builds the statement level control flow graph of the function. Modifiers of
+// the function are inlined, so that the placeholder of each modifier enters the next modifier, and
+// the placeholder of the last one enters the body of the function.
+func (b *Builder) BuildFunctionGraph(function *ir.Function) (*FunctionGraph, error) {
+	if function == nil || function.GetAST() == nil {
+		return nil, errors.New("function is not set (at control flow graph builder)")
+	}
+
+	astBuilder := b.builder.GetAstBuilder()
+	if astBuilder == nil || astBuilder.GetTree() == nil {
+		return nil, errors.New("ast tree is not set in IR builder")
+	}
+
+	fb := &functionGraphBuilder{
+		graph:   newFunctionGraph(function),
+		tree:    astBuilder.GetTree(),
+		symbols: astBuilder.GetSymbolTable(),
+	}
+	fb.current = fb.graph.GetEntry()
+
+	exits := fb.region(function.GetAST(), fb.modifiers(function), 0, EdgeNext)
+	fb.connect(exits, fb.graph.GetExit())
+
+	return fb.graph, nil
+}
+
+// modifiers returns the definitions of the modifiers the function is invoked with, in the order
+// they are executed in. Invocations of base constructors are not among them.
+func (f *functionGraphBuilder) modifiers(function *ir.Function) []*ast.ModifierDefinition {
+	toReturn := make([]*ast.ModifierDefinition, 0, len(function.GetModifiers()))
+	for _, modifier := range function.GetModifiers() {
+		if f.symbols != nil {
+			if definition, ok := f.tree.GetById(f.symbols.GetReference(modifier.GetId())).(*ast.ModifierDefinition); ok {
+				toReturn = append(toReturn, definition)
+				continue
+			}
+		}
+
+		// Trees imported from JSON have no symbol table, so the modifier is looked up by its name
+		// within the contract declaring the function.
+		contract := f.tree.GetById(function.GetAST().GetScope())
+		if ast.IsNilNode(contract) {
+			continue
+		}
+		for _, node := range contract.GetNodes() {
+			if definition, ok := node.(*ast.ModifierDefinition); ok && definition.GetName() == modifier.GetName() {
+				toReturn = append(toReturn, definition)
+				break
+			}
+		}
+	}
+	return toReturn
+}
+
+// region builds the modifier with the given index, or the body of the function once all of the
+// modifiers are built, entering it with the edge of the given kind. It returns the edges leaving
+// the region, which continue after the placeholder of the enclosing modifier.
+func (f *functionGraphBuilder) region(function *ast.Function, modifiers []*ast.ModifierDefinition, index int, kind EdgeKind) []pending {
+	ctx := &flowContext{
+		declarationId: function.GetId(),
+		returns:       &[]pending{},
+	}
+
+	var body *ast.BodyNode
+	if index < len(modifiers) {
+		ctx.declarationId = modifiers[index].GetId()
+		ctx.placeholder = func() []pending {
+			return f.region(function, modifiers, index+1, EdgePlaceholder)
+		}
+		body = modifiers[index].GetBody()
+	} else {
+		body = function.GetBody()
+	}
+
+	f.begin(kind, ctx.declarationId)
+
+	// Modifiers without a body are virtual ones, left to be overridden, and only pass through.
+	if body == nil && ctx.placeholder != nil {
+		f.join(ctx.placeholder(), ctx.declarationId)
+	} else {
+		f.statement(body, ctx)
+	}
+
+	return append(*ctx.returns, f.fallThrough(EdgeNext)...)
+}
+
+// statement adds the Solidity statement to the graph.
+func (f *functionGraphBuilder) statement(node ast.Node[ast.NodeType], ctx *flowContext) {
+	if ast.IsNilNode(node) {
+		return
+	}
+
+	switch nodeCtx := node.(type) {
+	case *ast.BodyNode:
+		for _, statement := range nodeCtx.GetStatements() {
+			f.statement(statement, ctx)
+		}
+
+	case *ast.IfStatement:
+		condition := f.add(conditionOf(nodeCtx.Condition, node), ctx)
+		f.begin(EdgeTrue, ctx.declarationId)
+		f.statement(nodeCtx.Body, ctx)
+		exits := f.fallThrough(EdgeNext)
+
+		if !ast.IsNilNode(nodeCtx.Else) {
+			f.current = condition
+			f.begin(EdgeFalse, ctx.declarationId)
+			f.statement(nodeCtx.Else, ctx)
+			exits = append(exits, f.fallThrough(EdgeNext)...)
+		} else {
+			exits = append(exits, pending{from: condition, kind: EdgeFalse})
+		}
+		f.join(exits, ctx.declarationId)
+
+	case *ast.ForStatement:
+		f.statement(nodeCtx.Initialiser, ctx)
+		f.begin(EdgeNext, ctx.declarationId)
+		var closure func()
+		if !ast.IsNilNode(nodeCtx.Closure) {
+			closure = func() { f.statement(nodeCtx.Closure, ctx) }
+		}
+		f.loop(f.current, nodeCtx.Condition, ctx, func(loop *flowContext) {
+			f.statement(nodeCtx.Body, loop)
+		}, closure)
+
+	case *ast.WhileStatement:
+		f.begin(EdgeNext, ctx.declarationId)
+		f.loop(f.current, conditionOf(nodeCtx.Condition, node), ctx, func(loop *flowContext) {
+			f.statement(nodeCtx.Body, loop)
+		}, nil)
+
+	case *ast.DoWhileStatement:
+		f.begin(EdgeNext, ctx.declarationId)
+		body := f.current
+		loop := ctx.loop()
+		f.statement(nodeCtx.Body, loop)
+		f.join(append(*loop.continues, f.fallThrough(EdgeNext)...), ctx.declarationId)
+		condition := f.add(conditionOf(nodeCtx.Condition, node), ctx)
+		f.graph.addEdge(condition, body, EdgeLoop)
+		f.join(append([]pending{{from: condition, kind: EdgeFalse}}, *loop.breaks...), ctx.declarationId)
+
+	case *ast.BreakStatement:
+		f.jumpPending(node, ctx.breaks, EdgeBreak, ctx)
+	case *ast.ContinueStatement:
+		f.jumpPending(node, ctx.continues, EdgeContinue, ctx)
+	case *ast.ReturnStatement:
+		f.jumpPending(node, ctx.returns, EdgeReturn, ctx)
+	case *ast.RevertStatement:
+		f.add(node, ctx)
+		f.jump(f.graph.GetRevert(), EdgeRevert)
+
+	case *ast.TryStatement:
+		call := f.add(conditionOf(nodeCtx.Expression, node), ctx)
+		f.begin(EdgeTry, ctx.declarationId)
+		f.statement(nodeCtx.Body, ctx)
+		exits := f.fallThrough(EdgeNext)
+		for _, clause := range nodeCtx.Clauses {
+			f.current = call
+			f.begin(EdgeCatch, ctx.declarationId)
+			if catch, ok := clause.(*ast.CatchStatement); ok && catch != nil {
+				f.statement(catch.Body, ctx)
+			}
+			exits = append(exits, f.fallThrough(EdgeNext)...)
+		}
+		f.join(exits, ctx.declarationId)
+
+	case *ast.FunctionCall:
+		f.add(node, ctx)
+		switch builtinName(nodeCtx.GetExpression()) {
+		case "revert":
+			f.jump(f.graph.GetRevert(), EdgeRevert)
+		case "require", "assert":
+			f.graph.addEdge(f.current, f.graph.GetRevert(), EdgeRevert)
+			f.begin(EdgeNext, ctx.declarationId)
+		}
+
+	case *ast.PrimaryExpression:
+		f.add(node, ctx)
+		if nodeCtx.GetType() == ast_pb.NodeType_PLACEHOLDER_STATEMENT && ctx.placeholder != nil {
+			f.join(ctx.placeholder(), ctx.declarationId)
+		}
+
+	case *ast.Yul:
+		f.add(node, ctx)
+		if nodeCtx.GetBody() != nil {
+			for _, statement := range nodeCtx.GetBody().GetStatements() {
+				f.yulStatement(statement, ctx)
+			}
+		}
+
+	default:
+		f.add(node, ctx)
+	}
+}
+
+// loop adds a loop checking the condition within the header block. The closure, if any, is
+// executed after the body and after continue statements.
+func (f *functionGraphBuilder) loop(header *BasicBlock, condition ast.Node[ast.NodeType], ctx *flowContext, body func(loop *flowContext), closure func()) {
+	enter := EdgeNext
+	if !ast.IsNilNode(condition) {
+		f.add(condition, ctx)
+		enter = EdgeTrue
+	}
+
+	loop := ctx.loop()
+	f.begin(enter, ctx.declarationId)
+	body(loop)
+
+	if closure != nil {
+		f.join(append(*loop.continues, f.fallThrough(EdgeNext)...), ctx.declarationId)
+		closure()
+		f.connect(f.fallThrough(EdgeLoop), header)
+	} else {
+		f.connect(append(*loop.continues, f.fallThrough(EdgeLoop)...), header)
+	}
+
+	exits := *loop.breaks
+	if !ast.IsNilNode(condition) {
+		exits = append([]pending{{from: header, kind: EdgeFalse}}, exits...)
+	}
+	f.join(exits, ctx.declarationId)
+}
+
+// yulStatement adds the Yul statement to the graph.
+func (f *functionGraphBuilder) yulStatement(node ast.Node[ast.NodeType], ctx *flowContext) {
+	if ast.IsNilNode(node) {
+		return
+	}
+
+	switch nodeCtx := node.(type) {
+	case *ast.YulStatement:
+		for _, statement := range nodeCtx.GetStatements() {
+			f.yulStatement(statement, ctx)
+		}
+	case *ast.YulBlockStatement:
+		for _, statement := range nodeCtx.GetStatements() {
+			f.yulStatement(statement, ctx)
+		}
+	case *ast.YulExpressionStatement:
+		f.yulStatement(nodeCtx.GetExpression(), ctx)
+
+	case *ast.YulIfStatement:
+		condition := f.add(conditionOf(nodeCtx.GetCondition(), node), ctx)
+		f.begin(EdgeTrue, ctx.declarationId)
+		f.yulStatement(nodeCtx.GetBody(), ctx)
+		f.join(append(f.fallThrough(EdgeNext), pending{from: condition, kind: EdgeFalse}), ctx.declarationId)
+
+	case *ast.YulSwitchStatement:
+		expression := f.add(conditionOf(nodeCtx.GetExpression(), node), ctx)
+		exits := make([]pending, 0)
+		for _, clause := range nodeCtx.GetCases() {
+			f.current = expression
+			f.begin(EdgeCase, ctx.declarationId)
+			if switchCase, ok := clause.(*ast.YulSwitchCaseStatement); ok && switchCase != nil {
+				f.add(conditionOf(switchCase.GetCase(), clause), ctx)
+				f.yulStatement(switchCase.GetBody(), ctx)
+			}
+			exits = append(exits, f.fallThrough(EdgeNext)...)
+		}
+		if !ast.IsNilNode(nodeCtx.GetDefault()) {
+			f.current = expression
+			f.begin(EdgeDefault, ctx.declarationId)
+			f.yulStatement(nodeCtx.GetDefault(), ctx)
+			exits = append(exits, f.fallThrough(EdgeNext)...)
+		} else {
+			exits = append(exits, pending{from: expression, kind: EdgeDefault})
+		}
+		f.join(exits, ctx.declarationId)
+
+	case *ast.YulForStatement:
+		f.yulStatement(nodeCtx.GetPre(), ctx)
+		f.begin(EdgeNext, ctx.declarationId)
+		f.loop(f.current, nodeCtx.GetCondition(), ctx, func(loop *flowContext) {
+			f.yulStatement(nodeCtx.GetBody(), loop)
+		}, func() {
+			f.yulStatement(nodeCtx.GetPost(), ctx)
+		})
+
+	case *ast.YulBreakStatement:
+		f.jumpPending(node, ctx.breaks, EdgeBreak, ctx)
+	case *ast.YulContinueStatement:
+		f.jumpPending(node, ctx.continues, EdgeContinue, ctx)
+	case *ast.YulLeaveStatement:
+		f.jumpPending(node, ctx.leaves, EdgeLeave, ctx)
+
+	case *ast.YulFunctionDefinition:
+		f.yulFunction(nodeCtx)
+
+	case *ast.YulFunctionCallStatement:
+		f.add(node, ctx)
+		if name := nodeCtx.GetFunctionName(); name != nil && f.isYulBuiltin(name) {
+			switch name.GetName() {
+			case "return", "stop":
+				// Unlike Solidity return statements, these end the whole call, modifiers included.
+				f.jump(f.graph.GetExit(), EdgeReturn)
+			case "revert", "invalid":
+				f.jump(f.graph.GetRevert(), EdgeRevert)
+			}
+		}
+
+	default:
+		f.add(node, ctx)
+	}
+}
+
+// yulFunction adds the body of the Yul function as a region of its own, between its entry and exit
+// blocks. Calls of the function are not connected to it.
+func (f *functionGraphBuilder) yulFunction(function *ast.YulFunctionDefinition) {
+	current := f.current
+	defer func() {
+		f.current = current
+	}()
+
+	ctx := &flowContext{
+		declarationId: function.GetId(),
+		leaves:        &[]pending{},
+	}
+
+	f.current = f.graph.addBlock(BlockYulEntry, function.GetId())
+	f.current.Statements = append(f.current.Statements, newStatement(function))
+	f.begin(EdgeNext, ctx.declarationId)
+	f.yulStatement(function.GetBody(), ctx)
+
+	exit := f.graph.addBlock(BlockYulExit, function.GetId())
+	f.connect(append(*ctx.leaves, f.fallThrough(EdgeNext)...), exit)
+}
+
+// isYulBuiltin returns true if the identifier calls a builtin rather than a Yul function.
+func (f *functionGraphBuilder) isYulBuiltin(identifier *ast.YulIdentifier) bool {
+	if f.symbols != nil && f.symbols.GetReference(identifier.GetId()) != 0 {
+		return false
+	}
+	return identifier.GetReferencedDeclaration() == 0
+}
+
+// add appends the node to the current block and returns the block. A new block, which nothing
+// enters, is started for nodes following a jump.
+func (f *functionGraphBuilder) add(node ast.Node[ast.NodeType], ctx *flowContext) *BasicBlock {
+	if f.current == nil {
+		f.current = f.graph.addBlock(BlockBasic, ctx.declarationId)
+	}
+	f.current.Statements = append(f.current.Statements, newStatement(node))
+	return f.current
+}
+
+// begin starts a new block entered from the current block with the edge of the given kind.
+func (f *functionGraphBuilder) begin(kind EdgeKind, declarationId int64) {
+	block := f.graph.addBlock(BlockBasic, declarationId)
+	if f.current != nil {
+		f.graph.addEdge(f.current, block, kind)
+	}
+	f.current = block
+}
+
+// jump ends the current block with the edge of the given kind to the target.
+func (f *functionGraphBuilder) jump(target *BasicBlock, kind EdgeKind) {
+	if f.current != nil {
+		f.graph.addEdge(f.current, target, kind)
+	}
+	f.current = nil
+}
+
+// jumpPending ends the current block with the node and an edge of the given kind, which enters a
+// block created later on. Nodes outside of the construct they jump out of are kept as they are.
+func (f *functionGraphBuilder) jumpPending(node ast.Node[ast.NodeType], targets *[]pending, kind EdgeKind, ctx *flowContext) {
+	block := f.add(node, ctx)
+	if targets == nil {
+		return
+	}
+	*targets = append(*targets, pending{from: block, kind: kind})
+	f.current = nil
+}
+
+// fallThrough returns the edge leaving the current block once it ends without a jump, if the end
+// of the block is reachable at all.
+func (f *functionGraphBuilder) fallThrough(kind EdgeKind) []pending {
+	if f.current == nil {
+		return nil
+	}
+	toReturn := []pending{{from: f.current, kind: kind}}
+	f.current = nil
+	return toReturn
+}
+
+// join starts a new block entered by the pending edges. If there are none, the code following
+// them is unreachable and no block is started.
+func (f *functionGraphBuilder) join(edges []pending, declarationId int64) {
+	if len(edges) == 0 {
+		f.current = nil
+		return
+	}
+
+	block := f.graph.addBlock(BlockBasic, declarationId)
+	f.connect(edges, block)
+	f.current = block
+}
+
+// connect connects the pending edges to the target.
+func (f *functionGraphBuilder) connect(edges []pending, target *BasicBlock) {
+	for _, edge := range edges {
+		f.graph.addEdge(edge.from, target, edge.kind)
+	}
+}
+
+// newStatement creates the statement of the node.
+func newStatement(node ast.Node[ast.NodeType]) *Statement {
+	return &Statement{
+		Node:     node,
+		Id:       node.GetId(),
+		NodeType: node.GetType(),
+		Src:      node.GetSrc(),
+	}
+}
+
+// conditionOf returns the condition, or the statement itself if the condition is missing.
+func conditionOf(condition ast.Node[ast.NodeType], statement ast.Node[ast.NodeType]) ast.Node[ast.NodeType] {
+	if ast.IsNilNode(condition) {
+		return statement
+	}
+	return condition
+}
+
+// builtinName returns the name of the builtin function called by the expression, or an empty
+// string if the expression does not call a builtin.
+func builtinName(expression ast.Node[ast.NodeType]) string {
+	primary, ok := expression.(*ast.PrimaryExpression)
+	if !ok || primary == nil || primary.GetReferencedDeclaration() != 0 {
+		return ""
+	}
+	return primary.GetName()
+}
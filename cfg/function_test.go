@@ -0,0 +1,145 @@
+package cfg
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unpackdev/solgo/tests/solgotest"
+)
+
+func buildFunctionGraphs(t *testing.T) *Builder {
+	builder, err := NewBuilder(context.TODO(), solgotest.NewBuilderForTest(t, "cfg/function/Vault"))
+	require.NoError(t, err)
+	require.NoError(t, builder.Build())
+	return builder
+}
+
+// edgeKinds returns the kinds of the edges of the graph, counted.
+func edgeKinds(graph *FunctionGraph) map[EdgeKind]int {
+	toReturn := make(map[EdgeKind]int)
+	for _, edge := range graph.GetEdges() {
+		toReturn[edge.GetKind()]++
+	}
+	return toReturn
+}
+
+func TestFunctionGraph(t *testing.T) {
+	builder := buildFunctionGraphs(t)
+
+	node := builder.GetGraph().GetNode("Vault")
+	require.NotNil(t, node)
+	require.Len(t, node.GetFunctions(), 4)
+
+	t.Run("modifier and loop", func(t *testing.T) {
+		graph := node.GetFunction("sum")
+		require.NotNil(t, graph)
+
+		kinds := edgeKinds(graph)
+		assert.Equal(t, 1, kinds[EdgePlaceholder])
+		assert.Equal(t, 1, kinds[EdgeRevert])
+		assert.Equal(t, 1, kinds[EdgeContinue])
+		assert.Equal(t, 1, kinds[EdgeBreak])
+		assert.Equal(t, 1, kinds[EdgeReturn])
+		assert.Equal(t, 1, kinds[EdgeLoop])
+
+		// The modifier body continues after the placeholder once the function body returns.
+		var placeholder *Edge
+		for _, edge := range graph.GetEdges() {
+			if edge.GetKind() == EdgePlaceholder {
+				placeholder = edge
+			}
+		}
+		require.NotNil(t, placeholder)
+		from := graph.GetBlock(placeholder.GetFrom())
+		to := graph.GetBlock(placeholder.GetTo())
+		assert.NotEqual(t, from.GetDeclarationId(), to.GetDeclarationId())
+		assert.Equal(t, graph.GetFunction().GetId(), to.GetDeclarationId())
+
+		for _, edge := range graph.GetEdges() {
+			if edge.GetKind() == EdgeReturn {
+				assert.NotEqual(t, graph.GetExit().GetId(), edge.GetTo())
+				assert.Equal(t, from.GetDeclarationId(), graph.GetBlock(edge.GetTo()).GetDeclarationId())
+			}
+		}
+		assert.NotEmpty(t, graph.GetExit().GetPredecessors())
+	})
+
+	t.Run("while, do while and revert", func(t *testing.T) {
+		graph := node.GetFunction("drain")
+		require.NotNil(t, graph)
+
+		kinds := edgeKinds(graph)
+		assert.Equal(t, 2, kinds[EdgeLoop])
+		assert.Equal(t, 1, kinds[EdgeRevert])
+		assert.Equal(t, 2, kinds[EdgeTrue])
+		assert.Equal(t, 3, kinds[EdgeFalse])
+		require.Len(t, graph.GetRevert().GetPredecessors(), 1)
+	})
+
+	t.Run("try and catch", func(t *testing.T) {
+		graph := node.GetFunction("quote")
+		require.NotNil(t, graph)
+
+		kinds := edgeKinds(graph)
+		assert.Equal(t, 1, kinds[EdgeTry])
+		assert.Equal(t, 1, kinds[EdgeCatch])
+		assert.Equal(t, 1, kinds[EdgeRevert])
+
+		// Both clauses end the function, so nothing falls through to the exit.
+		require.Len(t, graph.GetExit().GetPredecessors(), 1)
+		assert.Equal(t, EdgeReturn, graph.GetExit().GetPredecessors()[0].GetKind())
+		for _, block := range graph.GetBlocks() {
+			if block.GetKind() == BlockBasic && len(block.GetStatements()) > 0 {
+				assert.True(t, block.IsReachable())
+			}
+		}
+	})
+
+	t.Run("assembly switch and leave", func(t *testing.T) {
+		graph := node.GetFunction("select")
+		require.NotNil(t, graph)
+
+		kinds := edgeKinds(graph)
+		assert.Equal(t, 2, kinds[EdgeCase])
+		assert.Equal(t, 1, kinds[EdgeDefault])
+		assert.Equal(t, 1, kinds[EdgeLeave])
+		assert.Equal(t, 1, kinds[EdgeRevert])
+
+		var entries, exits int
+		for _, block := range graph.GetBlocks() {
+			switch block.GetKind() {
+			case BlockYulEntry:
+				entries++
+			case BlockYulExit:
+				exits++
+				assert.Len(t, block.GetPredecessors(), 2)
+			}
+		}
+		assert.Equal(t, 1, entries)
+		assert.Equal(t, 1, exits)
+	})
+
+	t.Run("printers", func(t *testing.T) {
+		mermaid, err := builder.ToFunctionMermaid("Vault", "sum")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(mermaid, "graph TD\n"))
+		assert.Contains(t, mermaid, "-->|placeholder|")
+
+		_, err = builder.ToFunctionMermaid("Vault", "missing")
+		assert.Error(t, err)
+
+		data, err := builder.ToJSON("Vault")
+		require.NoError(t, err)
+
+		var decoded struct {
+			Functions []*FunctionGraph `json:"functions"`
+		}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		require.Len(t, decoded.Functions, 4)
+		assert.Equal(t, len(node.GetFunction("sum").GetEdges()), len(decoded.Functions[0].GetEdges()))
+	})
+}
@@ -9,13 +9,14 @@ import (
 
 // Node represents a node in the graph, encapsulating information about a Solidity contract.
 // It includes the contract's name, IR representation, import dependencies, inherited contracts,
-// and a flag indicating if it's the entry contract.
+// a flag indicating if it's the entry contract and the control flow graphs of its functions.
 type Node struct {
 	Name          string              `json:"name"`
 	Contract      *ir.Contract        `json:"-"`
 	Imports       []*ir.Import        `json:"imports"`
 	Inherits      []*ast.BaseContract `json:"inherits"`
 	EntryContract bool                `json:"entry_contract"`
+	Functions     []*FunctionGraph    `json:"functions"`
 }
 
 // IsEntryContract returns true if the node represents an entry contract in the graph.
@@ -38,6 +39,22 @@ func (n *Node) GetInherits() []*ast.BaseContract {
 	return n.Inherits
 }
 
+// GetFunctions returns the control flow graphs of the functions of the contract.
+func (n *Node) GetFunctions() []*FunctionGraph {
+	return n.Functions
+}
+
+// GetFunction returns the control flow graph of the function with the given name, or nil if the
+// contract has no such function. Overloaded functions are matched by their signature instead.
+func (n *Node) GetFunction(name string) *FunctionGraph {
+	for _, function := range n.Functions {
+		if function.GetName() == name || function.GetSignature() == name {
+			return function
+		}
+	}
+	return nil
+}
+
 // GetName returns the name of the Solidity contract.
 func (n *Node) GetName() string {
 	return n.Name
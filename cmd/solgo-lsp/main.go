@@ -0,0 +1,43 @@
+// Command solgo-lsp is a Language Server Protocol server for Solidity, speaking JSON-RPC over
+// stdin and stdout. Logs are written to stderr.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/unpackdev/solgo/lsp"
+	"go.uber.org/zap"
+)
+
+func main() {
+	debug := flag.Bool("debug", false, "Log debug messages to stderr")
+	flag.Parse()
+
+	config := zap.NewProductionConfig()
+	config.OutputPaths = []string{"stderr"}
+	config.ErrorOutputPaths = []string{"stderr"}
+	if *debug {
+		config.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	}
+
+	logger, err := config.Build()
+	if err != nil {
+		panic(err)
+	}
+	defer logger.Sync() // nolint:errcheck
+
+	// Stdout carries the protocol, so nothing else can be written to it.
+	zap.ReplaceGlobals(logger)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := lsp.NewServer(ctx, os.Stdin, os.Stdout).Run(); err != nil {
+		logger.Error("Language server stopped", zap.Error(err))
+		os.Exit(1)
+	}
+}
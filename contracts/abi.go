@@ -0,0 +1,79 @@
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/unpackdev/solgo/bytecode"
+	"github.com/unpackdev/solgo/opcode"
+)
+
+// ReconstructAbi reconstructs the ABI of the contract from its deployed bytecode, which allows
+// decoding the transactions and logs of contracts whose source code is not verified. Functions and
+// events are named after the signature database when it holds their selector or topic.
+// The reconstructed ABI is stored in the contract descriptor for further processing.
+func (c *Contract) ReconstructAbi() (opcode.Abi, error) {
+	if c.descriptor.ReconstructedABI != nil {
+		return c.descriptor.ReconstructedABI, nil
+	}
+
+	if len(c.descriptor.DeployedBytecode) < 1 {
+		return nil, fmt.Errorf("failed to reconstruct abi of %s: deployed bytecode is not discovered", c.addr.Hex())
+	}
+
+	decompiler, err := opcode.NewDecompiler(c.ctx, c.descriptor.DeployedBytecode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decompiler for %s: %w", c.addr.Hex(), err)
+	}
+
+	if err := decompiler.Decompile(); err != nil {
+		return nil, fmt.Errorf("failed to decompile bytecode of %s: %w", c.addr.Hex(), err)
+	}
+
+	c.descriptor.ReconstructedABI = decompiler.GetAbi()
+	return c.descriptor.ReconstructedABI, nil
+}
+
+// decodeTransactionFromBytecode decodes the transaction data with the function of the reconstructed
+// ABI matching its selector, or with the signature database if the bytecode does not dispatch it.
+func (c *Contract) decodeTransactionFromBytecode(data []byte) (*bytecode.Transaction, error) {
+	reconstructed, err := c.ReconstructAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := reconstructed.GetFunction(data[:4])
+	if entry == nil {
+		return bytecode.DecodeTransactionFromAbi(data, nil)
+	}
+
+	method, err := entry.ToMethod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert reconstructed function %s: %w", entry.GetSignature(), err)
+	}
+	return bytecode.DecodeTransactionFromMethod(data, method)
+}
+
+// decodeLogFromBytecode decodes the log with the event of the reconstructed ABI matching its first
+// topic, or with the signature database if the bytecode does not emit it.
+func (c *Contract) decodeLogFromBytecode(log *types.Log) (*bytecode.Log, error) {
+	if len(log.Topics) < 1 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	reconstructed, err := c.ReconstructAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	entry := reconstructed.GetEvent(log.Topics[0])
+	if entry == nil {
+		return bytecode.DecodeLogFromAbi(log, nil)
+	}
+
+	event, err := entry.ToEvent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert reconstructed event %s: %w", entry.GetSignature(), err)
+	}
+	return bytecode.DecodeLogFromEvent(log, event)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/unpackdev/solgo/audit"
 	"github.com/unpackdev/solgo/bytecode"
 	"github.com/unpackdev/solgo/detector"
+	"github.com/unpackdev/solgo/heuristics"
 	"github.com/unpackdev/solgo/ir"
+	"github.com/unpackdev/solgo/opcode"
 	"github.com/unpackdev/solgo/providers/etherscan"
 	"github.com/unpackdev/solgo/tokens"
 	"github.com/unpackdev/solgo/utils"
@@ -27,6 +29,7 @@ type Descriptor struct {
 	Transaction       *types.Transaction `json:"transaction,omitempty"`
 	Receipt           *types.Receipt     `json:"receipt,omitempty"`
 	ABI               string             `json:"abi,omitempty"`
+	ReconstructedABI  opcode.Abi         `json:"reconstructed_abi,omitempty"`
 	Name              string             `json:"name,omitempty"`
 	License           string             `json:"license,omitempty"`
 	SolgoVersion      string             `json:"solgo_version,omitempty"`
@@ -56,6 +59,9 @@ type Descriptor struct {
 	Safe                 bool          `json:"safe,omitempty"`
 	Audit                *audit.Report `json:"audit,omitempty"`
 
+	// Heuristics related fields.
+	Heuristics *heuristics.Report `json:"heuristics,omitempty"`
+
 	// Proxy
 	Proxy           bool             `json:"proxy"`
 	Implementations []common.Address `json:"implementations"`
@@ -84,6 +90,11 @@ func (d *Descriptor) HasAudit() bool {
 	return d.Audit != nil
 }
 
+// HasHeuristics checks if a heuristics report is available for the contract.
+func (d *Descriptor) HasHeuristics() bool {
+	return d.Heuristics != nil
+}
+
 // HasMetadata checks if metadata is available for the contract.
 func (d *Descriptor) HasMetadata() bool {
 	return d.Metadata != nil
@@ -129,6 +140,12 @@ func (d *Descriptor) GetAudit() *audit.Report {
 	return d.Audit
 }
 
+// GetHeuristics returns the heuristics report for the contract, if available. This report includes
+// the scam and honeypot patterns found within the contract along with their confidence.
+func (d *Descriptor) GetHeuristics() *heuristics.Report {
+	return d.Heuristics
+}
+
 // GetMetadata returns the contract's metadata, including the compiler version, language, and settings used for compilation.
 func (d *Descriptor) GetMetadata() *bytecode.Metadata {
 	return d.Metadata
@@ -174,6 +191,12 @@ func (d *Descriptor) GetABI() string {
 	return d.ABI
 }
 
+// GetReconstructedABI returns the ABI reconstructed from the deployed bytecode of the contract, which
+// is used when its source code is not verified.
+func (d *Descriptor) GetReconstructedABI() opcode.Abi {
+	return d.ReconstructedABI
+}
+
 // GetLicense returns the software license under which the contract source code is distributed.
 func (d *Descriptor) GetLicense() string {
 	return d.License
@@ -0,0 +1,51 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/unpackdev/solgo/heuristics"
+	"go.uber.org/zap"
+)
+
+// DiscoverHeuristics runs the scam and honeypot heuristics over the contract using its associated
+// detector, if available. When storage is available the values of the state variables the patterns
+// rely on are read at the given block. It updates the contract descriptor with the heuristics report.
+func (c *Contract) DiscoverHeuristics(ctx context.Context, atBlock *big.Int) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	default:
+		if !c.descriptor.HasDetector() || !c.descriptor.HasContracts() {
+			return nil
+		}
+
+		detector := c.descriptor.Detector
+		engine, err := heuristics.NewEngine(ctx, detector.GetIR())
+		if err != nil {
+			zap.L().Debug(
+				"failed to create heuristics engine",
+				zap.Error(err),
+				zap.String("contract_address", c.descriptor.Address.Hex()),
+			)
+			return err
+		}
+
+		if c.stor != nil {
+			reader, err := c.stor.Describe(ctx, c.addr, detector, detector.GetCFG(), atBlock)
+			if err != nil {
+				// Heuristics do not depend on the storage, they are only more accurate with it.
+				zap.L().Debug(
+					"failed to describe contract storage for heuristics",
+					zap.Error(err),
+					zap.String("contract_address", c.descriptor.Address.Hex()),
+				)
+			} else {
+				engine.SetStorage(reader.GetDescriptor())
+			}
+		}
+
+		c.descriptor.Heuristics = engine.Analyze()
+		return nil
+	}
+}
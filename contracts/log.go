@@ -92,6 +92,19 @@ func (c *Contract) DecodeLog(ctx context.Context, log *types.Log) (*Log, error)
 			return toReturn, nil
 		}
 
-		return nil, fmt.Errorf("failed to decode log from abi: %s", "signature not found")
+		// Unverified contracts are decoded with the ABI reconstructed from their bytecode.
+		logData, err := c.decodeLogFromBytecode(log)
+		if err != nil {
+			zap.L().Debug(
+				"failed to decode log from reconstructed abi",
+				zap.Error(err),
+				zap.Any("network", c.network),
+				zap.String("contract_address", c.addr.String()),
+				zap.String("decode_type", "from_bytecode"),
+			)
+			return nil, fmt.Errorf("failed to decode log from abi: %s", "signature not found")
+		}
+		toReturn.DecodedLog = logData
+		return toReturn, nil
 	}
 }
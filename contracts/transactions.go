@@ -74,8 +74,23 @@ func (c *Contract) DecodeTransaction(ctx context.Context, data []byte) (*bytecod
 			return transaction, nil
 		}
 
-		// Signature itself is not found due to any of the above reasons...
-		// What you could do is have post hook to figure out based on your own signature logic.
-		return nil, fmt.Errorf("failed to decode transaction from abi: %s", "signature not found")
+		// Unverified contracts are decoded with the ABI reconstructed from their bytecode.
+		transaction, err := c.decodeTransactionFromBytecode(data)
+		if err != nil {
+			zap.L().Debug(
+				"failed to decode transaction from reconstructed abi",
+				zap.Error(err),
+				zap.Any("network", c.network),
+				zap.String("contract_address", c.addr.String()),
+				zap.Binary("method_signature_data", methodSigData),
+				zap.String("decode_type", "from_bytecode"),
+			)
+
+			// Signature itself is not found due to any of the above reasons...
+			// What you could do is have post hook to figure out based on your own signature logic.
+			return nil, fmt.Errorf("failed to decode transaction from abi: %s", "signature not found")
+		}
+
+		return transaction, nil
 	}
 }
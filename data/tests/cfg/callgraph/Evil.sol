@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Base {
+    function run() public {
+        hook();
+    }
+
+    function hook() internal virtual {}
+}
+
+contract Middle is Base {
+    function hook() internal virtual override {
+        super.hook();
+    }
+}
+
+contract Evil is Middle {
+    function hook() internal override {
+        super.hook();
+        selfdestruct(payable(msg.sender));
+    }
+}
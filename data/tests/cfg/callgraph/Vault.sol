@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IStrategy {
+    function run() external;
+}
+
+library MathLib {
+    function add(uint256 a, uint256 b) internal pure returns (uint256) {
+        return a + b;
+    }
+}
+
+contract StrategyA is IStrategy {
+    function run() external override {}
+}
+
+contract StrategyB is IStrategy {
+    function run() external override {}
+}
+
+contract Base {
+    address internal owner;
+
+    function hook() internal virtual {}
+
+    function kill() internal {
+        selfdestruct(payable(owner));
+    }
+}
+
+contract Vault is Base {
+    using MathLib for uint256;
+
+    IStrategy internal strategy;
+
+    modifier onlyOwner() {
+        check();
+        _;
+    }
+
+    function check() internal view {
+        require(msg.sender == owner, "not owner");
+    }
+
+    function hook() internal override {
+        super.hook();
+    }
+
+    function emergency() public onlyOwner {
+        hook();
+        kill();
+    }
+
+    function invest() external {
+        strategy.run();
+    }
+
+    function forward(address implementation) external returns (bool ok) {
+        (ok, ) = implementation.delegatecall("");
+    }
+
+    function sum(uint256 value) public pure returns (uint256) {
+        return value.add(1);
+    }
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+interface IOracle {
+    function price() external view returns (uint256);
+}
+
+contract Vault {
+    address internal owner;
+    uint256 internal total;
+    IOracle internal oracle;
+
+    error Empty();
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "not owner");
+        _;
+        total += 1;
+    }
+
+    function sum(uint256[] memory values) public onlyOwner returns (uint256 result) {
+        for (uint256 i = 0; i < values.length; i++) {
+            if (values[i] == 0) {
+                continue;
+            }
+            if (values[i] > 100) {
+                break;
+            }
+            result += values[i];
+        }
+        return result;
+    }
+
+    function drain(uint256 amount) public {
+        while (amount > 0) {
+            amount -= 1;
+        }
+        do {
+            amount += 1;
+        } while (amount < 10);
+        if (amount == 0) {
+            revert Empty();
+        }
+        total = amount;
+    }
+
+    function quote() public view returns (uint256) {
+        try oracle.price() returns (uint256 value) {
+            return value;
+        } catch {
+            revert("oracle");
+        }
+    }
+
+    function select(uint256 value) public pure returns (uint256 out) {
+        assembly {
+            function half(x) -> y {
+                if iszero(x) { leave }
+                y := div(x, 2)
+            }
+            switch value
+            case 0 { revert(0, 0) }
+            case 1 { out := half(value) }
+            default { out := value }
+        }
+    }
+}